@@ -17,11 +17,13 @@ package backend
 import (
 	"go.opencensus.io/stats"
 	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
 	"google.golang.org/grpc"
 	"open-match.dev/open-match/internal/appmain"
 	"open-match.dev/open-match/internal/rpc"
 	"open-match.dev/open-match/internal/statestore"
 	"open-match.dev/open-match/internal/telemetry"
+	"open-match.dev/open-match/internal/tenant"
 	"open-match.dev/open-match/pkg/pb"
 )
 
@@ -30,45 +32,74 @@ var (
 	ticketsPerMatch    = stats.Int64("open-match.dev/backend/tickets_per_match", "Number of tickets per match", stats.UnitDimensionless)
 	ticketsReleased    = stats.Int64("open-match.dev/backend/tickets_released", "Number of tickets released per request", stats.UnitDimensionless)
 	ticketsAssigned    = stats.Int64("open-match.dev/backend/tickets_assigned", "Number of tickets assigned per request", stats.UnitDimensionless)
+	timeToMatch        = stats.Float64("open-match.dev/backend/time_to_match", "Time from the creation of tickets to their match", stats.UnitMilliseconds)
+	timeToAssignment   = stats.Float64("open-match.dev/backend/time_to_assignment", "Time from the creation of tickets to their assignment", stats.UnitMilliseconds)
+
+	profileTag  = tag.MustNewKey("profile")
+	functionTag = tag.MustNewKey("function")
 
 	totalMatchesView = &view.View{
 		Measure:     totalBytesPerMatch,
 		Name:        "open-match.dev/backend/total_matches",
 		Description: "Total number of matches",
 		Aggregation: view.Count(),
+		TagKeys:     []tag.Key{tenant.TagKey},
 	}
 	totalBytesPerMatchView = &view.View{
 		Measure:     totalBytesPerMatch,
 		Name:        "open-match.dev/backend/total_bytes_per_match",
 		Description: "Total bytes per match",
 		Aggregation: telemetry.DefaultBytesDistribution,
+		TagKeys:     []tag.Key{tenant.TagKey},
 	}
 	ticketsPerMatchView = &view.View{
 		Measure:     ticketsPerMatch,
 		Name:        "open-match.dev/backend/tickets_per_match",
 		Description: "Tickets per ticket",
 		Aggregation: telemetry.DefaultCountDistribution,
+		TagKeys:     []tag.Key{tenant.TagKey},
 	}
 	ticketsAssignedView = &view.View{
 		Measure:     ticketsAssigned,
 		Name:        "open-match.dev/backend/tickets_assigned",
 		Description: "Number of tickets assigned per request",
 		Aggregation: view.Sum(),
+		TagKeys:     []tag.Key{tenant.TagKey},
 	}
 	ticketsReleasedView = &view.View{
 		Measure:     ticketsReleased,
 		Name:        "open-match.dev/backend/tickets_released",
 		Description: "Number of tickets released per request",
 		Aggregation: view.Sum(),
+		TagKeys:     []tag.Key{tenant.TagKey},
+	}
+	timeToMatchView = &view.View{
+		Measure:     timeToMatch,
+		Name:        "open-match.dev/backend/time_to_match",
+		Description: "Time from the creation of tickets to their match",
+		Aggregation: telemetry.DefaultWaitTimeDistribution,
+		TagKeys:     []tag.Key{tenant.TagKey, profileTag, functionTag},
+	}
+	timeToAssignmentView = &view.View{
+		Measure:     timeToAssignment,
+		Name:        "open-match.dev/backend/time_to_assignment",
+		Description: "Time from the creation of tickets to their assignment",
+		Aggregation: telemetry.DefaultWaitTimeDistribution,
+		TagKeys:     []tag.Key{tenant.TagKey, profileTag, functionTag},
 	}
 )
 
 // BindService creates the backend service and binds it to the serving harness.
 func BindService(p *appmain.Params, b *appmain.Bindings) error {
+	store := statestore.New(p.Config())
+	cc := rpc.NewClientCache(p.Config())
 	service := &backendService{
-		synchronizer: newSynchronizerClient(p.Config()),
-		store:        statestore.New(p.Config()),
-		cc:           rpc.NewClientCache(p.Config()),
+		cfg:          p.Config(),
+		synchronizer: newSynchronizerClient(p.Config(), store, cc),
+		store:        store,
+		cc:           cc,
+		wasm:         newWasmRunner(p.Config(), store),
+		audit:        p.Audit(),
 	}
 
 	b.AddHealthCheckFunc(service.store.HealthCheck)
@@ -81,6 +112,8 @@ func BindService(p *appmain.Params, b *appmain.Bindings) error {
 		ticketsPerMatchView,
 		ticketsAssignedView,
 		ticketsReleasedView,
+		timeToMatchView,
+		timeToAssignmentView,
 	)
 	return nil
 }
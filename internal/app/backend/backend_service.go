@@ -20,13 +20,17 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
 
 	"github.com/golang/protobuf/jsonpb"
 	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/sync/errgroup"
@@ -34,18 +38,24 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"open-match.dev/open-match/internal/appmain/contextcause"
+	"open-match.dev/open-match/internal/audit"
+	"open-match.dev/open-match/internal/config"
 	"open-match.dev/open-match/internal/ipb"
 	"open-match.dev/open-match/internal/rpc"
 	"open-match.dev/open-match/internal/statestore"
+	"open-match.dev/open-match/internal/tenant"
 	"open-match.dev/open-match/pkg/pb"
 )
 
 // The service implementing the Backend API that is called to generate matches
 // and make assignments for Tickets.
 type backendService struct {
+	cfg          config.View
 	synchronizer *synchronizerClient
 	store        statestore.Service
 	cc           *rpc.ClientCache
+	wasm         *wasmRunner
+	audit        *audit.Logger
 }
 
 var (
@@ -66,10 +76,24 @@ func (s *backendService) FetchMatches(req *pb.FetchMatchesRequest, stream pb.Bac
 	if req.Profile == nil {
 		return status.Error(codes.InvalidArgument, ".profile is required")
 	}
+	if req.Config.Type == pb.FunctionConfig_WASM && req.Config.WasmModule == "" {
+		return status.Error(codes.InvalidArgument, ".config.wasm_module is required for WASM match functions")
+	}
+	if req.ReadyCheck != nil {
+		timeout, err := ptypes.Duration(req.ReadyCheck.Timeout)
+		if err != nil || timeout <= 0 {
+			return status.Error(codes.InvalidArgument, ".ready_check.timeout must be positive")
+		}
+	}
+
+	shard, err := s.synchronizer.shardFor(req.Profile)
+	if err != nil {
+		return err
+	}
 
 	// Error group for handling the synchronizer calls only.
 	eg, ctx := errgroup.WithContext(stream.Context())
-	syncStream, err := s.synchronizer.synchronize(ctx)
+	syncStream, err := s.synchronizer.synchronize(ctx, shard)
 	if err != nil {
 		return err
 	}
@@ -82,12 +106,16 @@ func (s *backendService) FetchMatches(req *pb.FetchMatchesRequest, stream pb.Bac
 	startMmfs := make(chan struct{})
 	proposals := make(chan *pb.Match)
 	m := &sync.Map{}
+	// Ids of the sent matches awaiting their ready check.
+	var readyChecks []string
 
 	eg.Go(func() error {
 		return synchronizeSend(ctx, syncStream, m, proposals)
 	})
 	eg.Go(func() error {
-		return synchronizeRecv(ctx, syncStream, m, stream, startMmfs, cancelMmfs)
+		var err error
+		readyChecks, err = synchronizeRecv(ctx, syncStream, m, stream, startMmfs, cancelMmfs, s.store, req)
+		return err
 	})
 
 	var mmfErr error
@@ -95,7 +123,7 @@ func (s *backendService) FetchMatches(req *pb.FetchMatchesRequest, stream pb.Bac
 	case <-mmfCtx.Done():
 		mmfErr = fmt.Errorf("mmf was never started")
 	case <-startMmfs:
-		mmfErr = callMmf(mmfCtx, s.cc, req, proposals)
+		mmfErr = callMmf(mmfCtx, s.cc, s.wasm, req, proposals)
 	}
 
 	syncErr := eg.Wait()
@@ -114,7 +142,7 @@ func (s *backendService) FetchMatches(req *pb.FetchMatchesRequest, stream pb.Bac
 		)
 	}
 
-	return nil
+	return awaitReadyChecks(stream.Context(), s.cfg, s.store, stream, readyChecks)
 }
 
 func synchronizeSend(ctx context.Context, syncStream synchronizerStream, m *sync.Map, proposals <-chan *pb.Match) error {
@@ -145,16 +173,19 @@ sendProposals:
 	return nil
 }
 
-func synchronizeRecv(ctx context.Context, syncStream synchronizerStream, m *sync.Map, stream pb.BackendService_FetchMatchesServer, startMmfs chan<- struct{}, cancelMmfs contextcause.CancelErrFunc) error {
+// synchronizeRecv streams the matches back to the caller, returning the ids of
+// those awaiting a ready check.
+func synchronizeRecv(ctx context.Context, syncStream synchronizerStream, m *sync.Map, stream pb.BackendService_FetchMatchesServer, startMmfs chan<- struct{}, cancelMmfs contextcause.CancelErrFunc, store statestore.Service, req *pb.FetchMatchesRequest) ([]string, error) {
 	var startMmfsOnce sync.Once
+	var readyChecks []string
 
 	for {
 		resp, err := syncStream.Recv()
 		if err == io.EOF {
-			return nil
+			return readyChecks, nil
 		}
 		if err != nil {
-			return fmt.Errorf("error receiving match from synchronizer: %w", err)
+			return nil, fmt.Errorf("error receiving match from synchronizer: %w", err)
 		}
 
 		if resp.StartMmfs {
@@ -167,23 +198,176 @@ func synchronizeRecv(ctx context.Context, syncStream synchronizerStream, m *sync
 			cancelMmfs(errors.New("match function ran longer than proposal window, canceling"))
 		}
 
+		if r := resp.GetRejectedMatch(); r != nil {
+			if !req.GetIncludeRejectedProposals() {
+				continue
+			}
+			v, ok := m.Load(r.GetMatchId())
+			if !ok {
+				continue
+			}
+			err = stream.Send(&pb.FetchMatchesResponse{
+				RejectedProposal: &pb.RejectedProposal{
+					Proposal:  v.(*pb.Match),
+					Rejection: r,
+				},
+			})
+			if err != nil {
+				return nil, fmt.Errorf("error sending rejected proposal to caller of backend: %w", err)
+			}
+			continue
+		}
+
 		if v, ok := m.Load(resp.GetMatchId()); ok {
 			match, ok := v.(*pb.Match)
 			if !ok {
-				return fmt.Errorf("error casting sync map value into *pb.Match: %w", err)
+				return nil, fmt.Errorf("error casting sync map value into *pb.Match: %w", err)
 			}
 			stats.Record(ctx, totalBytesPerMatch.M(int64(proto.Size(match))))
 			stats.Record(ctx, ticketsPerMatch.M(int64(len(match.GetTickets()))))
+			now := time.Now()
+			recordTimeToMatch(ctx, match, now)
+			err = recordMatch(ctx, store, req, match, now)
+			if err == nil && req.GetReadyCheck() != nil {
+				readyChecks = append(readyChecks, match.GetMatchId())
+			}
 			err = stream.Send(&pb.FetchMatchesResponse{Match: match})
 			if err != nil {
-				return fmt.Errorf("error sending match to caller of backend: %w", err)
+				return nil, fmt.Errorf("error sending match to caller of backend: %w", err)
+			}
+		}
+	}
+}
+
+// recordMatch persists the match so that it can be resumed if the caller is
+// lost, along with the lifecycles of its tickets, in a single round trip.
+// Failing to do so doesn't fail the call, as the caller can still handle the
+// match itself, but the match's ready check can't happen.
+func recordMatch(ctx context.Context, store statestore.Service, req *pb.FetchMatchesRequest, match *pb.Match, now time.Time) error {
+	created, err := ptypes.TimestampProto(now)
+	if err != nil {
+		return err
+	}
+	record := &pb.MatchRecord{
+		Match:      match,
+		Profile:    req.GetProfile(),
+		Config:     req.GetConfig(),
+		State:      pb.MatchRecord_UNASSIGNED,
+		CreateTime: created,
+	}
+	if rc := req.GetReadyCheck(); rc != nil {
+		// Validated by FetchMatches.
+		timeout, _ := ptypes.Duration(rc.GetTimeout())
+		deadline, err := ptypes.TimestampProto(now.Add(timeout))
+		if err != nil {
+			return err
+		}
+		record.State = pb.MatchRecord_AWAITING_READY
+		record.ReadyCheck = rc
+		record.ReadyDeadline = deadline
+	}
+
+	err = store.CreateMatch(ctx, record)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"matchId": match.GetMatchId(),
+			"error":   err.Error(),
+		}).Error("failed to record match")
+	}
+	return err
+}
+
+// recordTimeToMatch records the time the tickets of the match waited to be
+// matched.
+func recordTimeToMatch(ctx context.Context, match *pb.Match, now time.Time) {
+	ctx, err := tag.New(ctx, tag.Upsert(profileTag, match.GetMatchProfile()), tag.Upsert(functionTag, match.GetMatchFunction()))
+	if err != nil {
+		return
+	}
+	for _, t := range match.GetTickets() {
+		// Match functions may not return the create time of tickets.
+		created, err := ptypes.Timestamp(t.GetCreateTime())
+		if err != nil {
+			continue
+		}
+		stats.Record(ctx, timeToMatch.M(milliseconds(now.Sub(created))))
+	}
+}
+
+// recordTicketsAssigned records the assignment in the lifecycles of the
+// tickets, and the time they waited to be assigned.
+func recordTicketsAssigned(ctx context.Context, store statestore.Service, ids []string, failures []*pb.AssignmentFailure) {
+	failed := make(map[string]struct{}, len(failures))
+	for _, f := range failures {
+		failed[f.GetTicketId()] = struct{}{}
+	}
+	now := time.Now()
+	times := make(map[string]time.Time, len(ids))
+	assigned := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if _, ok := failed[id]; !ok {
+			times[id] = now
+			assigned = append(assigned, id)
+		}
+	}
+
+	err := store.RecordTicketEvents(ctx, statestore.TicketAssigned, times)
+	if err != nil {
+		logger.WithError(err).Error("failed to record the assigned tickets")
+	}
+	lifecycles, err := store.GetTicketLifecycles(ctx, assigned)
+	if err != nil {
+		logger.WithError(err).Error("failed to get the lifecycles of the assigned tickets")
+		return
+	}
+	for _, l := range lifecycles {
+		created, ok := l.Times[statestore.TicketCreated]
+		if !ok {
+			continue
+		}
+		_ = stats.RecordWithTags(ctx, []tag.Mutator{tag.Upsert(profileTag, l.Profile), tag.Upsert(functionTag, l.Function)},
+			timeToAssignment.M(milliseconds(now.Sub(created))))
+	}
+}
+
+// auditAssignments records an event for each group of assigned tickets.  The
+// tickets which failed to be assigned are listed in the failedTicketIds detail,
+// instead of the ticket ids.
+func (s *backendService) auditAssignments(ctx context.Context, operation string, matchID string, groups []*pb.AssignmentGroup, failures []*pb.AssignmentFailure, err error) {
+	failed := make(map[string]struct{}, len(failures))
+	for _, f := range failures {
+		failed[f.GetTicketId()] = struct{}{}
+	}
+	for _, g := range groups {
+		e := audit.Event{
+			Operation: operation,
+			Details:   map[string]string{"connection": g.GetAssignment().GetConnection()},
+			Error:     audit.ErrorOf(err),
+		}
+		if matchID != "" {
+			e.MatchIDs = []string{matchID}
+		}
+		var failedIDs []string
+		for _, id := range g.GetTicketIds() {
+			if _, ok := failed[id]; ok {
+				failedIDs = append(failedIDs, id)
+				continue
 			}
+			e.TicketIDs = append(e.TicketIDs, id)
+		}
+		if len(failedIDs) > 0 {
+			e.Details["failedTicketIds"] = strings.Join(failedIDs, ",")
 		}
+		s.audit.Log(ctx, e)
 	}
 }
 
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
 // callMmf triggers execution of MMFs to fetch match proposals.
-func callMmf(ctx context.Context, cc *rpc.ClientCache, req *pb.FetchMatchesRequest, proposals chan<- *pb.Match) error {
+func callMmf(ctx context.Context, cc *rpc.ClientCache, wr *wasmRunner, req *pb.FetchMatchesRequest, proposals chan<- *pb.Match) error {
 	defer close(proposals)
 	address := fmt.Sprintf("%s:%d", req.GetConfig().GetHost(), req.GetConfig().GetPort())
 
@@ -192,6 +376,8 @@ func callMmf(ctx context.Context, cc *rpc.ClientCache, req *pb.FetchMatchesReque
 		return callGrpcMmf(ctx, cc, req.GetProfile(), address, proposals)
 	case pb.FunctionConfig_REST:
 		return callHTTPMmf(ctx, cc, req.GetProfile(), address, proposals)
+	case pb.FunctionConfig_WASM:
+		return wr.run(ctx, req.GetProfile(), req.GetConfig().GetWasmModule(), proposals)
 	default:
 		return status.Error(codes.InvalidArgument, "provided match function type is not supported")
 	}
@@ -262,6 +448,9 @@ func callHTTPMmf(ctx context.Context, cc *rpc.ClientCache, profile *pb.MatchProf
 	if err != nil {
 		return status.Errorf(codes.FailedPrecondition, "failed to create mmf http request for profile %s: %s", profile.GetName(), err.Error())
 	}
+	if name := tenant.FromContext(ctx); name != "" {
+		req.Header.Set(tenant.MetadataKey, name)
+	}
 
 	resp, err := client.Do(req.WithContext(ctx))
 	if err != nil {
@@ -305,12 +494,130 @@ func callHTTPMmf(ctx context.Context, cc *rpc.ClientCache, profile *pb.MatchProf
 	return nil
 }
 
+// UploadMatchFunctionModule validates and stores a WebAssembly match function
+// module, so that it can be run by FetchMatches calls on any backend.
+func (s *backendService) UploadMatchFunctionModule(ctx context.Context, req *pb.UploadMatchFunctionModuleRequest) (*pb.UploadMatchFunctionModuleResponse, error) {
+	if req.GetName() == "" {
+		return nil, status.Error(codes.InvalidArgument, ".name is required")
+	}
+	if len(req.GetModule()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, ".module is required")
+	}
+
+	err := s.wasm.validate(ctx, req.GetModule())
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.store.SetMatchFunctionModule(ctx, req.GetName(), req.GetModule())
+	if err != nil {
+		logger.WithError(err).Error("failed to store match function module")
+		return nil, err
+	}
+	return &pb.UploadMatchFunctionModuleResponse{}, nil
+}
+
+// GetMatch gets the record of a match returned by FetchMatches.
+func (s *backendService) GetMatch(ctx context.Context, req *pb.GetMatchRequest) (*pb.MatchRecord, error) {
+	if req.GetMatchId() == "" {
+		return nil, status.Error(codes.InvalidArgument, ".match_id is required")
+	}
+	return s.store.GetMatch(ctx, req.GetMatchId())
+}
+
+// ListMatches lists the records of unassigned matches, optionally only those of
+// a single profile.
+func (s *backendService) ListMatches(ctx context.Context, req *pb.ListMatchesRequest) (*pb.ListMatchesResponse, error) {
+	records, err := s.store.GetUnassignedMatches(ctx)
+	if err != nil {
+		logger.WithError(err).Error("failed to list unassigned matches")
+		return nil, err
+	}
+
+	resp := &pb.ListMatchesResponse{}
+	for _, r := range records {
+		if req.GetProfileName() == "" || r.GetProfile().GetName() == req.GetProfileName() {
+			resp.Matches = append(resp.Matches, r)
+		}
+	}
+	// Oldest first, as those are closest to expiring.
+	sort.Slice(resp.Matches, func(i, j int) bool {
+		a, b := resp.Matches[i].GetCreateTime(), resp.Matches[j].GetCreateTime()
+		if a.GetSeconds() != b.GetSeconds() {
+			return a.GetSeconds() < b.GetSeconds()
+		}
+		return a.GetNanos() < b.GetNanos()
+	})
+	return resp, nil
+}
+
+// AssignMatch assigns the tickets of an unassigned match.  Tickets which can't
+// be assigned are returned as failures, and don't prevent assigning the others.
+func (s *backendService) AssignMatch(ctx context.Context, req *pb.AssignMatchRequest) (*pb.AssignMatchResponse, error) {
+	if req.GetMatchId() == "" {
+		return nil, status.Error(codes.InvalidArgument, ".match_id is required")
+	}
+	if req.GetAssignment() == nil {
+		return nil, status.Error(codes.InvalidArgument, ".assignment is required")
+	}
+
+	// The tickets are assigned and the match recorded as assigned at once, so
+	// that a failure can't leave one without the other.
+	record, failures, err := s.store.AssignMatch(ctx, req.GetMatchId(), req.GetAssignment())
+	ids := getTicketIds(record.GetMatch())
+	groups := []*pb.AssignmentGroup{{TicketIds: ids, Assignment: req.GetAssignment()}}
+	s.auditAssignments(ctx, audit.AssignMatch, req.GetMatchId(), groups, failures, err)
+	if err != nil {
+		return nil, err
+	}
+	recordTicketsAssigned(ctx, s.store, ids, failures)
+
+	stats.Record(ctx, ticketsAssigned.M(int64(len(ids))))
+	return &pb.AssignMatchResponse{Failures: failures}, nil
+}
+
+// ReleaseMatch releases all of the tickets of an unassigned match.
+func (s *backendService) ReleaseMatch(ctx context.Context, req *pb.ReleaseMatchRequest) (*pb.ReleaseMatchResponse, error) {
+	if req.GetMatchId() == "" {
+		return nil, status.Error(codes.InvalidArgument, ".match_id is required")
+	}
+
+	record, err := s.store.ReleaseMatch(ctx, req.GetMatchId())
+	ids := getTicketIds(record.GetMatch())
+	s.audit.Log(ctx, audit.Event{
+		Operation: audit.ReleaseMatch,
+		TicketIDs: ids,
+		MatchIDs:  []string{req.GetMatchId()},
+		Error:     audit.ErrorOf(err),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	stats.Record(ctx, ticketsReleased.M(int64(len(ids))))
+	return &pb.ReleaseMatchResponse{}, nil
+}
+
+func getTicketIds(m *pb.Match) []string {
+	ids := make([]string, 0, len(m.GetTickets()))
+	for _, t := range m.GetTickets() {
+		ids = append(ids, t.GetId())
+	}
+	return ids
+}
+
 func (s *backendService) ReleaseTickets(ctx context.Context, req *pb.ReleaseTicketsRequest) (*pb.ReleaseTicketsResponse, error) {
 	err := doReleasetickets(ctx, req, s.store)
+	s.audit.Log(ctx, audit.Event{
+		Operation: audit.ReleaseTickets,
+		TicketIDs: req.GetTicketIds(),
+		Error:     audit.ErrorOf(err),
+	})
 	if err != nil {
 		logger.WithError(err).Error("failed to remove the awaiting tickets from the ignore list for requested tickets")
 		return nil, err
 	}
+	updateMatchesOfTickets(ctx, s.store, req.GetTicketIds(), pb.MatchRecord_RELEASED)
 
 	stats.Record(ctx, ticketsReleased.M(int64(len(req.TicketIds))))
 	return &pb.ReleaseTicketsResponse{}, nil
@@ -318,6 +625,10 @@ func (s *backendService) ReleaseTickets(ctx context.Context, req *pb.ReleaseTick
 
 func (s *backendService) ReleaseAllTickets(ctx context.Context, req *pb.ReleaseAllTicketsRequest) (*pb.ReleaseAllTicketsResponse, error) {
 	err := s.store.ReleaseAllTickets(ctx)
+	s.audit.Log(ctx, audit.Event{
+		Operation: audit.ReleaseAllTickets,
+		Error:     audit.ErrorOf(err),
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -327,20 +638,55 @@ func (s *backendService) ReleaseAllTickets(ctx context.Context, req *pb.ReleaseA
 // AssignTickets overwrites the Assignment field of the input TicketIds.
 func (s *backendService) AssignTickets(ctx context.Context, req *pb.AssignTicketsRequest) (*pb.AssignTicketsResponse, error) {
 	resp, err := doAssignTickets(ctx, req, s.store)
+	s.auditAssignments(ctx, audit.AssignTickets, "", req.GetAssignments(), resp.GetFailures(), err)
 	if err != nil {
 		logger.WithError(err).Error("failed to update assignments for requested tickets")
 		return nil, err
 	}
 
+	failed := map[string]struct{}{}
+	for _, f := range resp.GetFailures() {
+		failed[f.GetTicketId()] = struct{}{}
+	}
 	numIds := 0
+	assigned := []string{}
 	for _, ag := range req.Assignments {
 		numIds += len(ag.TicketIds)
+		for _, id := range ag.GetTicketIds() {
+			if _, ok := failed[id]; !ok {
+				assigned = append(assigned, id)
+			}
+		}
 	}
+	updateMatchesOfTickets(ctx, s.store, assigned, pb.MatchRecord_ASSIGNED)
 
 	stats.Record(ctx, ticketsAssigned.M(int64(numIds)))
 	return resp, nil
 }
 
+// updateMatchesOfTickets moves the unassigned latest matches of the tickets,
+// handled by ticket id rather than by match, to the state so that ListMatches
+// doesn't return them anymore.  Matches which were already handled are left
+// alone, and failing to update the others doesn't fail the call.
+func updateMatchesOfTickets(ctx context.Context, store statestore.Service, ticketIDs []string, to pb.MatchRecord_State) {
+	matchIDs, err := store.GetTicketMatchIDs(ctx, ticketIDs)
+	if err != nil {
+		logger.WithError(err).Error("failed to get the matches of the tickets")
+		return
+	}
+	for _, id := range matchIDs {
+		_, err = store.UpdateMatchState(ctx, id, pb.MatchRecord_UNASSIGNED, to)
+		switch status.Code(err) {
+		case codes.OK, codes.NotFound, codes.FailedPrecondition:
+		default:
+			logger.WithFields(logrus.Fields{
+				"matchId": id,
+				"error":   err.Error(),
+			}).Error("failed to update the match of the tickets")
+		}
+	}
+}
+
 func doAssignTickets(ctx context.Context, req *pb.AssignTicketsRequest, store statestore.Service) (*pb.AssignTicketsResponse, error) {
 	resp, err := store.UpdateAssignments(ctx, req)
 	if err != nil {
@@ -354,6 +700,8 @@ func doAssignTickets(ctx context.Context, req *pb.AssignTicketsRequest, store st
 		ids = append(ids, ag.TicketIds...)
 	}
 
+	recordTicketsAssigned(ctx, store, ids, resp.GetFailures())
+
 	for _, id := range ids {
 		err = store.DeindexTicket(ctx, id)
 		// Try to deindex all input tickets. Log without returning an error if the deindexing operation failed.
@@ -0,0 +1,121 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backend
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"open-match.dev/open-match/internal/config"
+	"open-match.dev/open-match/internal/readycheck"
+	"open-match.dev/open-match/internal/statestore"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+const (
+	// configNameReadyCheckPollInterval is how often the records of matches
+	// awaiting their ready check are read.
+	configNameReadyCheckPollInterval = "backend.readyCheckPollInterval"
+	defaultReadyCheckPollInterval    = 50 * time.Millisecond
+)
+
+// awaitReadyChecks streams back the result of the ready check of each match
+// once it is known, timing out the ready checks past their deadline.
+func awaitReadyChecks(ctx context.Context, cfg config.View, store statestore.Service, stream pb.BackendService_FetchMatchesServer, matchIDs []string) error {
+	if len(matchIDs) == 0 {
+		return nil
+	}
+
+	interval := cfg.GetDuration(configNameReadyCheckPollInterval)
+	if interval <= 0 {
+		interval = defaultReadyCheckPollInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		// The records of all pending matches are read in a single round trip.
+		records, err := store.GetMatches(ctx, matchIDs)
+		if err != nil {
+			return err
+		}
+		pending := matchIDs[:0]
+		for _, id := range matchIDs {
+			result, err := readyCheckResult(ctx, store, id, records[id])
+			if err != nil {
+				return err
+			}
+			if result == nil {
+				pending = append(pending, id)
+				continue
+			}
+			err = stream.Send(&pb.FetchMatchesResponse{ReadyCheckResult: result})
+			if err != nil {
+				return fmt.Errorf("error sending ready check result to caller of backend: %w", err)
+			}
+		}
+		matchIDs = pending
+		if len(matchIDs) == 0 {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// readyCheckResult returns the result of the ready check of the match given
+// its record, or nil if it is still pending.
+func readyCheckResult(ctx context.Context, store statestore.Service, matchID string, record *pb.MatchRecord) (*pb.ReadyCheckResult, error) {
+	if record == nil {
+		// The record expired before the ready check could complete.
+		return &pb.ReadyCheckResult{MatchId: matchID, Outcome: pb.ReadyCheckResult_TIMED_OUT}, nil
+	}
+
+	outcome := pb.ReadyCheckResult_DECLINED
+	if record.GetState() == pb.MatchRecord_AWAITING_READY {
+		deadline, err := ptypes.Timestamp(record.GetReadyDeadline())
+		if err == nil && time.Now().Before(deadline) {
+			return nil, nil
+		}
+		var expired bool
+		record, expired, err = readycheck.Expire(ctx, store, matchID)
+		if err != nil {
+			return nil, err
+		}
+		if expired {
+			outcome = pb.ReadyCheckResult_TIMED_OUT
+		}
+	}
+
+	switch record.GetState() {
+	case pb.MatchRecord_AWAITING_READY:
+		return nil, nil
+	case pb.MatchRecord_DECLINED:
+		return &pb.ReadyCheckResult{
+			MatchId:           matchID,
+			Outcome:           outcome,
+			DeclinedTicketIds: record.GetDeclinedTicketIds(),
+		}, nil
+	default:
+		// The match may already be assigned or released by the time it is read.
+		return &pb.ReadyCheckResult{MatchId: matchID, Outcome: pb.ReadyCheckResult_READY}, nil
+	}
+}
@@ -2,17 +2,43 @@ package backend
 
 import (
 	"context"
+	"strings"
+	"time"
 
+	"github.com/cenkalti/backoff"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"open-match.dev/open-match/internal/config"
 	"open-match.dev/open-match/internal/ipb"
 	"open-match.dev/open-match/internal/rpc"
+	"open-match.dev/open-match/internal/statestore"
+	"open-match.dev/open-match/pkg/pb"
 )
 
+const (
+	configNameSynchronizerLeaderElectionEnabled = "synchronizer.leaderElection.enabled"
+	configNameSynchronizerLeaseDuration         = "synchronizer.leaderElection.leaseDuration"
+	// configNameShardSeparator, when set, makes the part of a profile's name
+	// before the separator its shard.
+	configNameShardSeparator = "backend.shardSeparator"
+	// shardExtension is the profile extension, a google.protobuf.StringValue,
+	// which names the profile's shard.  It takes precedence over the name.
+	shardExtension = "shard"
+)
+
+// synchronizerClient connects to the synchronizer at api.synchronizer, or when
+// synchronizer leader election is enabled, to the replica holding the lease.
 type synchronizerClient struct {
+	cfg    config.View
+	store  statestore.Service
+	cc     *rpc.ClientCache
 	cacher *config.Cacher
 }
 
-func newSynchronizerClient(cfg config.View) *synchronizerClient {
+func newSynchronizerClient(cfg config.View, store statestore.Service, cc *rpc.ClientCache) *synchronizerClient {
 	newInstance := func(cfg config.View) (interface{}, func(), error) {
 		conn, err := rpc.GRPCClientFromConfig(cfg, "api.synchronizer")
 		if err != nil {
@@ -30,6 +56,9 @@ func newSynchronizerClient(cfg config.View) *synchronizerClient {
 	}
 
 	return &synchronizerClient{
+		cfg:    cfg,
+		store:  store,
+		cc:     cc,
 		cacher: config.NewCacher(cfg, newInstance),
 	}
 }
@@ -40,10 +69,142 @@ type synchronizerStream interface {
 	CloseSend() error
 }
 
-func (sc *synchronizerClient) synchronize(ctx context.Context) (synchronizerStream, error) {
+// shardFor returns the synchronizer shard the profile's proposals are
+// synchronized in.  Profiles of different shards run independent cycles.
+func (sc *synchronizerClient) shardFor(profile *pb.MatchProfile) (string, error) {
+	if a, ok := profile.GetExtensions()[shardExtension]; ok {
+		v := &wrappers.StringValue{}
+		err := ptypes.UnmarshalAny(a, v)
+		if err != nil {
+			return "", status.Errorf(codes.InvalidArgument, "profile extension %s must be a google.protobuf.StringValue: %s", shardExtension, err.Error())
+		}
+		return v.GetValue(), nil
+	}
+
+	if sep := sc.cfg.GetString(configNameShardSeparator); sep != "" {
+		if i := strings.Index(profile.GetName(), sep); i >= 0 {
+			return profile.GetName()[:i], nil
+		}
+	}
+	return "", nil
+}
+
+// synchronize starts a stream registered in the shard's cycle.
+func (sc *synchronizerClient) synchronize(ctx context.Context, shard string) (synchronizerStream, error) {
+	if sc.cfg.GetBool(configNameSynchronizerLeaderElectionEnabled) {
+		return sc.synchronizeWithLeader(ctx, shard)
+	}
+
 	client, err := sc.cacher.Get()
 	if err != nil {
 		return nil, err
 	}
-	return client.(ipb.SynchronizerClient).Synchronize(ctx)
+	stream, err := client.(ipb.SynchronizerClient).Synchronize(ctx)
+	if err != nil {
+		return nil, err
+	}
+	err = stream.Send(&ipb.SynchronizeRequest{Shard: shard})
+	if err != nil {
+		return nil, err
+	}
+	return stream, nil
+}
+
+// synchronizeWithLeader starts a stream with the current leader, retrying
+// while there is no leader or the stream went to a replica which is no longer
+// the leader.  Only the shard has been sent on the stream until the
+// synchronizer's first response, so it is safe to retry until then.
+func (sc *synchronizerClient) synchronizeWithLeader(ctx context.Context, shard string) (synchronizerStream, error) {
+	leaseDuration := 10 * time.Second
+	if sc.cfg.IsSet(configNameSynchronizerLeaseDuration) {
+		leaseDuration = sc.cfg.GetDuration(configNameSynchronizerLeaseDuration)
+	}
+
+	bo := backoff.NewExponentialBackOff()
+	bo.InitialInterval = 100 * time.Millisecond
+	// Long enough for a standby to take over an expired lease.
+	bo.MaxElapsedTime = 2 * leaseDuration
+
+	var stream synchronizerStream
+	err := backoff.Retry(func() error {
+		var err error
+		stream, err = sc.tryLeader(ctx, shard)
+		if status.Code(err) == codes.Unavailable {
+			logger.WithError(err).Warning("failed to reach synchronizer leader, retrying")
+			return err
+		}
+		if err != nil {
+			return backoff.Permanent(err)
+		}
+		return nil
+	}, backoff.WithContext(bo, ctx))
+	if err != nil {
+		return nil, err
+	}
+	return stream, nil
+}
+
+func (sc *synchronizerClient) tryLeader(ctx context.Context, shard string) (synchronizerStream, error) {
+	leader, err := sc.store.GetLeaseHolder(ctx, statestore.SynchronizerLease)
+	if err != nil {
+		return nil, err
+	}
+	if leader == "" {
+		return nil, status.Error(codes.Unavailable, "no synchronizer is the leader")
+	}
+
+	conn, err := sc.cc.GetGRPC(leader)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "failed to connect to synchronizer leader %s: %s", leader, err.Error())
+	}
+
+	streamCtx, cancel := context.WithCancel(ctx)
+	stream, err := ipb.NewSynchronizerClient(conn).Synchronize(streamCtx)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	err = stream.Send(&ipb.SynchronizeRequest{Shard: shard})
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	first, err := stream.Recv()
+	if err != nil {
+		cancel()
+		logger.WithFields(logrus.Fields{
+			"leader": leader,
+			"error":  err.Error(),
+		}).Warning("synchronizer leader rejected stream")
+		return nil, err
+	}
+
+	return &peekedSynchronizerStream{
+		Synchronizer_SynchronizeClient: stream,
+		first:                          first,
+		cancel:                         cancel,
+	}, nil
+}
+
+// peekedSynchronizerStream returns the already received first response before
+// the rest of the stream.
+type peekedSynchronizerStream struct {
+	ipb.Synchronizer_SynchronizeClient
+	first  *ipb.SynchronizeResponse
+	cancel context.CancelFunc
+}
+
+func (s *peekedSynchronizerStream) Recv() (*ipb.SynchronizeResponse, error) {
+	if s.first != nil {
+		first := s.first
+		s.first = nil
+		return first, nil
+	}
+	resp, err := s.Synchronizer_SynchronizeClient.Recv()
+	if err != nil {
+		s.cancel()
+	}
+	return resp, err
 }
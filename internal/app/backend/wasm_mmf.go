@@ -0,0 +1,403 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backend
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/sirupsen/logrus"
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/api"
+	"github.com/tetratelabs/wazero/imports/wasi_snapshot_preview1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"open-match.dev/open-match/internal/config"
+	"open-match.dev/open-match/internal/rpc"
+	"open-match.dev/open-match/internal/statestore"
+	"open-match.dev/open-match/pkg/matchfunction"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+const (
+	configNameWasmMaxMemoryBytes   = "wasmMatchFunction.maxMemoryBytes"
+	configNameWasmMaxExecutionTime = "wasmMatchFunction.maxExecutionTime"
+
+	// wasmHostModule is the module name WebAssembly match functions import
+	// the host API from.
+	wasmHostModule = "openmatch"
+	// wasmRunFunction is the function WebAssembly match functions export as
+	// their entry point.
+	wasmRunFunction = "run"
+	wasmPageSize    = 64 * 1024
+	// wasmMaxCompiledModules bounds the compiled modules kept for reuse, as
+	// replaced modules are never run again.
+	wasmMaxCompiledModules = 16
+)
+
+var (
+	wasmLogger = logrus.WithFields(logrus.Fields{
+		"app":       "openmatch",
+		"component": "app.backend.wasm_mmf",
+	})
+)
+
+// wasmRunner runs WebAssembly match functions stored in the statestore inside
+// of a sandbox.  The sandbox only has access to the host API defined in
+// api/backend.proto, which queries tickets through the query service.
+type wasmRunner struct {
+	store  statestore.Service
+	engine *config.Cacher
+	query  *config.Cacher
+}
+
+func newWasmRunner(cfg config.View, store statestore.Service) *wasmRunner {
+	newQueryClient := func(cfg config.View) (interface{}, func(), error) {
+		conn, err := rpc.GRPCClientFromConfig(cfg, "api.query")
+		if err != nil {
+			return nil, nil, err
+		}
+
+		close := func() {
+			err := conn.Close()
+			if err != nil {
+				wasmLogger.WithError(err).Warning("Error closing query client.")
+			}
+		}
+
+		return pb.NewQueryServiceClient(conn), close, nil
+	}
+
+	return &wasmRunner{
+		store:  store,
+		engine: config.NewCacher(cfg, newWasmEngine),
+		query:  config.NewCacher(cfg, newQueryClient),
+	}
+}
+
+// validate checks that the module compiles and exports the run function,
+// without running it.
+func (wr *wasmRunner) validate(ctx context.Context, module []byte) error {
+	e, err := wr.engine.Get()
+	if err != nil {
+		return err
+	}
+	_, release, err := e.(*wasmEngine).compile(ctx, module)
+	if err != nil {
+		return err
+	}
+	release()
+	return nil
+}
+
+// run runs the named module for the profile, streaming the proposals it emits
+// on the proposals channel.
+func (wr *wasmRunner) run(ctx context.Context, profile *pb.MatchProfile, name string, proposals chan<- *pb.Match) error {
+	module, err := wr.store.GetMatchFunctionModule(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	e, err := wr.engine.Get()
+	if err != nil {
+		return err
+	}
+	engine := e.(*wasmEngine)
+
+	compiled, release, err := engine.compile(ctx, module)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	q, err := wr.query.Get()
+	if err != nil {
+		return err
+	}
+
+	profileBytes, err := proto.Marshal(profile)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to marshal profile %s: %s", profile.GetName(), err.Error())
+	}
+
+	runCtx := ctx
+	if engine.timeout > 0 {
+		var cancel context.CancelFunc
+		runCtx, cancel = context.WithTimeout(ctx, engine.timeout)
+		defer cancel()
+	}
+	runCtx = context.WithValue(runCtx, wasmCallKey{}, &wasmCall{
+		profile:   profileBytes,
+		query:     q.(pb.QueryServiceClient),
+		proposals: proposals,
+	})
+
+	// An anonymous module may be instantiated concurrently, so each run gets
+	// its own isolated memory.
+	mod, err := engine.runtime.InstantiateModule(runCtx, compiled, wazero.NewModuleConfig().WithName("").WithStartFunctions("_initialize"))
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return status.Errorf(codes.FailedPrecondition, "failed to instantiate match function module %s: %s", name, err.Error())
+	}
+	defer func() {
+		if err := mod.Close(context.Background()); err != nil {
+			wasmLogger.WithError(err).Warning("failed to close match function module instance")
+		}
+	}()
+
+	results, err := mod.ExportedFunction(wasmRunFunction).Call(runCtx)
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if runCtx.Err() != nil {
+			return status.Errorf(codes.DeadlineExceeded, "match function module %s ran longer than %s", name, engine.timeout)
+		}
+		return status.Errorf(codes.Unknown, "match function module %s failed: %s", name, err.Error())
+	}
+	if code := int32(results[0]); code != 0 {
+		return status.Errorf(codes.Unknown, "match function module %s returned error code %d", name, code)
+	}
+
+	return nil
+}
+
+// wasmEngine holds a runtime configured with the sandbox limits, and the
+// modules recently compiled for it.
+type wasmEngine struct {
+	runtime wazero.Runtime
+	timeout time.Duration
+
+	m        sync.Mutex
+	compiled map[[sha256.Size]byte]*wasmCompiledModule
+	// lru orders the compiled modules from the most to the least recently
+	// used.
+	lru *list.List
+}
+
+// wasmCompiledModule is a compiled module, closed once it is evicted and no
+// longer used by any run.
+type wasmCompiledModule struct {
+	key     [sha256.Size]byte
+	module  wazero.CompiledModule
+	elem    *list.Element
+	refs    int
+	evicted bool
+}
+
+func newWasmEngine(cfg config.View) (interface{}, func(), error) {
+	ctx := context.Background()
+
+	maxMemoryBytes := int64(64 * 1024 * 1024)
+	if cfg.IsSet(configNameWasmMaxMemoryBytes) {
+		maxMemoryBytes = cfg.GetInt64(configNameWasmMaxMemoryBytes)
+	}
+	if maxMemoryBytes < wasmPageSize {
+		return nil, nil, status.Errorf(codes.FailedPrecondition, "%s must be at least %d", configNameWasmMaxMemoryBytes, wasmPageSize)
+	}
+
+	timeout := 10 * time.Second
+	if cfg.IsSet(configNameWasmMaxExecutionTime) {
+		timeout = cfg.GetDuration(configNameWasmMaxExecutionTime)
+	}
+
+	runtimeConfig := wazero.NewRuntimeConfig().
+		WithMemoryLimitPages(uint32(maxMemoryBytes / wasmPageSize)).
+		WithCloseOnContextDone(true)
+	r := wazero.NewRuntimeWithConfig(ctx, runtimeConfig)
+
+	close := func() {
+		if err := r.Close(context.Background()); err != nil {
+			wasmLogger.WithError(err).Warning("Error closing wasm runtime.")
+		}
+	}
+
+	// Modules built by common toolchains expect WASI to be present, even when
+	// they don't use it for anything.
+	if _, err := wasi_snapshot_preview1.Instantiate(ctx, r); err != nil {
+		close()
+		return nil, nil, fmt.Errorf("failed to instantiate wasi: %w", err)
+	}
+
+	_, err := r.NewHostModuleBuilder(wasmHostModule).
+		NewFunctionBuilder().WithFunc(hostProfileSize).Export("profile_size").
+		NewFunctionBuilder().WithFunc(hostProfileRead).Export("profile_read").
+		NewFunctionBuilder().WithFunc(hostQueryPool).Export("query_pool").
+		NewFunctionBuilder().WithFunc(hostResultRead).Export("result_read").
+		NewFunctionBuilder().WithFunc(hostEmitProposal).Export("emit_proposal").
+		Instantiate(ctx)
+	if err != nil {
+		close()
+		return nil, nil, fmt.Errorf("failed to instantiate wasm host module: %w", err)
+	}
+
+	return &wasmEngine{
+		runtime:  r,
+		timeout:  timeout,
+		compiled: make(map[[sha256.Size]byte]*wasmCompiledModule),
+		lru:      list.New(),
+	}, close, nil
+}
+
+// compile returns the compiled module, reusing recent compilations of the
+// same binary.  The returned func must be called once the module is no longer
+// used.
+func (e *wasmEngine) compile(ctx context.Context, module []byte) (wazero.CompiledModule, func(), error) {
+	key := sha256.Sum256(module)
+
+	e.m.Lock()
+	defer e.m.Unlock()
+
+	c, ok := e.compiled[key]
+	if ok {
+		e.lru.MoveToFront(c.elem)
+	} else {
+		compiled, err := e.runtime.CompileModule(ctx, module)
+		if err != nil {
+			return nil, nil, status.Errorf(codes.InvalidArgument, "invalid match function module: %s", err.Error())
+		}
+
+		run, ok := compiled.ExportedFunctions()[wasmRunFunction]
+		if !ok || len(run.ParamTypes()) != 0 || len(run.ResultTypes()) != 1 || run.ResultTypes()[0] != api.ValueTypeI32 {
+			closeCompiledModule(compiled)
+			return nil, nil, status.Errorf(codes.InvalidArgument, "match function module must export function %s() -> i32", wasmRunFunction)
+		}
+
+		c = &wasmCompiledModule{key: key, module: compiled}
+		c.elem = e.lru.PushFront(c)
+		e.compiled[key] = c
+		for e.lru.Len() > wasmMaxCompiledModules {
+			e.evict(e.lru.Back().Value.(*wasmCompiledModule))
+		}
+	}
+
+	c.refs++
+	release := func() {
+		e.m.Lock()
+		defer e.m.Unlock()
+		c.refs--
+		if c.evicted && c.refs == 0 {
+			closeCompiledModule(c.module)
+		}
+	}
+	return c.module, release, nil
+}
+
+// evict removes the compiled module from the cache, closing it unless runs
+// still use it.  Must be called with e.m held.
+func (e *wasmEngine) evict(c *wasmCompiledModule) {
+	e.lru.Remove(c.elem)
+	delete(e.compiled, c.key)
+	c.evicted = true
+	if c.refs == 0 {
+		closeCompiledModule(c.module)
+	}
+}
+
+func closeCompiledModule(c wazero.CompiledModule) {
+	if err := c.Close(context.Background()); err != nil {
+		wasmLogger.WithError(err).Warning("failed to close compiled match function module")
+	}
+}
+
+///////////////////////////////////////
+///////////////////////////////////////
+
+// Host API exposed to WebAssembly match functions.  State for a single run is
+// passed through the context given to the module's run function.
+
+type wasmCallKey struct{}
+
+type wasmCall struct {
+	profile   []byte
+	result    []byte
+	query     pb.QueryServiceClient
+	proposals chan<- *pb.Match
+}
+
+func getWasmCall(ctx context.Context) *wasmCall {
+	return ctx.Value(wasmCallKey{}).(*wasmCall)
+}
+
+func hostProfileSize(ctx context.Context) int32 {
+	return int32(len(getWasmCall(ctx).profile))
+}
+
+func hostProfileRead(ctx context.Context, m api.Module, ptr uint32) {
+	if !m.Memory().Write(ptr, getWasmCall(ctx).profile) {
+		panic(fmt.Errorf("profile_read out of memory range at %d", ptr))
+	}
+}
+
+func hostQueryPool(ctx context.Context, m api.Module, ptr, size uint32) int32 {
+	call := getWasmCall(ctx)
+
+	b, ok := m.Memory().Read(ptr, size)
+	if !ok {
+		panic(fmt.Errorf("query_pool out of memory range at %d, size %d", ptr, size))
+	}
+
+	pool := &pb.Pool{}
+	if err := proto.Unmarshal(b, pool); err != nil {
+		wasmLogger.WithError(err).Error("match function module sent an invalid pool")
+		return -1
+	}
+
+	tickets, err := matchfunction.QueryPool(ctx, call.query, pool)
+	if err != nil {
+		wasmLogger.WithError(err).Error("failed to query pool for match function module")
+		return -1
+	}
+
+	call.result, err = proto.Marshal(&pb.QueryTicketsResponse{Tickets: tickets})
+	if err != nil {
+		wasmLogger.WithError(err).Error("failed to marshal query result for match function module")
+		return -1
+	}
+	return int32(len(call.result))
+}
+
+func hostResultRead(ctx context.Context, m api.Module, ptr uint32) {
+	if !m.Memory().Write(ptr, getWasmCall(ctx).result) {
+		panic(fmt.Errorf("result_read out of memory range at %d", ptr))
+	}
+}
+
+func hostEmitProposal(ctx context.Context, m api.Module, ptr, size uint32) int32 {
+	b, ok := m.Memory().Read(ptr, size)
+	if !ok {
+		panic(fmt.Errorf("emit_proposal out of memory range at %d, size %d", ptr, size))
+	}
+
+	match := &pb.Match{}
+	if err := proto.Unmarshal(b, match); err != nil {
+		wasmLogger.WithError(err).Error("match function module sent an invalid proposal")
+		return -1
+	}
+
+	select {
+	case getWasmCall(ctx).proposals <- match:
+		return 0
+	case <-ctx.Done():
+		return -1
+	}
+}
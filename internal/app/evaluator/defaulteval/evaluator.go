@@ -19,6 +19,8 @@ import (
 	"context"
 	"math"
 	"sort"
+	"strings"
+	"time"
 
 	"go.opencensus.io/stats"
 
@@ -27,6 +29,7 @@ import (
 	"go.opencensus.io/stats/view"
 	"open-match.dev/open-match/internal/app/evaluator"
 	"open-match.dev/open-match/internal/appmain"
+	"open-match.dev/open-match/internal/config"
 	"open-match.dev/open-match/pkg/pb"
 )
 
@@ -45,14 +48,39 @@ var (
 	}
 )
 
+const (
+	configNameStrategy           = "defaultEvaluator.strategy"
+	configNameMaxExactSearchSize = "defaultEvaluator.maxExactSearchSize"
+	configNameWaitTimeBoost      = "defaultEvaluator.waitTimeBoost"
+)
+
 type matchInp struct {
 	match *pb.Match
 	inp   *pb.DefaultEvaluationCriteria
+
+	// score is the criteria's score, including any wait time boost.
+	score float64
+	// oldest is the create time of the match's oldest ticket, or zero if no
+	// ticket has a create time.
+	oldest time.Time
+}
+
+// evaluateOptions are the config driven settings of the evaluator.
+type evaluateOptions struct {
+	strategy           pb.DefaultEvaluationCriteria_Strategy
+	maxExactSearchSize int
+	// waitTimeBoost is added to a match's score per second its oldest ticket
+	// has waited.
+	waitTimeBoost float64
 }
 
 // BindService define the initialization steps for this evaluator
 func BindService(p *appmain.Params, b *appmain.Bindings) error {
-	if err := evaluator.BindServiceFor(evaluate)(p, b); err != nil {
+	cfg := p.Config()
+	eval := func(ctx context.Context, in <-chan *pb.Match, out chan<- string, rejected chan<- *pb.RejectedMatch) error {
+		return evaluate(ctx, in, out, rejected, readOptions(cfg))
+	}
+	if err := evaluator.BindServiceForRejecting(eval)(p, b); err != nil {
 		return err
 	}
 	b.RegisterViews(collidedMatchesPerEvaluateView)
@@ -60,10 +88,16 @@ func BindService(p *appmain.Params, b *appmain.Bindings) error {
 }
 
 // evaluate sorts the matches by DefaultEvaluationCriteria.Score (optional),
-// then returns matches which don't collide with previously returned matches.
-func evaluate(ctx context.Context, in <-chan *pb.Match, out chan<- string) error {
+// boosted by wait time and then ordered by tie breakers, then returns matches
+// which don't collide with previously returned matches.
+// With the OPTIMAL strategy, the matches with the highest total score are
+// returned first instead.  Matches which collide, or whose evaluation_input is
+// invalid, are rejected.
+func evaluate(ctx context.Context, in <-chan *pb.Match, out chan<- string, rejected chan<- *pb.RejectedMatch, opts evaluateOptions) error {
 	matches := make([]*matchInp, 0)
 	nilEvlautionInputs := 0
+	strategy := opts.strategy
+	now := time.Now()
 
 	for m := range in {
 		// Evaluation criteria is optional, but sort it lower than any matches which
@@ -79,14 +113,32 @@ func evaluate(ctx context.Context, in <-chan *pb.Match, out chan<- string) error
 					"match_id": m.MatchId,
 					"error":    err,
 				}).Error("Failed to unmarshal match's DefaultEvaluationCriteria.  Rejecting match.")
+				select {
+				case rejected <- &pb.RejectedMatch{
+					MatchId: m.MatchId,
+					Reason:  pb.RejectedMatch_EVALUATOR_ERROR,
+				}:
+				case <-ctx.Done():
+					return ctx.Err()
+				}
 				continue
 			}
 		} else {
 			nilEvlautionInputs++
 		}
+		if inp.GetStrategy() > strategy {
+			strategy = inp.GetStrategy()
+		}
+		oldest := oldestCreateTime(m.GetTickets())
+		score := inp.GetScore()
+		if opts.waitTimeBoost != 0 && !oldest.IsZero() {
+			score += opts.waitTimeBoost * now.Sub(oldest).Seconds()
+		}
 		matches = append(matches, &matchInp{
-			match: m,
-			inp:   inp,
+			match:  m,
+			inp:    inp,
+			score:  score,
+			oldest: oldest,
 		})
 	}
 
@@ -102,14 +154,35 @@ func evaluate(ctx context.Context, in <-chan *pb.Match, out chan<- string) error
 		ticketsUsed: make(map[string]*collidingMatch),
 	}
 
+	if strategy == pb.DefaultEvaluationCriteria_OPTIMAL {
+		// The chosen matches don't collide with each other, so are all added
+		// before the remaining matches are considered.
+		chosen := maxWeightPacking(matches, opts.maxExactSearchSize)
+		for _, m := range chosen {
+			d.maybeAdd(m)
+		}
+		matches = withoutMatches(matches, chosen)
+	}
+
 	for _, m := range matches {
 		d.maybeAdd(m)
 	}
 
-	stats.Record(context.Background(), collidedMatchesPerEvaluate.M(int64(len(matches)-len(d.resultIDs))))
+	stats.Record(context.Background(), collidedMatchesPerEvaluate.M(int64(len(d.rejected))))
 
 	for _, id := range d.resultIDs {
-		out <- id
+		select {
+		case out <- id:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	for _, r := range d.rejected {
+		select {
+		case rejected <- r:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
 	}
 
 	return nil
@@ -122,6 +195,7 @@ type collidingMatch struct {
 
 type decollider struct {
 	resultIDs   []string
+	rejected    []*pb.RejectedMatch
 	ticketsUsed map[string]*collidingMatch
 }
 
@@ -131,10 +205,15 @@ func (d *decollider) maybeAdd(m *matchInp) {
 			logger.WithFields(logrus.Fields{
 				"match_id":              m.match.GetMatchId(),
 				"ticket_id":             t.GetId(),
-				"match_score":           m.inp.GetScore(),
+				"match_score":           m.score,
 				"colliding_match_id":    cm.id,
 				"colliding_match_score": cm.score,
 			}).Info("Higher quality match with colliding ticket found. Rejecting match.")
+			d.rejected = append(d.rejected, &pb.RejectedMatch{
+				MatchId:          m.match.GetMatchId(),
+				Reason:           pb.RejectedMatch_COLLISION,
+				CollidingMatchId: cm.id,
+			})
 			return
 		}
 	}
@@ -142,7 +221,7 @@ func (d *decollider) maybeAdd(m *matchInp) {
 	for _, t := range m.match.GetTickets() {
 		d.ticketsUsed[t.Id] = &collidingMatch{
 			id:    m.match.GetMatchId(),
-			score: m.inp.GetScore(),
+			score: m.score,
 		}
 	}
 
@@ -159,6 +238,103 @@ func (m byScore) Swap(i, j int) {
 	m[i], m[j] = m[j], m[i]
 }
 
+// Less orders by descending score, then tie breakers, then wait time of the
+// oldest ticket, then match size.  Match id is the final key so the order is
+// deterministic.
 func (m byScore) Less(i, j int) bool {
-	return m[i].inp.Score > m[j].inp.Score
+	a, b := m[i], m[j]
+	if a.score != b.score {
+		return a.score > b.score
+	}
+
+	at, bt := a.inp.GetTieBreakers(), b.inp.GetTieBreakers()
+	for k := 0; k < len(at) || k < len(bt); k++ {
+		if k >= len(at) || k >= len(bt) {
+			// Matches which have the key sort first.
+			return k < len(at)
+		}
+		if at[k] != bt[k] {
+			return at[k] > bt[k]
+		}
+	}
+
+	if !a.oldest.Equal(b.oldest) {
+		if a.oldest.IsZero() || b.oldest.IsZero() {
+			return b.oldest.IsZero()
+		}
+		return a.oldest.Before(b.oldest)
+	}
+
+	if len(a.match.GetTickets()) != len(b.match.GetTickets()) {
+		return len(a.match.GetTickets()) > len(b.match.GetTickets())
+	}
+
+	return a.match.GetMatchId() < b.match.GetMatchId()
+}
+
+// oldestCreateTime returns the earliest create time of the tickets, or zero if
+// none have one.
+func oldestCreateTime(tickets []*pb.Ticket) time.Time {
+	var oldest time.Time
+	for _, t := range tickets {
+		if t.GetCreateTime() == nil {
+			continue
+		}
+		ct, err := ptypes.Timestamp(t.GetCreateTime())
+		if err != nil {
+			continue
+		}
+		if oldest.IsZero() || ct.Before(oldest) {
+			oldest = ct
+		}
+	}
+	return oldest
+}
+
+func withoutMatches(matches []*matchInp, remove []*matchInp) []*matchInp {
+	removed := make(map[*matchInp]bool, len(remove))
+	for _, m := range remove {
+		removed[m] = true
+	}
+	result := make([]*matchInp, 0, len(matches)-len(remove))
+	for _, m := range matches {
+		if !removed[m] {
+			result = append(result, m)
+		}
+	}
+	return result
+}
+
+func readOptions(cfg config.View) evaluateOptions {
+	return evaluateOptions{
+		strategy:           configuredStrategy(cfg),
+		maxExactSearchSize: maxExactSearchSize(cfg),
+		waitTimeBoost:      cfg.GetFloat64(configNameWaitTimeBoost),
+	}
+}
+
+// configuredStrategy returns the strategy used for matches which don't
+// request one.
+func configuredStrategy(cfg config.View) pb.DefaultEvaluationCriteria_Strategy {
+	if !cfg.IsSet(configNameStrategy) {
+		return pb.DefaultEvaluationCriteria_GREEDY
+	}
+	name := cfg.GetString(configNameStrategy)
+	s, ok := pb.DefaultEvaluationCriteria_Strategy_value[strings.ToUpper(name)]
+	if !ok || s == int32(pb.DefaultEvaluationCriteria_DEFAULT) {
+		logger.WithFields(logrus.Fields{
+			"strategy": name,
+		}).Warningf("Unknown %s, using greedy strategy.", configNameStrategy)
+		return pb.DefaultEvaluationCriteria_GREEDY
+	}
+	return pb.DefaultEvaluationCriteria_Strategy(s)
+}
+
+// maxExactSearchSize returns the largest group of colliding matches the
+// optimal strategy solves exactly.
+func maxExactSearchSize(cfg config.View) int {
+	if !cfg.IsSet(configNameMaxExactSearchSize) {
+		return defaultMaxExactSearchSize
+	}
+	return cfg.GetInt(configNameMaxExactSearchSize)
 }
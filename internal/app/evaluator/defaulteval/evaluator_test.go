@@ -17,6 +17,7 @@ package defaulteval
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes"
@@ -78,10 +79,19 @@ func TestEvaluate(t *testing.T) {
 		},
 	}
 
+	ticket4BadInput := &pb.Match{
+		MatchId: "ticket4BadInput",
+		Tickets: []*pb.Ticket{{Id: "4"}},
+		Extensions: map[string]*any.Any{
+			"evaluation_input": mustAny(&pb.Ticket{}),
+		},
+	}
+
 	tests := []struct {
 		description  string
 		testMatches  []*pb.Match
 		wantMatchIDs []string
+		wantRejected []*pb.RejectedMatch
 	}{
 		{
 			description:  "test empty request returns empty response",
@@ -97,16 +107,34 @@ func TestEvaluate(t *testing.T) {
 			description:  "test deduplicates and expect the one with higher score",
 			testMatches:  []*pb.Match{ticket12Score1, ticket12Score10},
 			wantMatchIDs: []string{ticket12Score10.GetMatchId()},
+			wantRejected: []*pb.RejectedMatch{
+				{MatchId: ticket12Score1.GetMatchId(), Reason: pb.RejectedMatch_COLLISION, CollidingMatchId: ticket12Score10.GetMatchId()},
+			},
 		},
 		{
 			description:  "test first returns matches with higher score",
 			testMatches:  []*pb.Match{ticket123Score5, ticket12Score10},
 			wantMatchIDs: []string{ticket12Score10.GetMatchId()},
+			wantRejected: []*pb.RejectedMatch{
+				{MatchId: ticket123Score5.GetMatchId(), Reason: pb.RejectedMatch_COLLISION, CollidingMatchId: ticket12Score10.GetMatchId()},
+			},
 		},
 		{
 			description:  "test evaluator returns two matches with the highest score",
 			testMatches:  []*pb.Match{ticket12Score1, ticket12Score10, ticket123Score5, ticket3Score50},
 			wantMatchIDs: []string{ticket12Score10.GetMatchId(), ticket3Score50.GetMatchId()},
+			wantRejected: []*pb.RejectedMatch{
+				{MatchId: ticket12Score1.GetMatchId(), Reason: pb.RejectedMatch_COLLISION, CollidingMatchId: ticket12Score10.GetMatchId()},
+				{MatchId: ticket123Score5.GetMatchId(), Reason: pb.RejectedMatch_COLLISION, CollidingMatchId: ticket12Score10.GetMatchId()},
+			},
+		},
+		{
+			description:  "test evaluator rejects matches with invalid evaluation input",
+			testMatches:  []*pb.Match{ticket4BadInput, ticket3Score50},
+			wantMatchIDs: []string{ticket3Score50.GetMatchId()},
+			wantRejected: []*pb.RejectedMatch{
+				{MatchId: ticket4BadInput.GetMatchId(), Reason: pb.RejectedMatch_EVALUATOR_ERROR},
+			},
 		},
 	}
 
@@ -116,12 +144,13 @@ func TestEvaluate(t *testing.T) {
 			t.Parallel()
 			in := make(chan *pb.Match, 10)
 			out := make(chan string, 10)
+			rejected := make(chan *pb.RejectedMatch, 10)
 			for _, m := range test.testMatches {
 				in <- m
 			}
 			close(in)
 
-			err := evaluate(context.Background(), in, out)
+			err := evaluate(context.Background(), in, out, rejected, evaluateOptions{strategy: pb.DefaultEvaluationCriteria_GREEDY, maxExactSearchSize: defaultMaxExactSearchSize})
 			assert.Nil(t, err)
 
 			gotMatchIDs := []string{}
@@ -134,6 +163,244 @@ func TestEvaluate(t *testing.T) {
 			for _, mID := range gotMatchIDs {
 				assert.Contains(t, test.wantMatchIDs, mID)
 			}
+
+			gotRejected := []*pb.RejectedMatch{}
+			close(rejected)
+			for r := range rejected {
+				gotRejected = append(gotRejected, r)
+			}
+			assert.Equal(t, len(test.wantRejected), len(gotRejected))
+
+			for _, want := range test.wantRejected {
+				found := false
+				for _, got := range gotRejected {
+					found = found || proto.Equal(want, got)
+				}
+				assert.True(t, found, "missing rejection %v", want)
+			}
+		})
+	}
+}
+
+func TestEvaluateOptimal(t *testing.T) {
+	ticket1 := &pb.Ticket{Id: "1"}
+	ticket2 := &pb.Ticket{Id: "2"}
+	ticket3 := &pb.Ticket{Id: "3"}
+
+	ticket12Score10 := &pb.Match{
+		MatchId: "ticket12Score10",
+		Tickets: []*pb.Ticket{ticket1, ticket2},
+		Extensions: map[string]*any.Any{
+			"evaluation_input": mustAny(&pb.DefaultEvaluationCriteria{
+				Score: 10,
+			}),
+		},
+	}
+
+	ticket1Score6 := &pb.Match{
+		MatchId: "ticket1Score6",
+		Tickets: []*pb.Ticket{ticket1},
+		Extensions: map[string]*any.Any{
+			"evaluation_input": mustAny(&pb.DefaultEvaluationCriteria{
+				Score: 6,
+			}),
+		},
+	}
+
+	ticket2Score6 := &pb.Match{
+		MatchId: "ticket2Score6",
+		Tickets: []*pb.Ticket{ticket2},
+		Extensions: map[string]*any.Any{
+			"evaluation_input": mustAny(&pb.DefaultEvaluationCriteria{
+				Score: 6,
+			}),
+		},
+	}
+
+	ticket2Score6Optimal := &pb.Match{
+		MatchId: "ticket2Score6Optimal",
+		Tickets: []*pb.Ticket{ticket2},
+		Extensions: map[string]*any.Any{
+			"evaluation_input": mustAny(&pb.DefaultEvaluationCriteria{
+				Score:    6,
+				Strategy: pb.DefaultEvaluationCriteria_OPTIMAL,
+			}),
+		},
+	}
+
+	ticket23NoScore := &pb.Match{
+		MatchId: "ticket23NoScore",
+		Tickets: []*pb.Ticket{ticket2, ticket3},
+	}
+
+	ticket3NoScore := &pb.Match{
+		MatchId: "ticket3NoScore",
+		Tickets: []*pb.Ticket{ticket3},
+	}
+
+	tests := []struct {
+		description  string
+		strategy     pb.DefaultEvaluationCriteria_Strategy
+		testMatches  []*pb.Match
+		wantMatchIDs []string
+	}{
+		{
+			description:  "test greedy prefers the single highest score",
+			strategy:     pb.DefaultEvaluationCriteria_GREEDY,
+			testMatches:  []*pb.Match{ticket12Score10, ticket1Score6, ticket2Score6},
+			wantMatchIDs: []string{ticket12Score10.GetMatchId()},
+		},
+		{
+			description:  "test optimal prefers the highest total score",
+			strategy:     pb.DefaultEvaluationCriteria_OPTIMAL,
+			testMatches:  []*pb.Match{ticket12Score10, ticket1Score6, ticket2Score6},
+			wantMatchIDs: []string{ticket1Score6.GetMatchId(), ticket2Score6.GetMatchId()},
+		},
+		{
+			description:  "test match criteria can request optimal",
+			strategy:     pb.DefaultEvaluationCriteria_GREEDY,
+			testMatches:  []*pb.Match{ticket12Score10, ticket1Score6, ticket2Score6Optimal},
+			wantMatchIDs: []string{ticket1Score6.GetMatchId(), ticket2Score6Optimal.GetMatchId()},
+		},
+		{
+			description:  "test optimal still returns non colliding matches without scores",
+			strategy:     pb.DefaultEvaluationCriteria_OPTIMAL,
+			testMatches:  []*pb.Match{ticket12Score10, ticket1Score6, ticket2Score6, ticket23NoScore, ticket3NoScore},
+			wantMatchIDs: []string{ticket1Score6.GetMatchId(), ticket2Score6.GetMatchId(), ticket3NoScore.GetMatchId()},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.description, func(t *testing.T) {
+			t.Parallel()
+			in := make(chan *pb.Match, 10)
+			out := make(chan string, 10)
+			rejected := make(chan *pb.RejectedMatch, 10)
+			for _, m := range test.testMatches {
+				in <- m
+			}
+			close(in)
+
+			err := evaluate(context.Background(), in, out, rejected, evaluateOptions{strategy: test.strategy, maxExactSearchSize: defaultMaxExactSearchSize})
+			assert.Nil(t, err)
+
+			gotMatchIDs := []string{}
+			close(out)
+			for id := range out {
+				gotMatchIDs = append(gotMatchIDs, id)
+			}
+			assert.ElementsMatch(t, test.wantMatchIDs, gotMatchIDs)
+
+			close(rejected)
+			for r := range rejected {
+				assert.Equal(t, pb.RejectedMatch_COLLISION, r.Reason)
+				assert.Contains(t, gotMatchIDs, r.CollidingMatchId)
+			}
+		})
+	}
+}
+
+func TestEvaluateTieBreakers(t *testing.T) {
+	now := time.Now()
+	ticketAt := func(id string, waited time.Duration) *pb.Ticket {
+		ct, err := ptypes.TimestampProto(now.Add(-waited))
+		if err != nil {
+			panic(err)
+		}
+		return &pb.Ticket{Id: id, CreateTime: ct}
+	}
+	match := func(id string, criteria *pb.DefaultEvaluationCriteria, tickets ...*pb.Ticket) *pb.Match {
+		return &pb.Match{
+			MatchId: id,
+			Tickets: tickets,
+			Extensions: map[string]*any.Any{
+				"evaluation_input": mustAny(criteria),
+			},
+		}
+	}
+
+	newTicket := ticketAt("new", time.Second)
+	oldTicket := ticketAt("old", time.Minute)
+
+	tests := []struct {
+		description string
+		opts        evaluateOptions
+		testMatches []*pb.Match
+		wantMatchID string
+	}{
+		{
+			description: "test higher tie breaker wins equal scores",
+			testMatches: []*pb.Match{
+				match("low", &pb.DefaultEvaluationCriteria{Score: 1, TieBreakers: []float64{5, 1}}, newTicket),
+				match("high", &pb.DefaultEvaluationCriteria{Score: 1, TieBreakers: []float64{5, 2}}, newTicket),
+			},
+			wantMatchID: "high",
+		},
+		{
+			description: "test match with tie breakers wins over match without",
+			testMatches: []*pb.Match{
+				match("without", &pb.DefaultEvaluationCriteria{Score: 1}, newTicket),
+				match("with", &pb.DefaultEvaluationCriteria{Score: 1, TieBreakers: []float64{-100}}, newTicket),
+			},
+			wantMatchID: "with",
+		},
+		{
+			description: "test longest waiting ticket wins equal scores",
+			testMatches: []*pb.Match{
+				match("new", &pb.DefaultEvaluationCriteria{Score: 1}, newTicket, ticketAt("a", time.Second)),
+				match("old", &pb.DefaultEvaluationCriteria{Score: 1}, newTicket, oldTicket),
+			},
+			wantMatchID: "old",
+		},
+		{
+			description: "test larger match wins equal scores and wait times",
+			testMatches: []*pb.Match{
+				match("small", &pb.DefaultEvaluationCriteria{Score: 1}, newTicket),
+				match("large", &pb.DefaultEvaluationCriteria{Score: 1}, newTicket, ticketAt("b", 0)),
+			},
+			wantMatchID: "large",
+		},
+		{
+			description: "test higher score wins without wait time boost",
+			testMatches: []*pb.Match{
+				match("higher", &pb.DefaultEvaluationCriteria{Score: 10}, newTicket, ticketAt("c", 0)),
+				match("waited", &pb.DefaultEvaluationCriteria{Score: 1}, newTicket, oldTicket),
+			},
+			wantMatchID: "higher",
+		},
+		{
+			description: "test wait time boost favors long waiting tickets",
+			opts:        evaluateOptions{waitTimeBoost: 1},
+			testMatches: []*pb.Match{
+				match("higher", &pb.DefaultEvaluationCriteria{Score: 10}, newTicket, ticketAt("c", 0)),
+				match("waited", &pb.DefaultEvaluationCriteria{Score: 1}, newTicket, oldTicket),
+			},
+			wantMatchID: "waited",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.description, func(t *testing.T) {
+			t.Parallel()
+			in := make(chan *pb.Match, 10)
+			out := make(chan string, 10)
+			rejected := make(chan *pb.RejectedMatch, 10)
+			for _, m := range test.testMatches {
+				in <- m
+			}
+			close(in)
+
+			err := evaluate(context.Background(), in, out, rejected, test.opts)
+			assert.Nil(t, err)
+
+			close(out)
+			gotMatchIDs := []string{}
+			for id := range out {
+				gotMatchIDs = append(gotMatchIDs, id)
+			}
+			assert.Equal(t, []string{test.wantMatchID}, gotMatchIDs)
 		})
 	}
 }
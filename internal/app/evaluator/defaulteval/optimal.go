@@ -0,0 +1,312 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package defaulteval
+
+import "sort"
+
+// The optimal strategy solves maximum-weight set packing: choose matches which
+// don't share tickets, maximizing the sum of their scores.  Matches only
+// interact with matches they share tickets with, so the problem is split into
+// connected groups of colliding matches.  Each group is solved exactly with
+// branch and bound when it has at most maxExactSearchSize matches.  Larger
+// groups start from the greedy solution and are improved by local search,
+// which is bounded to a fixed number of passes.
+//
+// Only matches with a positive score contribute to the objective.  Matches
+// left over are added afterwards by the decollider, so the result still
+// accepts every match which doesn't collide with an accepted match.
+
+const (
+	defaultMaxExactSearchSize = 20
+	maxLocalSearchPasses      = 10
+)
+
+// maxWeightPacking returns the matches chosen by the optimal strategy, in the
+// same order they appear in matches.  matches must be sorted byScore.
+func maxWeightPacking(matches []*matchInp, maxExactSearchSize int) []*matchInp {
+	// Only positive scores matter to the objective.
+	var candidates []*matchInp
+	for _, m := range matches {
+		if m.score > 0 {
+			candidates = append(candidates, m)
+		}
+	}
+
+	chosen := make([]bool, len(candidates))
+	for _, group := range collisionGroups(candidates) {
+		p := newPacker(candidates, group)
+		if len(group) <= maxExactSearchSize {
+			p.searchExact()
+		} else {
+			p.searchLocal()
+		}
+		for i, idx := range group {
+			chosen[idx] = p.best[i]
+		}
+	}
+
+	var result []*matchInp
+	for i, m := range candidates {
+		if chosen[i] {
+			result = append(result, m)
+		}
+	}
+	return result
+}
+
+// collisionGroups returns the indices of the matches, grouped so that matches
+// which share a ticket, directly or through other matches, are in the same
+// group.  Indices within a group are ascending.
+func collisionGroups(matches []*matchInp) [][]int {
+	parent := make([]int, len(matches))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(i int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	ticketOwner := make(map[string]int)
+	for i, m := range matches {
+		for _, t := range m.match.GetTickets() {
+			if j, ok := ticketOwner[t.GetId()]; ok {
+				parent[find(i)] = find(j)
+			} else {
+				ticketOwner[t.GetId()] = i
+			}
+		}
+	}
+
+	groupIndex := make(map[int]int)
+	var groups [][]int
+	for i := range matches {
+		root := find(i)
+		g, ok := groupIndex[root]
+		if !ok {
+			g = len(groups)
+			groupIndex[root] = g
+			groups = append(groups, nil)
+		}
+		groups[g] = append(groups[g], i)
+	}
+	return groups
+}
+
+// packer searches for the best packing of a single group.  Indices are local
+// to the group, and ordered by descending weight.
+type packer struct {
+	weights   []float64
+	conflicts [][]int
+
+	best       []bool
+	bestWeight float64
+
+	// State of the current search.
+	chosen   []bool
+	blockers []int
+}
+
+func newPacker(matches []*matchInp, group []int) *packer {
+	n := len(group)
+	p := &packer{
+		weights:   make([]float64, n),
+		conflicts: make([][]int, n),
+		best:      make([]bool, n),
+		chosen:    make([]bool, n),
+		blockers:  make([]int, n),
+	}
+
+	owners := make(map[string][]int)
+	for i, idx := range group {
+		p.weights[i] = matches[idx].score
+		for _, t := range matches[idx].match.GetTickets() {
+			owners[t.GetId()] = append(owners[t.GetId()], i)
+		}
+	}
+
+	seen := make([]map[int]bool, n)
+	for _, ids := range owners {
+		for _, i := range ids {
+			for _, j := range ids {
+				if i == j || seen[i][j] {
+					continue
+				}
+				if seen[i] == nil {
+					seen[i] = make(map[int]bool)
+				}
+				seen[i][j] = true
+				p.conflicts[i] = append(p.conflicts[i], j)
+			}
+		}
+	}
+	// Keep moves deterministic, trying heavier matches first.
+	for _, c := range p.conflicts {
+		sort.Ints(c)
+	}
+	return p
+}
+
+func (p *packer) choose(i int) {
+	p.chosen[i] = true
+	for _, j := range p.conflicts[i] {
+		p.blockers[j]++
+	}
+}
+
+func (p *packer) unchoose(i int) {
+	p.chosen[i] = false
+	for _, j := range p.conflicts[i] {
+		p.blockers[j]--
+	}
+}
+
+func (p *packer) record() {
+	total := 0.0
+	for i, c := range p.chosen {
+		if c {
+			total += p.weights[i]
+		}
+	}
+	if total > p.bestWeight {
+		p.bestWeight = total
+		copy(p.best, p.chosen)
+	}
+}
+
+// searchExact finds the best packing with branch and bound.  Matches are
+// tried in descending weight, including them before excluding them, so the
+// first complete packing found is the greedy one.
+func (p *packer) searchExact() {
+	remaining := make([]float64, len(p.weights)+1)
+	for i := len(p.weights) - 1; i >= 0; i-- {
+		remaining[i] = remaining[i+1] + p.weights[i]
+	}
+
+	var search func(i int, weight float64)
+	search = func(i int, weight float64) {
+		if weight+remaining[i] <= p.bestWeight {
+			return
+		}
+		if i == len(p.weights) {
+			p.record()
+			return
+		}
+		if p.blockers[i] == 0 {
+			p.choose(i)
+			search(i+1, weight+p.weights[i])
+			p.unchoose(i)
+		}
+		search(i+1, weight)
+	}
+	search(0, 0)
+}
+
+// searchLocal starts from the greedy packing and applies improving moves
+// until none are left, or a pass limit is reached.  The moves are:
+// - Add a match, removing the chosen matches it collides with.
+// - Remove a chosen match, adding matches which only collided with it.
+func (p *packer) searchLocal() {
+	p.fill()
+
+	for pass := 0; pass < maxLocalSearchPasses; pass++ {
+		improved := false
+		for i := range p.weights {
+			if !p.chosen[i] && p.tryAdd(i) {
+				improved = true
+			}
+		}
+		for i := range p.weights {
+			if p.chosen[i] && p.tryRemove(i) {
+				improved = true
+			}
+		}
+		if !improved {
+			break
+		}
+	}
+
+	p.record()
+}
+
+// fill chooses every match which doesn't collide with a chosen match, in
+// descending weight.
+func (p *packer) fill() {
+	for i := range p.weights {
+		if !p.chosen[i] && p.blockers[i] == 0 {
+			p.choose(i)
+		}
+	}
+}
+
+func (p *packer) tryAdd(i int) bool {
+	lost := 0.0
+	for _, j := range p.conflicts[i] {
+		if p.chosen[j] {
+			lost += p.weights[j]
+		}
+	}
+	if p.weights[i] <= lost {
+		return false
+	}
+
+	var removed []int
+	for _, j := range p.conflicts[i] {
+		if p.chosen[j] {
+			p.unchoose(j)
+			removed = append(removed, j)
+		}
+	}
+	p.choose(i)
+
+	// Matches which only collided with removed matches may now be chosen.
+	var freed []int
+	for _, j := range removed {
+		freed = append(freed, p.conflicts[j]...)
+	}
+	sort.Ints(freed)
+	for _, k := range freed {
+		if !p.chosen[k] && p.blockers[k] == 0 {
+			p.choose(k)
+		}
+	}
+	return true
+}
+
+func (p *packer) tryRemove(i int) bool {
+	p.unchoose(i)
+
+	var added []int
+	gained := 0.0
+	for _, j := range p.conflicts[i] {
+		if !p.chosen[j] && p.blockers[j] == 0 {
+			p.choose(j)
+			added = append(added, j)
+			gained += p.weights[j]
+		}
+	}
+	if gained > p.weights[i] {
+		return true
+	}
+
+	for _, j := range added {
+		p.unchoose(j)
+	}
+	p.choose(i)
+	return false
+}
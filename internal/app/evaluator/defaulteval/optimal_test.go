@@ -0,0 +1,183 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package defaulteval
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+// randomMatches generates matches of a few tickets each, drawn from a pool of
+// tickets small enough that many matches collide.  The result is sorted
+// byScore.
+func randomMatches(r *rand.Rand, count, ticketPool int) []*matchInp {
+	matches := make([]*matchInp, count)
+	for i := range matches {
+		m := &pb.Match{MatchId: fmt.Sprintf("m%d", i)}
+		for j := 1 + r.Intn(3); j > 0; j-- {
+			m.Tickets = append(m.Tickets, &pb.Ticket{Id: fmt.Sprintf("t%d", r.Intn(ticketPool))})
+		}
+		score := float64(r.Intn(100))
+		matches[i] = &matchInp{
+			match: m,
+			inp:   &pb.DefaultEvaluationCriteria{Score: score},
+			score: score,
+		}
+	}
+	sort.Sort(byScore(matches))
+	return matches
+}
+
+func packingScore(matches []*matchInp) float64 {
+	total := 0.0
+	for _, m := range matches {
+		total += m.score
+	}
+	return total
+}
+
+func requireDisjoint(t testing.TB, matches []*matchInp) {
+	owner := map[string]string{}
+	for _, m := range matches {
+		for _, ticket := range m.match.GetTickets() {
+			if o, ok := owner[ticket.GetId()]; ok && o != m.match.GetMatchId() {
+				t.Fatalf("matches %s and %s share ticket %s", o, m.match.GetMatchId(), ticket.GetId())
+			}
+			owner[ticket.GetId()] = m.match.GetMatchId()
+		}
+	}
+}
+
+func greedyPacking(matches []*matchInp) []*matchInp {
+	d := decollider{
+		ticketsUsed: make(map[string]*collidingMatch),
+	}
+	var result []*matchInp
+	for _, m := range matches {
+		before := len(d.resultIDs)
+		d.maybeAdd(m)
+		if len(d.resultIDs) > before {
+			result = append(result, m)
+		}
+	}
+	return result
+}
+
+func bruteForcePacking(matches []*matchInp) float64 {
+	best := 0.0
+	for set := 0; set < 1<<len(matches); set++ {
+		var chosen []*matchInp
+		for i, m := range matches {
+			if set&(1<<i) != 0 {
+				chosen = append(chosen, m)
+			}
+		}
+		if !disjoint(chosen) {
+			continue
+		}
+		var total float64
+		for _, m := range chosen {
+			total += m.score
+		}
+		if total > best {
+			best = total
+		}
+	}
+	return best
+}
+
+func disjoint(matches []*matchInp) bool {
+	owner := map[string]*matchInp{}
+	for _, m := range matches {
+		for _, ticket := range m.match.GetTickets() {
+			if o, ok := owner[ticket.GetId()]; ok && o != m {
+				return false
+			}
+			owner[ticket.GetId()] = m
+		}
+	}
+	return true
+}
+
+func TestMaxWeightPackingExact(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		matches := randomMatches(r, 12, 10)
+
+		got := maxWeightPacking(matches, defaultMaxExactSearchSize)
+		requireDisjoint(t, got)
+		require.Equal(t, bruteForcePacking(matches), packingScore(got))
+	}
+}
+
+func TestMaxWeightPackingLocalSearch(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 50; i++ {
+		matches := randomMatches(r, 200, 100)
+
+		got := maxWeightPacking(matches, 0)
+		requireDisjoint(t, got)
+		assert.GreaterOrEqual(t, packingScore(got), packingScore(greedyPacking(matches)))
+	}
+
+	// A high score match which collides with two matches whose total score is
+	// higher is replaced by them.
+	matches := []*matchInp{
+		{match: &pb.Match{MatchId: "a", Tickets: []*pb.Ticket{{Id: "1"}, {Id: "2"}}}, inp: &pb.DefaultEvaluationCriteria{Score: 10}, score: 10},
+		{match: &pb.Match{MatchId: "b", Tickets: []*pb.Ticket{{Id: "1"}}}, inp: &pb.DefaultEvaluationCriteria{Score: 6}, score: 6},
+		{match: &pb.Match{MatchId: "c", Tickets: []*pb.Ticket{{Id: "2"}}}, inp: &pb.DefaultEvaluationCriteria{Score: 6}, score: 6},
+	}
+	got := maxWeightPacking(matches, 0)
+	require.Len(t, got, 2)
+	assert.Equal(t, "b", got[0].match.GetMatchId())
+	assert.Equal(t, "c", got[1].match.GetMatchId())
+}
+
+func BenchmarkGreedy(b *testing.B) {
+	benchmarkPacking(b, greedyPacking)
+}
+
+func BenchmarkOptimal(b *testing.B) {
+	benchmarkPacking(b, func(matches []*matchInp) []*matchInp {
+		return maxWeightPacking(matches, defaultMaxExactSearchSize)
+	})
+}
+
+func benchmarkPacking(b *testing.B, pack func([]*matchInp) []*matchInp) {
+	for _, size := range []struct {
+		matches, tickets int
+	}{
+		{matches: 10, tickets: 20},
+		{matches: 100, tickets: 300},
+		{matches: 1000, tickets: 1000},
+		{matches: 10000, tickets: 10000},
+	} {
+		b.Run(fmt.Sprintf("matches=%d/tickets=%d", size.matches, size.tickets), func(b *testing.B) {
+			matches := randomMatches(rand.New(rand.NewSource(1)), size.matches, size.tickets)
+			var score float64
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				score = packingScore(pack(matches))
+			}
+			b.ReportMetric(score, "score")
+		})
+	}
+}
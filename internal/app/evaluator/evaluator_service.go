@@ -37,19 +37,27 @@ var (
 // and the Evaluator will return an accepted list of Matches.
 type Evaluator func(ctx context.Context, in <-chan *pb.Match, out chan<- string) error
 
+// RejectingEvaluator is an Evaluator which may also explain why matches were
+// not accepted, by sending them on the rejected channel.  A match must not be
+// both accepted and rejected.
+type RejectingEvaluator func(ctx context.Context, in <-chan *pb.Match, out chan<- string, rejected chan<- *pb.RejectedMatch) error
+
 // evaluatorService implements pb.EvaluatorServer, the server generated by
 // compiling the protobuf, by fulfilling the pb.EvaluatorServer interface.
 type evaluatorService struct {
-	evaluate Evaluator
+	evaluate RejectingEvaluator
 }
 
 // Evaluate is this harness's implementation of the gRPC call defined in
 // api/evaluator.proto.
 func (s *evaluatorService) Evaluate(stream pb.Evaluator_EvaluateServer) error {
 	g, ctx := errgroup.WithContext(stream.Context())
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
 
 	in := make(chan *pb.Match)
 	out := make(chan string)
+	rejected := make(chan *pb.RejectedMatch)
 
 	g.Go(func() error {
 		defer close(in)
@@ -74,21 +82,47 @@ func (s *evaluatorService) Evaluate(stream pb.Evaluator_EvaluateServer) error {
 	})
 	g.Go(func() error {
 		defer close(out)
-		return s.evaluate(ctx, in, out)
+		defer close(rejected)
+		return s.evaluate(ctx, in, out, rejected)
 	})
 	g.Go(func() error {
-		defer func() {
-			for range out {
-			}
-		}()
-
 		count := 0
-		for id := range out {
-			err := stream.Send(&pb.EvaluateResponse{MatchId: id})
-			if err != nil {
-				return err
+		var sendErr error
+		// Set to nil once closed, to no longer select on them.  Both channels
+		// are read until closed, even once sending failed, so that the
+		// evaluator never blocks sending on either of them.
+		outc, rejectedc := out, rejected
+		for outc != nil || rejectedc != nil {
+			var resp *pb.EvaluateResponse
+			select {
+			case id, ok := <-outc:
+				if !ok {
+					outc = nil
+					continue
+				}
+				resp = &pb.EvaluateResponse{MatchId: id}
+			case r, ok := <-rejectedc:
+				if !ok {
+					rejectedc = nil
+					continue
+				}
+				resp = &pb.EvaluateResponse{RejectedMatch: r}
 			}
-			count++
+			if sendErr != nil {
+				continue
+			}
+			sendErr = stream.Send(resp)
+			if sendErr != nil {
+				// Stops the evaluator early, if it watches the context.
+				cancel()
+				continue
+			}
+			if resp.MatchId != "" {
+				count++
+			}
+		}
+		if sendErr != nil {
+			return sendErr
 		}
 		stats.Record(ctx, matchesPerEvaluateResponse.M(int64(count)))
 		return nil
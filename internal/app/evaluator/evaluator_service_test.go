@@ -0,0 +1,86 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evaluator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+// fakeEvaluateStream receives the requests, and fails to send any response.
+type fakeEvaluateStream struct {
+	grpc.ServerStream
+	ctx      context.Context
+	requests []*pb.EvaluateRequest
+	sendErr  error
+}
+
+func (s *fakeEvaluateStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *fakeEvaluateStream) Recv() (*pb.EvaluateRequest, error) {
+	if len(s.requests) == 0 {
+		return nil, io.EOF
+	}
+	req := s.requests[0]
+	s.requests = s.requests[1:]
+	return req, nil
+}
+
+func (s *fakeEvaluateStream) Send(*pb.EvaluateResponse) error {
+	return s.sendErr
+}
+
+func TestEvaluateSendFailure(t *testing.T) {
+	stream := &fakeEvaluateStream{
+		ctx:     context.Background(),
+		sendErr: errors.New("stream broken"),
+	}
+	for i := 0; i < 10; i++ {
+		stream.requests = append(stream.requests, &pb.EvaluateRequest{Match: &pb.Match{MatchId: fmt.Sprintf("m%d", i)}})
+	}
+
+	// Rejects every match, and accepts none, without watching the context.
+	s := &evaluatorService{evaluate: func(ctx context.Context, in <-chan *pb.Match, out chan<- string, rejected chan<- *pb.RejectedMatch) error {
+		var matches []*pb.Match
+		for m := range in {
+			matches = append(matches, m)
+		}
+		for _, m := range matches {
+			rejected <- &pb.RejectedMatch{MatchId: m.GetMatchId(), Reason: pb.RejectedMatch_COLLISION}
+		}
+		return nil
+	}}
+
+	done := make(chan error)
+	go func() {
+		done <- s.Evaluate(stream)
+	}()
+	select {
+	case err := <-done:
+		assert.Equal(t, stream.sendErr, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Evaluate didn't return after failing to send")
+	}
+}
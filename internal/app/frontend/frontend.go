@@ -17,10 +17,14 @@ package frontend
 import (
 	"go.opencensus.io/stats"
 	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
 	"google.golang.org/grpc"
 	"open-match.dev/open-match/internal/appmain"
+	"open-match.dev/open-match/internal/auth"
+	"open-match.dev/open-match/internal/config"
 	"open-match.dev/open-match/internal/statestore"
 	"open-match.dev/open-match/internal/telemetry"
+	"open-match.dev/open-match/internal/tenant"
 	"open-match.dev/open-match/pkg/pb"
 )
 
@@ -33,12 +37,14 @@ var (
 		Name:        "open-match.dev/frontend/total_bytes_per_ticket",
 		Description: "Total bytes per ticket",
 		Aggregation: telemetry.DefaultBytesDistribution,
+		TagKeys:     []tag.Key{tenant.TagKey},
 	}
 	searchFieldsPerTicketView = &view.View{
 		Measure:     searchFieldsPerTicket,
 		Name:        "open-match.dev/frontend/searchfields_per_ticket",
 		Description: "SearchFields per ticket",
 		Aggregation: telemetry.DefaultCountDistribution,
+		TagKeys:     []tag.Key{tenant.TagKey},
 	}
 )
 
@@ -47,7 +53,12 @@ func BindService(p *appmain.Params, b *appmain.Bindings) error {
 	service := &frontendService{
 		cfg:   p.Config(),
 		store: statestore.New(p.Config()),
+		audit: p.Audit(),
 	}
+	prefix := "api." + p.ServiceName() + "."
+	b.AddCloser(config.Subscribe(p.Config(), prefix+"auth", func(cfg config.View) {
+		service.owners.set(cfg.GetBool(prefix+auth.ConfigNameBindTicketOwner), cfg.GetInt(prefix+auth.ConfigNameMaxTicketsPerOwner))
+	}))
 
 	b.AddHealthCheckFunc(service.store.HealthCheck)
 	b.AddHandleFunc(func(s *grpc.Server) {
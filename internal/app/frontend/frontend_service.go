@@ -16,6 +16,9 @@ package frontend
 
 import (
 	"context"
+	"math"
+	"sync"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes"
@@ -26,18 +29,54 @@ import (
 	"go.opencensus.io/trace"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"open-match.dev/open-match/internal/audit"
+	"open-match.dev/open-match/internal/auth"
 	"open-match.dev/open-match/internal/config"
+	"open-match.dev/open-match/internal/readycheck"
+	"open-match.dev/open-match/internal/rpc"
 	"open-match.dev/open-match/internal/statestore"
+	"open-match.dev/open-match/internal/tenant"
 	"open-match.dev/open-match/pkg/pb"
 )
 
 // frontendService implements the Frontend service that is used to create
 // Tickets and add, remove them from the pool for matchmaking.
 type frontendService struct {
-	cfg   config.View
-	store statestore.Service
+	cfg    config.View
+	store  statestore.Service
+	owners ownerPolicy
+	audit  *audit.Logger
 }
 
+// ownerPolicy restricts the Tickets of each authenticated identity, following
+// changes to the configuration.
+type ownerPolicy struct {
+	m sync.RWMutex
+	// bind restricts Tickets to the authenticated identity which created
+	// them.
+	bind bool
+	// maxTickets is how many Tickets each authenticated identity may have at
+	// once, or 0 for no limit.
+	maxTickets int
+}
+
+func (o *ownerPolicy) set(bind bool, maxTickets int) {
+	o.m.Lock()
+	defer o.m.Unlock()
+	o.bind = bind
+	o.maxTickets = maxTickets
+}
+
+func (o *ownerPolicy) get() (bind bool, maxTickets int) {
+	o.m.RLock()
+	defer o.m.RUnlock()
+	return o.bind, o.maxTickets
+}
+
+// ticketQuotaRetryDelay is how long callers over their ticket quota are told
+// to wait before retrying, as their Tickets are deleted once matched.
+const ticketQuotaRetryDelay = 5 * time.Second
+
 var (
 	logger = logrus.WithFields(logrus.Fields{
 		"app":       "openmatch",
@@ -60,11 +99,27 @@ func (s *frontendService) CreateTicket(ctx context.Context, req *pb.CreateTicket
 	if req.Ticket.CreateTime != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "tickets cannot be created with create time set")
 	}
+	if math.IsNaN(req.Ticket.Priority) || math.IsInf(req.Ticket.Priority, 0) {
+		return nil, status.Errorf(codes.InvalidArgument, ".ticket.priority must be a finite number")
+	}
 
-	return doCreateTicket(ctx, req, s.store)
+	owner := auth.FromContext(ctx)
+	_, maxOwned := s.owners.get()
+	maxActive := tenant.MaxActiveTickets(s.cfg, tenant.FromContext(ctx))
+	ticket, err := doCreateTicket(ctx, req, s.store, owner, maxOwned, maxActive)
+	e := audit.Event{Operation: audit.CreateTicket, Error: audit.ErrorOf(err)}
+	if err == nil {
+		e.TicketIDs = []string{ticket.Id}
+	}
+	s.audit.Log(ctx, e)
+	return ticket, err
 }
 
-func doCreateTicket(ctx context.Context, req *pb.CreateTicketRequest, store statestore.Service) (*pb.Ticket, error) {
+// doCreateTicket creates the ticket, owned by the owner unless it is "".  The
+// owner may own at most maxOwned tickets, or any number if maxOwned is 0, and
+// the tenant of the request may have at most maxActive tickets in the pool, or
+// any number if maxActive is 0.
+func doCreateTicket(ctx context.Context, req *pb.CreateTicketRequest, store statestore.Service, owner string, maxOwned, maxActive int) (*pb.Ticket, error) {
 	// Generate a ticket id and create a Ticket in state storage
 	ticket, ok := proto.Clone(req.Ticket).(*pb.Ticket)
 	if !ok {
@@ -90,16 +145,72 @@ func doCreateTicket(ctx context.Context, req *pb.CreateTicketRequest, store stat
 		return nil, err
 	}
 
-	err = store.IndexTicket(ctx, ticket)
+	if owner != "" {
+		// The quota of the owner is checked, and the ticket counted, at once
+		// so that concurrent calls can't exceed it.  The ticket isn't indexed
+		// yet, so isn't seen by matchmaking before it is deleted.
+		var set bool
+		set, err = store.SetTicketOwner(ctx, ticket.Id, owner, maxOwned)
+		if err == nil && !set {
+			err = rpc.ResourceExhaustedError(ticketQuotaRetryDelay, "%q already has %d active tickets, the maximum allowed", owner, maxOwned)
+		}
+		if err != nil {
+			if status.Code(err) != codes.ResourceExhausted {
+				logger.WithFields(logrus.Fields{
+					"error":  err.Error(),
+					"ticket": ticket,
+				}).Error("failed to set the owner of the ticket")
+			}
+			deleteTicket(ctx, store, ticket)
+			return nil, err
+		}
+	}
+
+	// Like the quota of the owner, the quota of the tenant is checked, and the
+	// ticket indexed, at once.
+	indexed, err := store.IndexTicketWithinLimit(ctx, ticket, maxActive)
+	if err == nil && !indexed {
+		err = rpc.ResourceExhaustedError(ticketQuotaRetryDelay, "tenant %q already has %d active tickets, the maximum allowed", tenant.FromContext(ctx), maxActive)
+	}
 	if err != nil {
+		if status.Code(err) != codes.ResourceExhausted {
+			logger.WithFields(logrus.Fields{
+				"error":  err.Error(),
+				"ticket": ticket,
+			}).Error("failed to index the ticket")
+		}
+		deleteTicket(ctx, store, ticket)
+		return nil, err
+	}
+
+	createTime, _ := ptypes.Timestamp(ticket.CreateTime)
+	recordTicketEvent(ctx, store, ticket.Id, statestore.TicketCreated, createTime)
+
+	return ticket, nil
+}
+
+// deleteTicket deletes the ticket which failed to be created.
+func deleteTicket(ctx context.Context, store statestore.Service, ticket *pb.Ticket) {
+	if err := store.DeleteTicket(ctx, ticket.Id); err != nil {
 		logger.WithFields(logrus.Fields{
 			"error":  err.Error(),
 			"ticket": ticket,
-		}).Error("failed to index the ticket")
-		return nil, err
+		}).Error("failed to delete the ticket which failed to be created")
 	}
+}
 
-	return ticket, nil
+// recordTicketEvent records the event in the lifecycle of the ticket.  Failing
+// to do so only loses the latency metrics of the ticket, so it doesn't fail
+// the call.
+func recordTicketEvent(ctx context.Context, store statestore.Service, id string, event statestore.TicketEvent, t time.Time) {
+	err := store.RecordTicketEvents(ctx, event, map[string]time.Time{id: t})
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"error": err.Error(),
+			"id":    id,
+			"event": event,
+		}).Error("failed to record the ticket event")
+	}
 }
 
 // DeleteTicket immediately stops Open Match from using the Ticket for matchmaking and removes the Ticket from state storage.
@@ -107,7 +218,16 @@ func doCreateTicket(ctx context.Context, req *pb.CreateTicketRequest, store stat
 //   - If SearchFields exist in a Ticket, DeleteTicket will deindex the fields lazily.
 // Users may still be able to assign/get a ticket after calling DeleteTicket on it.
 func (s *frontendService) DeleteTicket(ctx context.Context, req *pb.DeleteTicketRequest) (*empty.Empty, error) {
-	err := doDeleteTicket(ctx, req.GetTicketId(), s.store)
+	err := s.checkTicketOwner(ctx, req.GetTicketId())
+	if err != nil {
+		return nil, err
+	}
+	err = doDeleteTicket(ctx, req.GetTicketId(), s.store)
+	s.audit.Log(ctx, audit.Event{
+		Operation: audit.DeleteTicket,
+		TicketIDs: []string{req.GetTicketId()},
+		Error:     audit.ErrorOf(err),
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -127,8 +247,9 @@ func doDeleteTicket(ctx context.Context, id string, store statestore.Service) er
 
 	//'lazy' ticket delete that should be called after a ticket
 	// has been deindexed.
+	tenantName := tenant.FromContext(ctx)
 	go func() {
-		ctx, span := trace.StartSpan(context.Background(), "open-match/frontend.DeleteTicketLazy")
+		ctx, span := trace.StartSpan(tenant.NewContext(context.Background(), tenantName), "open-match/frontend.DeleteTicketLazy")
 		defer span.End()
 		err := store.DeleteTicket(ctx, id)
 		if err != nil {
@@ -136,6 +257,8 @@ func doDeleteTicket(ctx context.Context, id string, store statestore.Service) er
 				"error": err.Error(),
 				"id":    id,
 			}).Error("failed to delete the ticket")
+		} else {
+			recordTicketEvent(ctx, store, id, statestore.TicketDeleted, time.Now())
 		}
 		err = store.DeleteTicketsFromIgnoreList(ctx, []string{id})
 		if err != nil {
@@ -152,6 +275,10 @@ func doDeleteTicket(ctx context.Context, id string, store statestore.Service) er
 
 // GetTicket get the Ticket associated with the specified TicketId.
 func (s *frontendService) GetTicket(ctx context.Context, req *pb.GetTicketRequest) (*pb.Ticket, error) {
+	err := s.checkTicketOwner(ctx, req.GetTicketId())
+	if err != nil {
+		return nil, err
+	}
 	return doGetTickets(ctx, req.GetTicketId(), s.store)
 }
 
@@ -172,6 +299,10 @@ func doGetTickets(ctx context.Context, id string, store statestore.Service) (*pb
 //   - If the Assignment is not updated, GetAssignment will retry using the configured backoff strategy.
 func (s *frontendService) WatchAssignments(req *pb.WatchAssignmentsRequest, stream pb.FrontendService_WatchAssignmentsServer) error {
 	ctx := stream.Context()
+	err := s.checkTicketOwner(ctx, req.GetTicketId())
+	if err != nil {
+		return err
+	}
 	for {
 		select {
 		case <-ctx.Done():
@@ -206,3 +337,58 @@ func doWatchAssignments(ctx context.Context, id string, sender func(*pb.Assignme
 
 	return store.GetAssignments(ctx, id, callback)
 }
+
+// AcceptMatch accepts the ready check of the match the Ticket is in.
+func (s *frontendService) AcceptMatch(ctx context.Context, req *pb.AcceptMatchRequest) (*pb.AcceptMatchResponse, error) {
+	if req.GetTicketId() == "" {
+		return nil, status.Error(codes.InvalidArgument, ".ticket_id is required")
+	}
+
+	err := s.checkTicketOwner(ctx, req.GetTicketId())
+	if err != nil {
+		return nil, err
+	}
+
+	matchID, err := readycheck.Accept(ctx, s.store, req.GetTicketId())
+	if err != nil {
+		return nil, err
+	}
+	return &pb.AcceptMatchResponse{MatchId: matchID}, nil
+}
+
+// DeclineMatch declines the ready check of the match the Ticket is in,
+// releasing the Tickets of the other players.
+func (s *frontendService) DeclineMatch(ctx context.Context, req *pb.DeclineMatchRequest) (*pb.DeclineMatchResponse, error) {
+	if req.GetTicketId() == "" {
+		return nil, status.Error(codes.InvalidArgument, ".ticket_id is required")
+	}
+
+	err := s.checkTicketOwner(ctx, req.GetTicketId())
+	if err != nil {
+		return nil, err
+	}
+
+	matchID, err := readycheck.Decline(ctx, s.store, req.GetTicketId())
+	if err != nil {
+		return nil, err
+	}
+	return &pb.DeclineMatchResponse{MatchId: matchID}, nil
+}
+
+// checkTicketOwner returns an error unless the caller owns the Ticket, when
+// Tickets are bound to the identity which created them.  Tickets created
+// without an identity may be used by anyone.
+func (s *frontendService) checkTicketOwner(ctx context.Context, id string) error {
+	if bind, _ := s.owners.get(); !bind {
+		return nil
+	}
+
+	owner, err := s.store.GetTicketOwner(ctx, id)
+	if err != nil {
+		return err
+	}
+	if owner != "" && owner != auth.FromContext(ctx) {
+		return status.Errorf(codes.PermissionDenied, "Ticket id:%s belongs to another caller", id)
+	}
+	return nil
+}
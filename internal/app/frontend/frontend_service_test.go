@@ -15,19 +15,25 @@
 package frontend
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"regexp"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/golang/protobuf/ptypes"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"open-match.dev/open-match/internal/audit"
+	"open-match.dev/open-match/internal/auth"
 	"open-match.dev/open-match/internal/statestore"
 	statestoreTesting "open-match.dev/open-match/internal/statestore/testing"
+	"open-match.dev/open-match/internal/tenant"
 	utilTesting "open-match.dev/open-match/internal/util/testing"
 	"open-match.dev/open-match/pkg/pb"
 )
@@ -76,7 +82,7 @@ func TestDoCreateTickets(t *testing.T) {
 			ctx, cancel := context.WithCancel(utilTesting.NewContext(t))
 			test.preAction(cancel)
 
-			res, err := doCreateTicket(ctx, &pb.CreateTicketRequest{Ticket: test.ticket}, store)
+			res, err := doCreateTicket(ctx, &pb.CreateTicketRequest{Ticket: test.ticket}, store, "", 0, 0)
 			assert.Equal(t, test.wantCode, status.Convert(err).Code())
 			if err == nil {
 				matched, err := regexp.MatchString(`[0-9a-v]{20}`, res.GetId())
@@ -88,6 +94,21 @@ func TestDoCreateTickets(t *testing.T) {
 	}
 }
 
+func TestDoCreateTicketRecordsLifecycle(t *testing.T) {
+	store, closer := statestoreTesting.NewStoreServiceForTesting(t, viper.New())
+	defer closer()
+	ctx := utilTesting.NewContext(t)
+
+	ticket, err := doCreateTicket(ctx, &pb.CreateTicketRequest{Ticket: &pb.Ticket{}}, store, "", 0, 0)
+	assert.Nil(t, err)
+
+	lifecycles, err := store.GetTicketLifecycles(ctx, []string{ticket.GetId()})
+	assert.Nil(t, err)
+	created, err := ptypes.Timestamp(ticket.GetCreateTime())
+	assert.Nil(t, err)
+	assert.True(t, created.Equal(lifecycles[ticket.GetId()].Times[statestore.TicketCreated]))
+}
+
 func TestDoWatchAssignments(t *testing.T) {
 	testTicket := &pb.Ticket{
 		Id: "test-id",
@@ -274,3 +295,123 @@ func TestDoGetTicket(t *testing.T) {
 		})
 	}
 }
+
+func TestTenantQuota(t *testing.T) {
+	cfg := viper.New()
+	cfg.Set("tenants.limited.maxActiveTickets", 1)
+	store, closer := statestoreTesting.NewStoreServiceForTesting(t, cfg)
+	defer closer()
+	s := &frontendService{cfg: cfg, store: store, audit: audit.NewWriter(&bytes.Buffer{}, "frontend")}
+
+	for _, name := range []string{"", "limited", "unlimited"} {
+		ctx := tenant.NewContext(utilTesting.NewContext(t), name)
+		_, err := s.CreateTicket(ctx, &pb.CreateTicketRequest{Ticket: &pb.Ticket{}})
+		assert.Nil(t, err)
+	}
+
+	for name, wantCode := range map[string]codes.Code{
+		"":          codes.OK,
+		"limited":   codes.ResourceExhausted,
+		"unlimited": codes.OK,
+	} {
+		ctx := tenant.NewContext(utilTesting.NewContext(t), name)
+		_, err := s.CreateTicket(ctx, &pb.CreateTicketRequest{Ticket: &pb.Ticket{}})
+		assert.Equal(t, wantCode, status.Code(err), name)
+	}
+
+	// The rejected ticket isn't left behind.
+	count, err := store.CountIndexedTickets(tenant.NewContext(utilTesting.NewContext(t), "limited"))
+	assert.Nil(t, err)
+	assert.Equal(t, 1, count)
+}
+
+func TestOwnerQuota(t *testing.T) {
+	store, closer := statestoreTesting.NewStoreServiceForTesting(t, viper.New())
+	defer closer()
+
+	ctx := utilTesting.NewContext(t)
+	ticket, err := doCreateTicket(ctx, &pb.CreateTicketRequest{Ticket: &pb.Ticket{}}, store, "player-1", 1, 0)
+	assert.Nil(t, err)
+
+	_, err = doCreateTicket(ctx, &pb.CreateTicketRequest{Ticket: &pb.Ticket{}}, store, "player-1", 1, 0)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.Len(t, status.Convert(err).Details(), 1)
+	count, err := store.CountIndexedTickets(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, count)
+
+	_, err = doCreateTicket(ctx, &pb.CreateTicketRequest{Ticket: &pb.Ticket{}}, store, "player-2", 1, 0)
+	assert.Nil(t, err)
+	_, err = doCreateTicket(ctx, &pb.CreateTicketRequest{Ticket: &pb.Ticket{}}, store, "", 1, 0)
+	assert.Nil(t, err)
+
+	assert.Nil(t, store.DeleteTicket(ctx, ticket.Id))
+	_, err = doCreateTicket(ctx, &pb.CreateTicketRequest{Ticket: &pb.Ticket{}}, store, "player-1", 1, 0)
+	assert.Nil(t, err)
+}
+
+func TestCheckTicketOwner(t *testing.T) {
+	store, closer := statestoreTesting.NewStoreServiceForTesting(t, viper.New())
+	defer closer()
+	s := &frontendService{store: store}
+	s.owners.set(true, 0)
+
+	ctx := utilTesting.NewContext(t)
+	owned, err := doCreateTicket(ctx, &pb.CreateTicketRequest{Ticket: &pb.Ticket{}}, store, "player-1", 0, 0)
+	assert.Nil(t, err)
+	unowned, err := doCreateTicket(ctx, &pb.CreateTicketRequest{Ticket: &pb.Ticket{}}, store, "", 0, 0)
+	assert.Nil(t, err)
+
+	for _, identity := range []string{"player-1", "player-2", ""} {
+		wantCode := codes.PermissionDenied
+		if identity == "player-1" {
+			wantCode = codes.OK
+		}
+		_, err := s.GetTicket(auth.NewContext(ctx, identity), &pb.GetTicketRequest{TicketId: owned.Id})
+		assert.Equal(t, wantCode, status.Code(err), identity)
+
+		_, err = s.GetTicket(auth.NewContext(ctx, identity), &pb.GetTicketRequest{TicketId: unowned.Id})
+		assert.Nil(t, err, identity)
+	}
+
+	_, err = s.DeleteTicket(auth.NewContext(ctx, "player-2"), &pb.DeleteTicketRequest{TicketId: owned.Id})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// Without binding, anyone may use the ticket.
+	s.owners.set(false, 0)
+	_, err = s.GetTicket(auth.NewContext(ctx, "player-2"), &pb.GetTicketRequest{TicketId: owned.Id})
+	assert.Nil(t, err)
+}
+
+func TestAuditTicketChanges(t *testing.T) {
+	store, closer := statestoreTesting.NewStoreServiceForTesting(t, viper.New())
+	defer closer()
+	buf := &bytes.Buffer{}
+	s := &frontendService{cfg: viper.New(), store: store, audit: audit.NewWriter(buf, "frontend")}
+
+	ctx := auth.NewContext(utilTesting.NewContext(t), "player-1")
+	ticket, err := s.CreateTicket(ctx, &pb.CreateTicketRequest{Ticket: &pb.Ticket{}})
+	assert.Nil(t, err)
+	_, err = s.DeleteTicket(ctx, &pb.DeleteTicketRequest{TicketId: ticket.Id})
+	assert.Nil(t, err)
+
+	// Invalid requests don't change any state, and aren't audited.
+	_, err = s.CreateTicket(ctx, &pb.CreateTicketRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	var events []audit.Event
+	d := json.NewDecoder(buf)
+	for d.More() {
+		var e audit.Event
+		assert.Nil(t, d.Decode(&e))
+		events = append(events, e)
+	}
+	if assert.Len(t, events, 2) {
+		for i, op := range []string{audit.CreateTicket, audit.DeleteTicket} {
+			assert.Equal(t, op, events[i].Operation)
+			assert.Equal(t, "identity:player-1", events[i].Caller)
+			assert.Equal(t, []string{ticket.Id}, events[i].TicketIDs)
+			assert.Empty(t, events[i].Error)
+		}
+	}
+}
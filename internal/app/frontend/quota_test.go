@@ -0,0 +1,98 @@
+// +build !race
+
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package frontend
+
+// The quotas are enforced by Lua scripts, which Redis runs atomically.  The
+// miniredis used by tests releases its lock while running scripts, which the
+// scheduling of the race detector exposes, so these tests don't run with it.
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	statestoreTesting "open-match.dev/open-match/internal/statestore/testing"
+	"open-match.dev/open-match/internal/tenant"
+	utilTesting "open-match.dev/open-match/internal/util/testing"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+func TestOwnerQuotaConcurrentIgnoreRace(t *testing.T) {
+	store, closer := statestoreTesting.NewStoreServiceForTesting(t, viper.New())
+	defer closer()
+	ctx := utilTesting.NewContext(t)
+
+	const maxOwned = 3
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := doCreateTicket(ctx, &pb.CreateTicketRequest{Ticket: &pb.Ticket{}}, store, "player-1", maxOwned, 0)
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	created := 0
+	for err := range errs {
+		if err == nil {
+			created++
+		} else {
+			assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+		}
+	}
+	assert.Equal(t, maxOwned, created)
+}
+
+func TestTenantQuotaConcurrentIgnoreRace(t *testing.T) {
+	store, closer := statestoreTesting.NewStoreServiceForTesting(t, viper.New())
+	defer closer()
+	ctx := tenant.NewContext(utilTesting.NewContext(t), "limited")
+
+	const maxActive = 3
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := doCreateTicket(ctx, &pb.CreateTicketRequest{Ticket: &pb.Ticket{}}, store, "", 0, maxActive)
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	created := 0
+	for err := range errs {
+		if err == nil {
+			created++
+		} else {
+			assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+		}
+	}
+	assert.Equal(t, maxActive, created)
+	count, err := store.CountIndexedTickets(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, maxActive, count)
+}
@@ -0,0 +1,86 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"sort"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"open-match.dev/open-match/internal/config"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+const (
+	// configNameWaitTimePriority is the priority a ticket gains for every
+	// second it waits, when ordering by effective priority.
+	configNameWaitTimePriority = "queryWaitTimePriority"
+	defaultWaitTimePriority    = 1.0
+)
+
+// sortTickets sorts the tickets in the requested order.  Ties are broken by
+// oldest ticket first, then by id so that the order is stable across queries.
+func sortTickets(cfg config.View, order pb.TicketOrder, tickets []*pb.Ticket) {
+	if order == pb.TicketOrder_UNORDERED {
+		return
+	}
+
+	waitTimePriority := defaultWaitTimePriority
+	if cfg.IsSet(configNameWaitTimePriority) {
+		waitTimePriority = cfg.GetFloat64(configNameWaitTimePriority)
+	}
+
+	now := time.Now()
+	type sortable struct {
+		ticket   *pb.Ticket
+		created  time.Time
+		priority float64
+	}
+	s := make([]sortable, len(tickets))
+	for i, t := range tickets {
+		// Tickets without a valid create time are treated as the newest.
+		created, err := ptypes.Timestamp(t.GetCreateTime())
+		if err != nil {
+			created = now
+		}
+		priority := t.GetPriority()
+		if order == pb.TicketOrder_EFFECTIVE_PRIORITY {
+			priority += effectiveWaitPriority(waitTimePriority, now.Sub(created))
+		}
+		s[i] = sortable{ticket: t, created: created, priority: priority}
+	}
+
+	sort.Slice(s, func(i, j int) bool {
+		if s[i].priority != s[j].priority {
+			return s[i].priority > s[j].priority
+		}
+		if !s[i].created.Equal(s[j].created) {
+			return s[i].created.Before(s[j].created)
+		}
+		return s[i].ticket.GetId() < s[j].ticket.GetId()
+	})
+
+	for i := range s {
+		tickets[i] = s[i].ticket
+	}
+}
+
+// effectiveWaitPriority returns the priority gained by waiting.
+func effectiveWaitPriority(waitTimePriority float64, waited time.Duration) float64 {
+	if waited < 0 {
+		return 0
+	}
+	return waitTimePriority * waited.Seconds()
+}
@@ -0,0 +1,65 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+func TestSortTickets(t *testing.T) {
+	now := time.Now()
+	ticket := func(id string, priority float64, waited time.Duration) *pb.Ticket {
+		ts, err := ptypes.TimestampProto(now.Add(-waited))
+		require.Nil(t, err)
+		return &pb.Ticket{Id: id, Priority: priority, CreateTime: ts}
+	}
+	ids := func(tickets []*pb.Ticket) []string {
+		var result []string
+		for _, t := range tickets {
+			result = append(result, t.Id)
+		}
+		return result
+	}
+
+	tickets := []*pb.Ticket{
+		ticket("new-high", 10, 0),
+		ticket("old-low", 1, time.Minute),
+		ticket("mid", 5, 2*time.Second),
+		ticket("mid-older", 5, 4*time.Second),
+		{Id: "no-create-time", Priority: 5},
+	}
+
+	cfg := viper.New()
+
+	sortTickets(cfg, pb.TicketOrder_UNORDERED, tickets)
+	require.Equal(t, []string{"new-high", "old-low", "mid", "mid-older", "no-create-time"}, ids(tickets))
+
+	sortTickets(cfg, pb.TicketOrder_PRIORITY, tickets)
+	require.Equal(t, []string{"new-high", "mid-older", "mid", "no-create-time", "old-low"}, ids(tickets))
+
+	// 1 priority per second waited by default.
+	sortTickets(cfg, pb.TicketOrder_EFFECTIVE_PRIORITY, tickets)
+	require.Equal(t, []string{"old-low", "new-high", "mid-older", "mid", "no-create-time"}, ids(tickets))
+
+	cfg.Set(configNameWaitTimePriority, 0.1)
+	sortTickets(cfg, pb.TicketOrder_EFFECTIVE_PRIORITY, tickets)
+	require.Equal(t, []string{"new-high", "old-low", "mid-older", "mid", "no-create-time"}, ids(tickets))
+}
@@ -17,9 +17,12 @@ package query
 import (
 	"go.opencensus.io/stats"
 	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
 	"google.golang.org/grpc"
 	"open-match.dev/open-match/internal/appmain"
+	"open-match.dev/open-match/internal/statestore"
 	"open-match.dev/open-match/internal/telemetry"
+	"open-match.dev/open-match/internal/tenant"
 	"open-match.dev/open-match/pkg/pb"
 )
 
@@ -34,36 +37,42 @@ var (
 		Measure:     ticketsPerQuery,
 		Name:        "open-match.dev/query/tickets_per_query",
 		Description: "Tickets per query",
+		TagKeys:     []tag.Key{tenant.TagKey},
 		Aggregation: telemetry.DefaultCountDistribution,
 	}
 	cacheTotalItemsView = &view.View{
 		Measure:     cacheTotalItems,
 		Name:        "open-match.dev/query/total_cached_items",
 		Description: "Total number of cached tickets",
+		TagKeys:     []tag.Key{tenant.TagKey},
 		Aggregation: view.LastValue(),
 	}
 	cacheFetchedItemsView = &view.View{
 		Measure:     cacheFetchedItems,
 		Name:        "open-match.dev/query/total_fetched_items",
 		Description: "Total number of fetched tickets",
+		TagKeys:     []tag.Key{tenant.TagKey},
 		Aggregation: view.Sum(),
 	}
 	cacheUpdateView = &view.View{
 		Measure:     cacheWaitingQueries,
 		Name:        "open-match.dev/query/cache_updates",
 		Description: "Number of query cache updates in total",
+		TagKeys:     []tag.Key{tenant.TagKey},
 		Aggregation: view.Count(),
 	}
 	cacheWaitingQueriesView = &view.View{
 		Measure:     cacheWaitingQueries,
 		Name:        "open-match.dev/query/waiting_requests",
 		Description: "Number of waiting requests in total",
+		TagKeys:     []tag.Key{tenant.TagKey},
 		Aggregation: telemetry.DefaultCountDistribution,
 	}
 	cacheUpdateLatencyView = &view.View{
 		Measure:     cacheUpdateLatency,
 		Name:        "open-match.dev/query/update_latency",
 		Description: "Time elapsed of each query cache update",
+		TagKeys:     []tag.Key{tenant.TagKey},
 		Aggregation: telemetry.DefaultMillisecondsDistribution,
 	}
 )
@@ -71,9 +80,11 @@ var (
 // BindService creates the query service and binds it to the serving harness.
 func BindService(p *appmain.Params, b *appmain.Bindings) error {
 	service := &queryService{
-		cfg: p.Config(),
-		tc:  newTicketCache(b, p.Config()),
+		cfg:    p.Config(),
+		store:  statestore.New(p.Config()),
+		caches: make(map[string]*ticketCache),
 	}
+	b.AddHealthCheckFunc(service.store.HealthCheck)
 
 	b.AddHandleFunc(func(s *grpc.Server) {
 		pb.RegisterQueryServiceServer(s, service)
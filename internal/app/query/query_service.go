@@ -25,10 +25,10 @@ import (
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
-	"open-match.dev/open-match/internal/appmain"
 	"open-match.dev/open-match/internal/config"
 	"open-match.dev/open-match/internal/filter"
 	"open-match.dev/open-match/internal/statestore"
+	"open-match.dev/open-match/internal/tenant"
 	"open-match.dev/open-match/pkg/pb"
 )
 
@@ -42,8 +42,45 @@ var (
 // queryService API provides utility functions for common MMF functionality such
 // as retreiving Tickets from state storage.
 type queryService struct {
-	cfg config.View
-	tc  *ticketCache
+	cfg   config.View
+	store statestore.Service
+
+	// Each tenant has its own ticket cache, created on its first query.
+	// Clients name their tenant, so caches of tenants idle for
+	// ticketCacheIdleTimeout are evicted.
+	mu        sync.Mutex
+	caches    map[string]*ticketCache
+	lastEvict time.Time
+}
+
+// ticketCacheIdleTimeout is how long the ticket cache of a tenant is kept
+// without queries.
+const ticketCacheIdleTimeout = 10 * time.Minute
+
+// ticketCache returns the ticket cache of the request's tenant.
+func (s *queryService) ticketCache(ctx context.Context) *ticketCache {
+	name := tenant.FromContext(ctx)
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if now.Sub(s.lastEvict) >= ticketCacheIdleTimeout {
+		// Queries still running on an evicted cache keep using it, while new
+		// queries of its tenant create a new cache.
+		for n, tc := range s.caches {
+			if now.Sub(tc.lastUsed) >= ticketCacheIdleTimeout {
+				delete(s.caches, n)
+			}
+		}
+		s.lastEvict = now
+	}
+	tc, ok := s.caches[name]
+	if !ok {
+		tc = newTicketCache(s.store, name)
+		s.caches[name] = tc
+	}
+	tc.lastUsed = now
+	return tc
 }
 
 func (s *queryService) QueryTickets(req *pb.QueryTicketsRequest, responseServer pb.QueryService_QueryTicketsServer) error {
@@ -59,7 +96,7 @@ func (s *queryService) QueryTickets(req *pb.QueryTicketsRequest, responseServer
 	}
 
 	var results []*pb.Ticket
-	err = s.tc.request(ctx, func(tickets map[string]*pb.Ticket) {
+	err = s.ticketCache(ctx).request(ctx, func(tickets map[string]*pb.Ticket) {
 		for _, ticket := range tickets {
 			if pf.In(ticket) {
 				results = append(results, ticket)
@@ -71,6 +108,7 @@ func (s *queryService) QueryTickets(req *pb.QueryTicketsRequest, responseServer
 		return err
 	}
 	stats.Record(ctx, ticketsPerQuery.M(int64(len(results))))
+	sortTickets(s.cfg, req.GetOrder(), results)
 
 	pSize := getPageSize(s.cfg)
 	for start := 0; start < len(results); start += pSize {
@@ -102,11 +140,11 @@ func (s *queryService) QueryTicketIds(req *pb.QueryTicketIdsRequest, responseSer
 		return err
 	}
 
-	var results []string
-	err = s.tc.request(ctx, func(tickets map[string]*pb.Ticket) {
-		for id, ticket := range tickets {
+	var tickets []*pb.Ticket
+	err = s.ticketCache(ctx).request(ctx, func(all map[string]*pb.Ticket) {
+		for _, ticket := range all {
 			if pf.In(ticket) {
-				results = append(results, id)
+				tickets = append(tickets, ticket)
 			}
 		}
 	})
@@ -114,7 +152,13 @@ func (s *queryService) QueryTicketIds(req *pb.QueryTicketIdsRequest, responseSer
 		logger.WithError(err).Error("Failed to run request.")
 		return err
 	}
-	stats.Record(ctx, ticketsPerQuery.M(int64(len(results))))
+	stats.Record(ctx, ticketsPerQuery.M(int64(len(tickets))))
+	sortTickets(s.cfg, req.GetOrder(), tickets)
+
+	results := make([]string, 0, len(tickets))
+	for _, ticket := range tickets {
+		results = append(results, ticket.GetId())
+	}
 
 	pSize := getPageSize(s.cfg)
 	for start := 0; start < len(results); start += pSize {
@@ -172,7 +216,8 @@ func getPageSize(cfg config.View) int {
 // ticketCache unifies concurrent requests into a single cache update, and
 // gives a safe view into that map cache.
 type ticketCache struct {
-	store statestore.Service
+	store  statestore.Service
+	tenant string
 
 	requests chan *cacheRequest
 
@@ -183,22 +228,26 @@ type ticketCache struct {
 
 	wg sync.WaitGroup
 
+	// lastUsed is the time of the last query, guarded by the mutex of the
+	// query service.
+	lastUsed time.Time
+
 	// Mutlithreaded unsafe fields, only to be written by update, and read when
 	// request given the ok.
 	tickets map[string]*pb.Ticket
 	err     error
 }
 
-func newTicketCache(b *appmain.Bindings, cfg config.View) *ticketCache {
+func newTicketCache(store statestore.Service, tenantName string) *ticketCache {
 	tc := &ticketCache{
-		store:           statestore.New(cfg),
+		store:           store,
+		tenant:          tenantName,
 		requests:        make(chan *cacheRequest),
 		startRunRequest: make(chan struct{}, 1),
 		tickets:         make(map[string]*pb.Ticket),
 	}
 
 	tc.startRunRequest <- struct{}{}
-	b.AddHealthCheckFunc(tc.store.HealthCheck)
 
 	return tc
 }
@@ -261,7 +310,7 @@ collectAllWaiting:
 	}
 
 	tc.update()
-	stats.Record(context.Background(), cacheWaitingQueries.M(int64(len(reqs))))
+	stats.Record(tenant.NewContext(context.Background(), tc.tenant), cacheWaitingQueries.M(int64(len(reqs))))
 
 	// Send WaitGroup to query calls, letting them run their query on the ticket
 	// cache.
@@ -279,10 +328,11 @@ collectAllWaiting:
 }
 
 func (tc *ticketCache) update() {
+	ctx := tenant.NewContext(context.Background(), tc.tenant)
 	st := time.Now()
 	previousCount := len(tc.tickets)
 
-	currentAll, err := tc.store.GetIndexedIDSet(context.Background())
+	currentAll, err := tc.store.GetIndexedIDSet(ctx)
 	if err != nil {
 		tc.err = err
 		return
@@ -304,7 +354,7 @@ func (tc *ticketCache) update() {
 		}
 	}
 
-	newTickets, err := tc.store.GetTickets(context.Background(), toFetch)
+	newTickets, err := tc.store.GetTickets(ctx, toFetch)
 	if err != nil {
 		tc.err = err
 		return
@@ -314,9 +364,9 @@ func (tc *ticketCache) update() {
 		tc.tickets[t.Id] = t
 	}
 
-	stats.Record(context.Background(), cacheTotalItems.M(int64(previousCount)))
-	stats.Record(context.Background(), cacheFetchedItems.M(int64(len(toFetch))))
-	stats.Record(context.Background(), cacheUpdateLatency.M(float64(time.Since(st))/float64(time.Millisecond)))
+	stats.Record(ctx, cacheTotalItems.M(int64(previousCount)))
+	stats.Record(ctx, cacheFetchedItems.M(int64(len(toFetch))))
+	stats.Record(ctx, cacheUpdateLatency.M(float64(time.Since(st))/float64(time.Millisecond)))
 
 	logger.Debugf("Ticket Cache update: Previous %d, Deleted %d, Fetched %d, Current %d", previousCount, deletedCount, len(toFetch), len(tc.tickets))
 	tc.err = nil
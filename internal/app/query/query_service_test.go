@@ -18,7 +18,10 @@ import (
 	"testing"
 
 	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
 	"open-match.dev/open-match/internal/config"
+	"open-match.dev/open-match/internal/tenant"
+	utilTesting "open-match.dev/open-match/internal/util/testing"
 )
 
 func TestGetPageSize(t *testing.T) {
@@ -67,3 +70,19 @@ func TestGetPageSize(t *testing.T) {
 		})
 	}
 }
+
+func TestTicketCacheEviction(t *testing.T) {
+	s := &queryService{caches: make(map[string]*ticketCache)}
+	ctx := utilTesting.NewContext(t)
+
+	a := s.ticketCache(tenant.NewContext(ctx, "a"))
+	require.Same(t, a, s.ticketCache(tenant.NewContext(ctx, "a")))
+	b := s.ticketCache(tenant.NewContext(ctx, "b"))
+
+	// Only the cache of the idle tenant is evicted.
+	a.lastUsed = a.lastUsed.Add(-ticketCacheIdleTimeout)
+	s.lastEvict = s.lastEvict.Add(-ticketCacheIdleTimeout)
+	require.Same(t, b, s.ticketCache(tenant.NewContext(ctx, "b")))
+	require.Len(t, s.caches, 1)
+	require.True(t, a != s.ticketCache(tenant.NewContext(ctx, "a")))
+}
@@ -46,12 +46,14 @@ func RunApplication() {
 			"error": err.Error(),
 		}).Fatalf("cannot read configuration.")
 	}
-	logging.ConfigureLogging(cfg)
+	unsubscribeLogging := config.Subscribe(cfg, "logging", logging.ConfigureLogging)
 
-	serve(cfg)
+	err = serve(cfg)
+	unsubscribeLogging()
+	logger.Fatal(err)
 }
 
-func serve(cfg config.View) {
+func serve(cfg config.View) error {
 	mux := &http.ServeMux{}
 	port := cfg.GetInt("api.swaggerui.httpport")
 	baseDir, err := os.Getwd()
@@ -81,7 +83,7 @@ func serve(cfg config.View) {
 	logger.Infof("SwaggerUI for Open Match")
 	logger.Infof("Serving static directory: %s", directory)
 	logger.Infof("Serving on %s", addr)
-	logger.Fatal(srv.ListenAndServe())
+	return srv.ListenAndServe()
 }
 
 func bindHandler(mux *http.ServeMux, cfg config.View, path string, service string) {
@@ -0,0 +1,142 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package synchronizer
+
+import (
+	"sync"
+	"time"
+)
+
+// Cycles of different shards run concurrently, so MMFs of one shard may query
+// tickets which another shard's cycle is about to add to the ignore list.  The
+// evaluator only sees a single cycle's proposals, so it can't catch these
+// collisions.  Instead, accepted matches claim their tickets before being added
+// to the ignore list.
+//
+// A match collides with a claim from another cycle if that claim is not yet in
+// the ignore list, or was added to the ignore list after this cycle started,
+// as this cycle's MMFs may have queried the tickets before the ignore list was
+// updated.  Claims older than every running cycle are forgotten.
+
+type ticketClaims struct {
+	mu     sync.Mutex
+	claims map[string]*ticketClaim
+	cycles map[*claimCycle]struct{}
+}
+
+type ticketClaim struct {
+	cycle    *claimCycle
+	ticketID string
+	matchID  string
+	// committed is zero until the ticket is added to the ignore list.
+	committed time.Time
+}
+
+// claimCycle identifies a running cycle.
+type claimCycle struct {
+	start   time.Time
+	pending []*ticketClaim
+}
+
+func newTicketClaims() *ticketClaims {
+	return &ticketClaims{
+		claims: make(map[string]*ticketClaim),
+		cycles: make(map[*claimCycle]struct{}),
+	}
+}
+
+func (tc *ticketClaims) startCycle() *claimCycle {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+
+	c := &claimCycle{start: time.Now()}
+	tc.cycles[c] = struct{}{}
+	return c
+}
+
+// claim claims all of the tickets for the match, or none of them.  If any
+// collide, it returns the id of the match which claimed the ticket first.
+func (tc *ticketClaims) claim(c *claimCycle, matchID string, ticketIDs []string) (string, bool) {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+
+	for _, id := range ticketIDs {
+		existing, ok := tc.claims[id]
+		if !ok || existing.cycle == c {
+			continue
+		}
+		if existing.committed.IsZero() || !existing.committed.Before(c.start) {
+			return existing.matchID, false
+		}
+	}
+
+	for _, id := range ticketIDs {
+		claim := &ticketClaim{cycle: c, ticketID: id, matchID: matchID}
+		tc.claims[id] = claim
+		c.pending = append(c.pending, claim)
+	}
+	return "", true
+}
+
+// commit marks the cycle's claims as added to the ignore list.
+func (tc *ticketClaims) commit(c *claimCycle) {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+
+	now := time.Now()
+	for _, claim := range c.pending {
+		claim.committed = now
+	}
+	c.pending = nil
+}
+
+// release forgets the cycle's pending claims, when adding them to the ignore
+// list failed, so that the tickets may be matched by other cycles.
+func (tc *ticketClaims) release(c *claimCycle) {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+
+	for _, claim := range c.pending {
+		if tc.claims[claim.ticketID] == claim {
+			delete(tc.claims, claim.ticketID)
+		}
+	}
+	c.pending = nil
+}
+
+// endCycle forgets the cycle, and any claims which can no longer collide with
+// a running cycle.
+func (tc *ticketClaims) endCycle(c *claimCycle) {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+
+	delete(tc.cycles, c)
+
+	var oldest time.Time
+	for running := range tc.cycles {
+		if oldest.IsZero() || running.start.Before(oldest) {
+			oldest = running.start
+		}
+	}
+
+	for id, claim := range tc.claims {
+		if claim.committed.IsZero() {
+			continue
+		}
+		if oldest.IsZero() || claim.committed.Before(oldest) {
+			delete(tc.claims, id)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package synchronizer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTicketClaims(t *testing.T) {
+	tc := newTicketClaims()
+
+	a := tc.startCycle()
+	b := tc.startCycle()
+
+	_, ok := tc.claim(a, "a1", []string{"1", "2"})
+	require.True(t, ok)
+
+	// Pending in another cycle.
+	colliding, ok := tc.claim(b, "b1", []string{"3", "2"})
+	require.False(t, ok)
+	require.Equal(t, "a1", colliding)
+
+	// A rejected claim claims none of its tickets.
+	_, ok = tc.claim(b, "b2", []string{"3"})
+	require.True(t, ok)
+
+	// Committed after b started, so b's MMFs may have seen the tickets.
+	tc.commit(a)
+	colliding, ok = tc.claim(b, "b3", []string{"1"})
+	require.False(t, ok)
+	require.Equal(t, "a1", colliding)
+
+	// Pending in b, which a collides with.
+	colliding, ok = tc.claim(a, "a2", []string{"3"})
+	require.False(t, ok)
+	require.Equal(t, "b2", colliding)
+
+	// The same cycle never collides with itself.
+	_, ok = tc.claim(b, "b4", []string{"3"})
+	require.True(t, ok)
+
+	tc.endCycle(a)
+	tc.commit(b)
+
+	// Committed before c started, so the ignore list already hides the
+	// tickets from c.
+	time.Sleep(time.Millisecond)
+	c := tc.startCycle()
+	_, ok = tc.claim(c, "c1", []string{"1"})
+	require.True(t, ok)
+
+	tc.endCycle(b)
+	tc.commit(c)
+	tc.endCycle(c)
+	require.Empty(t, tc.claims)
+	require.Empty(t, tc.cycles)
+}
+
+func TestTicketClaimsRelease(t *testing.T) {
+	tc := newTicketClaims()
+
+	a := tc.startCycle()
+	b := tc.startCycle()
+
+	_, ok := tc.claim(a, "a1", []string{"1", "2"})
+	require.True(t, ok)
+	_, ok = tc.claim(b, "b1", []string{"2"})
+	require.False(t, ok)
+
+	// Adding a's tickets to the ignore list failed, so b may match them.
+	tc.release(a)
+	_, ok = tc.claim(b, "b2", []string{"2"})
+	require.True(t, ok)
+
+	tc.endCycle(a)
+	tc.release(b)
+	tc.endCycle(b)
+	require.Empty(t, tc.claims)
+	require.Empty(t, tc.cycles)
+}
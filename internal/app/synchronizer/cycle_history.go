@@ -0,0 +1,176 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package synchronizer
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"open-match.dev/open-match/internal/config"
+)
+
+const (
+	cyclesEndpoint = "/debug/cycles"
+	// configNameCycleHistorySize is how many of the latest cycles are shown by
+	// /debug/cycles.
+	configNameCycleHistorySize = "synchronizer.cycleHistorySize"
+	defaultCycleHistorySize    = 20
+)
+
+// cycleRecord is filled in while the cycle runs.  Some goroutines of the
+// cycle may outlive it, so it is always accessed under mu.
+type cycleRecord struct {
+	mu sync.Mutex
+	cycleSummary
+
+	proposalEnd time.Time
+}
+
+// cycleSummary describes what happened in a single cycle.
+type cycleSummary struct {
+	Tenant             string       `json:"tenant,omitempty"`
+	Shard              string       `json:"shard"`
+	Start              time.Time    `json:"start"`
+	RegistrationWindow jsonDuration `json:"registrationWindow"`
+	ProposalWindow     jsonDuration `json:"proposalWindow"`
+
+	Registrations     int `json:"registrations"`
+	ProposalsReceived int `json:"proposalsReceived"`
+	MatchesAccepted   int `json:"matchesAccepted"`
+	MatchesRejected   int `json:"matchesRejected"`
+	TicketsIgnored    int `json:"ticketsIgnored"`
+	// TicketIDs are the tickets consumed by the accepted matches, which were
+	// added to the ignore list.
+	TicketIDs []string `json:"ticketIds,omitempty"`
+
+	// Phase durations.  The proposal phase ends when all MMFs are done, or
+	// the proposal window closes and the MMFs are cancelled.
+	RegistrationDuration jsonDuration `json:"registrationDuration"`
+	ProposalDuration     jsonDuration `json:"proposalDuration"`
+	EvaluationDuration   jsonDuration `json:"evaluationDuration"`
+	TotalDuration        jsonDuration `json:"totalDuration"`
+
+	MmfsCancelled bool `json:"mmfsCancelled"`
+	// CancelCause is why the cycle was canceled, if it was.
+	CancelCause string `json:"cancelCause,omitempty"`
+}
+
+// jsonDuration is a time.Duration which is readable in JSON.
+type jsonDuration time.Duration
+
+func (d jsonDuration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+func (r *cycleRecord) snapshot() cycleSummary {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	summary := r.cycleSummary
+	summary.TicketIDs = append([]string(nil), r.TicketIDs...)
+	return summary
+}
+
+func (r *cycleRecord) proposalReceived() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.ProposalsReceived++
+}
+
+func (r *cycleRecord) evaluated(accepted, rejected int, ignoredTicketIDs []string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.MatchesAccepted += accepted
+	r.MatchesRejected += rejected
+	r.TicketsIgnored += len(ignoredTicketIDs)
+	r.TicketIDs = append(r.TicketIDs, ignoredTicketIDs...)
+}
+
+// proposalsDone records the end of the proposal phase.  Only the first call
+// counts, as both the MMFs finishing and the window closing end the phase.
+func (r *cycleRecord) proposalsDone(cancelled bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.proposalEnd.IsZero() {
+		return
+	}
+	r.proposalEnd = time.Now()
+	r.ProposalDuration = jsonDuration(r.proposalEnd.Sub(r.Start.Add(time.Duration(r.RegistrationDuration))))
+	r.MmfsCancelled = cancelled
+}
+
+func (r *cycleRecord) end(cause error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now()
+	if !r.proposalEnd.IsZero() {
+		r.EvaluationDuration = jsonDuration(now.Sub(r.proposalEnd))
+	}
+	r.TotalDuration = jsonDuration(now.Sub(r.Start))
+	if cause != nil {
+		r.CancelCause = cause.Error()
+	}
+}
+
+// cycleHistory keeps the latest cycles of every shard for /debug/cycles.
+type cycleHistory struct {
+	cfg config.View
+
+	mu      sync.Mutex
+	records []*cycleRecord
+}
+
+func (h *cycleHistory) add(r *cycleRecord) {
+	size := defaultCycleHistorySize
+	if h.cfg.IsSet(configNameCycleHistorySize) {
+		size = h.cfg.GetInt(configNameCycleHistorySize)
+	}
+	if size < 0 {
+		size = 0
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.records = append(h.records, r)
+	if len(h.records) > size {
+		h.records = h.records[len(h.records)-size:]
+	}
+}
+
+// ServeHTTP serves the /debug/cycles endpoint, listing the latest cycles as
+// JSON, newest first.
+func (h *cycleHistory) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	h.mu.Lock()
+	records := make([]*cycleRecord, 0, len(h.records))
+	for i := len(h.records) - 1; i >= 0; i-- {
+		records = append(records, h.records[i])
+	}
+	h.mu.Unlock()
+
+	summaries := make([]cycleSummary, 0, len(records))
+	for _, r := range records {
+		summaries = append(summaries, r.snapshot())
+	}
+
+	b, err := json.MarshalIndent(summaries, "", "  ")
+	if err != nil {
+		http.Error(w, fmt.Sprintf("cannot marshal cycles, %s", err), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(b)
+}
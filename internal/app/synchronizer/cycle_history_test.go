@@ -0,0 +1,77 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package synchronizer
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCycleHistory(t *testing.T) {
+	cfg := viper.New()
+	cfg.Set(configNameCycleHistorySize, 2)
+	h := &cycleHistory{cfg: cfg}
+
+	for _, shard := range []string{"a", "b", "c"} {
+		r := &cycleRecord{cycleSummary: cycleSummary{
+			Shard:              shard,
+			Start:              time.Now(),
+			RegistrationWindow: jsonDuration(time.Second),
+		}}
+		r.proposalReceived()
+		r.proposalReceived()
+		r.proposalsDone(true)
+		r.proposalsDone(false)
+		r.evaluated(1, 1, []string{"1", "2", "3"})
+		r.end(errors.New("canceled because all callers were done"))
+		h.add(r)
+	}
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", cyclesEndpoint, nil))
+	require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	var cycles []map[string]interface{}
+	require.Nil(t, json.Unmarshal(rec.Body.Bytes(), &cycles))
+	require.Len(t, cycles, 2)
+	// Newest first.
+	require.Equal(t, "c", cycles[0]["shard"])
+	require.Equal(t, "b", cycles[1]["shard"])
+
+	c := cycles[0]
+	require.Equal(t, "1s", c["registrationWindow"])
+	require.Equal(t, 2.0, c["proposalsReceived"])
+	require.Equal(t, 1.0, c["matchesAccepted"])
+	require.Equal(t, 1.0, c["matchesRejected"])
+	require.Equal(t, 3.0, c["ticketsIgnored"])
+	require.Equal(t, []interface{}{"1", "2", "3"}, c["ticketIds"])
+	require.Equal(t, true, c["mmfsCancelled"])
+	require.Equal(t, "canceled because all callers were done", c["cancelCause"])
+}
+
+func TestCycleHistoryNegativeSize(t *testing.T) {
+	cfg := viper.New()
+	cfg.Set(configNameCycleHistorySize, -1)
+	h := &cycleHistory{cfg: cfg}
+
+	h.add(&cycleRecord{})
+	require.Empty(t, h.records)
+}
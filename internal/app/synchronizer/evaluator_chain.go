@@ -0,0 +1,161 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package synchronizer
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"golang.org/x/sync/errgroup"
+	"open-match.dev/open-match/internal/config"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+const (
+	// configNameEvaluatorChain lists the names of the evaluators proposals
+	// are passed through, in order.  Each name is configured under api.<name>
+	// the same way as api.evaluator.
+	configNameEvaluatorChain = "evaluatorChain"
+	defaultEvaluatorName     = "evaluator"
+)
+
+// Each stage of the chain receives the matches accepted by the previous stage.
+// Matches rejected by any stage, and matches accepted by the final stage, are
+// the results of the chain.
+
+// newEvaluatorChain creates a client for every configured evaluator.  It is a
+// config.NewInstanceFunc, so the chain is recreated when the config of any
+// stage changes.
+func newEvaluatorChain(cfg config.View) (interface{}, func(), error) {
+	names := []string{defaultEvaluatorName}
+	if cfg.IsSet(configNameEvaluatorChain) {
+		names = cfg.GetStringSlice(configNameEvaluatorChain)
+	}
+	if len(names) == 0 {
+		return nil, nil, fmt.Errorf("%s must contain at least one evaluator", configNameEvaluatorChain)
+	}
+
+	chain := &evaluatorChain{}
+	var closers []func()
+	close := func() {
+		for _, c := range closers {
+			c()
+		}
+	}
+
+	for _, name := range names {
+		e, c, err := newStageEvaluator(cfg, name)
+		if err != nil {
+			close()
+			return nil, nil, err
+		}
+		closers = append(closers, c)
+		chain.stages = append(chain.stages, &evaluatorStage{name: name, eval: e})
+	}
+
+	return chain, close, nil
+}
+
+type evaluatorStage struct {
+	name string
+	eval evaluator
+}
+
+type evaluatorChain struct {
+	stages []*evaluatorStage
+}
+
+func (ec *evaluatorChain) evaluate(ctx context.Context, pc <-chan []*pb.Match, results chan<- *pb.EvaluateResponse) error {
+	eg, ctx := errgroup.WithContext(ctx)
+
+	// Remember the proposals, so that matches accepted by a stage can be sent
+	// to the next.
+	matches := &sync.Map{}
+	in := make(chan []*pb.Match)
+	go func() {
+		defer close(in)
+		for proposals := range pc {
+			for _, m := range proposals {
+				matches.Store(m.GetMatchId(), m)
+			}
+			in <- proposals
+		}
+	}()
+
+	var nextIn <-chan []*pb.Match = in
+	for i, stage := range ec.stages {
+		stage := stage
+		last := i == len(ec.stages)-1
+		stageIn := nextIn
+		stageOut := make(chan *pb.EvaluateResponse)
+
+		var next chan *pb.Match
+		if !last {
+			next = make(chan *pb.Match)
+		}
+
+		eg.Go(func() error {
+			defer close(stageOut)
+			defer func() {
+				// Don't block earlier stages if this one returned early.
+				go func() {
+					for range stageIn {
+					}
+				}()
+			}()
+
+			st := time.Now()
+			err := stage.eval.evaluate(ctx, stageIn, stageOut)
+			_ = stats.RecordWithTags(ctx, []tag.Mutator{tag.Upsert(evaluatorStageTag, stage.name)}, evaluatorStageLatency.M(float64(time.Since(st))/float64(time.Millisecond)))
+			if err != nil {
+				return fmt.Errorf("error in evaluator stage %s: %w", stage.name, err)
+			}
+			return nil
+		})
+
+		eg.Go(func() error {
+			if next != nil {
+				defer close(next)
+			}
+			var err error
+			for r := range stageOut {
+				if err != nil {
+					continue
+				}
+				if last || r.GetRejectedMatch() != nil {
+					results <- r
+					continue
+				}
+				m, ok := matches.Load(r.GetMatchId())
+				if !ok {
+					err = fmt.Errorf("evaluator stage %s returned match_id \"%s\" which does not correspond to its any match in its input", stage.name, r.GetMatchId())
+					continue
+				}
+				next <- m.(*pb.Match)
+			}
+			return err
+		})
+
+		if !last {
+			nextIn = bufferMatchChannel(next)
+		}
+	}
+
+	return eg.Wait()
+}
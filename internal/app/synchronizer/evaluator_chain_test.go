@@ -0,0 +1,137 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package synchronizer
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+type evaluatorFunc func(context.Context, <-chan []*pb.Match, chan<- *pb.EvaluateResponse) error
+
+func (f evaluatorFunc) evaluate(ctx context.Context, pc <-chan []*pb.Match, results chan<- *pb.EvaluateResponse) error {
+	return f(ctx, pc, results)
+}
+
+// rejectIDs returns a stage which rejects the given match ids and accepts
+// everything else.
+func rejectIDs(t *testing.T, seen *[]string, ids ...string) evaluator {
+	return evaluatorFunc(func(ctx context.Context, pc <-chan []*pb.Match, results chan<- *pb.EvaluateResponse) error {
+		for proposals := range pc {
+			for _, m := range proposals {
+				*seen = append(*seen, m.GetMatchId())
+				rejected := false
+				for _, id := range ids {
+					rejected = rejected || m.GetMatchId() == id
+				}
+				if rejected {
+					results <- &pb.EvaluateResponse{RejectedMatch: &pb.RejectedMatch{MatchId: m.GetMatchId(), Reason: pb.RejectedMatch_LOW_SCORE}}
+				} else {
+					results <- &pb.EvaluateResponse{MatchId: m.GetMatchId()}
+				}
+			}
+		}
+		return nil
+	})
+}
+
+func runChain(chain *evaluatorChain, proposals ...*pb.Match) ([]string, []string, error) {
+	pc := make(chan []*pb.Match, 1)
+	pc <- proposals
+	close(pc)
+
+	results := make(chan *pb.EvaluateResponse, len(proposals))
+	err := chain.evaluate(context.Background(), pc, results)
+	close(results)
+
+	var accepted, rejected []string
+	for r := range results {
+		if r.GetRejectedMatch() != nil {
+			rejected = append(rejected, r.GetRejectedMatch().GetMatchId())
+		} else {
+			accepted = append(accepted, r.GetMatchId())
+		}
+	}
+	return accepted, rejected, err
+}
+
+func TestEvaluatorChain(t *testing.T) {
+	var policySeen, resolverSeen []string
+	chain := &evaluatorChain{
+		stages: []*evaluatorStage{
+			{name: "policy", eval: rejectIDs(t, &policySeen, "cheater")},
+			{name: "resolver", eval: rejectIDs(t, &resolverSeen, "collided")},
+		},
+	}
+
+	accepted, rejected, err := runChain(chain,
+		&pb.Match{MatchId: "good"},
+		&pb.Match{MatchId: "cheater"},
+		&pb.Match{MatchId: "collided"},
+	)
+	require.Nil(t, err)
+	require.ElementsMatch(t, []string{"good"}, accepted)
+	require.ElementsMatch(t, []string{"cheater", "collided"}, rejected)
+	require.ElementsMatch(t, []string{"good", "cheater", "collided"}, policySeen)
+	// Only survivors of the policy stage reach the resolver.
+	require.ElementsMatch(t, []string{"good", "collided"}, resolverSeen)
+}
+
+func TestEvaluatorChainStageError(t *testing.T) {
+	var seen []string
+	chain := &evaluatorChain{
+		stages: []*evaluatorStage{
+			{name: "broken", eval: evaluatorFunc(func(context.Context, <-chan []*pb.Match, chan<- *pb.EvaluateResponse) error {
+				return errors.New("broken")
+			})},
+			{name: "resolver", eval: rejectIDs(t, &seen)},
+		},
+	}
+
+	_, _, err := runChain(chain, &pb.Match{MatchId: "1"})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "error in evaluator stage broken")
+}
+
+func TestNewEvaluatorChain(t *testing.T) {
+	cfg := viper.New()
+	cfg.Set("api.evaluator.hostname", "localhost")
+	cfg.Set("api.evaluator.grpcport", 50508)
+	cfg.Set("api.policy.hostname", "localhost")
+	cfg.Set("api.policy.httpport", 51508)
+
+	chain, close, err := newEvaluatorChain(cfg)
+	require.Nil(t, err)
+	close()
+	require.Len(t, chain.(*evaluatorChain).stages, 1)
+	require.Equal(t, "evaluator", chain.(*evaluatorChain).stages[0].name)
+
+	cfg.Set("evaluatorChain", []string{"policy", "evaluator"})
+	chain, close, err = newEvaluatorChain(cfg)
+	require.Nil(t, err)
+	close()
+	require.Len(t, chain.(*evaluatorChain).stages, 2)
+	require.Equal(t, "policy", chain.(*evaluatorChain).stages[0].name)
+	require.IsType(t, &httpEvaluatorClient{}, chain.(*evaluatorChain).stages[0].eval)
+
+	cfg.Set("evaluatorChain", []string{"missing"})
+	_, _, err = newEvaluatorChain(cfg)
+	require.Contains(t, err.Error(), "api.missing.grpcport")
+}
@@ -40,39 +40,39 @@ var (
 )
 
 type evaluator interface {
-	evaluate(context.Context, <-chan []*pb.Match, chan<- string) error
+	evaluate(context.Context, <-chan []*pb.Match, chan<- *pb.EvaluateResponse) error
 }
 
-var errNoEvaluatorType = status.Errorf(codes.FailedPrecondition, "unable to determine evaluator type, either api.evaluator.grpcport or api.evaluator.httpport must be specified in the config")
-
 func newEvaluator(cfg config.View) evaluator {
-	newInstance := func(cfg config.View) (interface{}, func(), error) {
-		// grpc is preferred over http.
-		if cfg.IsSet("api.evaluator.grpcport") {
-			return newGrpcEvaluator(cfg)
-		}
-		if cfg.IsSet("api.evaluator.httpport") {
-			return newHTTPEvaluator(cfg)
-		}
-		return nil, nil, errNoEvaluatorType
+	return &deferredEvaluator{
+		cacher: config.NewCacher(cfg, newEvaluatorChain),
 	}
+}
 
-	return &deferredEvaluator{
-		cacher: config.NewCacher(cfg, newInstance),
+// newStageEvaluator creates a client for the evaluator configured under
+// api.<name>.
+func newStageEvaluator(cfg config.View, name string) (evaluator, func(), error) {
+	// grpc is preferred over http.
+	if cfg.IsSet("api." + name + ".grpcport") {
+		return newGrpcEvaluator(cfg, name)
+	}
+	if cfg.IsSet("api." + name + ".httpport") {
+		return newHTTPEvaluator(cfg, name)
 	}
+	return nil, nil, status.Errorf(codes.FailedPrecondition, "unable to determine evaluator type, either api.%s.grpcport or api.%s.httpport must be specified in the config", name, name)
 }
 
 type deferredEvaluator struct {
 	cacher *config.Cacher
 }
 
-func (de *deferredEvaluator) evaluate(ctx context.Context, pc <-chan []*pb.Match, acceptedIds chan<- string) error {
+func (de *deferredEvaluator) evaluate(ctx context.Context, pc <-chan []*pb.Match, results chan<- *pb.EvaluateResponse) error {
 	e, err := de.cacher.Get()
 	if err != nil {
 		return err
 	}
 
-	err = e.(evaluator).evaluate(ctx, pc, acceptedIds)
+	err = e.(evaluator).evaluate(ctx, pc, results)
 	if err != nil {
 		de.cacher.ForceReset()
 	}
@@ -83,8 +83,8 @@ type grcpEvaluatorClient struct {
 	evaluator pb.EvaluatorClient
 }
 
-func newGrpcEvaluator(cfg config.View) (evaluator, func(), error) {
-	grpcAddr := fmt.Sprintf("%s:%d", cfg.GetString("api.evaluator.hostname"), cfg.GetInt64("api.evaluator.grpcport"))
+func newGrpcEvaluator(cfg config.View, name string) (evaluator, func(), error) {
+	grpcAddr := fmt.Sprintf("%s:%d", cfg.GetString("api."+name+".hostname"), cfg.GetInt64("api."+name+".grpcport"))
 	conn, err := rpc.GRPCClientFromEndpoint(cfg, grpcAddr)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to create grpc evaluator client: %w", err)
@@ -106,7 +106,7 @@ func newGrpcEvaluator(cfg config.View) (evaluator, func(), error) {
 	}, close, nil
 }
 
-func (ec *grcpEvaluatorClient) evaluate(ctx context.Context, pc <-chan []*pb.Match, acceptedIds chan<- string) error {
+func (ec *grcpEvaluatorClient) evaluate(ctx context.Context, pc <-chan []*pb.Match, results chan<- *pb.EvaluateResponse) error {
 	eg, ctx := errgroup.WithContext(ctx)
 
 	var stream pb.Evaluator_EvaluateClient
@@ -149,15 +149,16 @@ func (ec *grcpEvaluatorClient) evaluate(ctx context.Context, pc <-chan []*pb.Mat
 				return fmt.Errorf("failed to get response from evaluator client, desc: %w", err)
 			}
 
-			v, ok := matchIDs.Load(resp.GetMatchId())
+			id := evaluatedMatchID(resp)
+			v, ok := matchIDs.Load(id)
 			if !ok {
-				return fmt.Errorf("evaluator returned match_id \"%s\" which does not correspond to its any match in its input", resp.GetMatchId())
+				return fmt.Errorf("evaluator returned match_id \"%s\" which does not correspond to its any match in its input", id)
 			}
 			if !v.(bool) {
-				return fmt.Errorf("evaluator returned same match_id twice: \"%s\"", resp.GetMatchId())
+				return fmt.Errorf("evaluator returned same match_id twice: \"%s\"", id)
 			}
-			matchIDs.Store(resp.GetMatchId(), false)
-			acceptedIds <- resp.GetMatchId()
+			matchIDs.Store(id, false)
+			results <- resp
 		}
 	})
 
@@ -173,8 +174,8 @@ type httpEvaluatorClient struct {
 	baseURL    string
 }
 
-func newHTTPEvaluator(cfg config.View) (evaluator, func(), error) {
-	httpAddr := fmt.Sprintf("%s:%d", cfg.GetString("api.evaluator.hostname"), cfg.GetInt64("api.evaluator.httpport"))
+func newHTTPEvaluator(cfg config.View, name string) (evaluator, func(), error) {
+	httpAddr := fmt.Sprintf("%s:%d", cfg.GetString("api."+name+".hostname"), cfg.GetInt64("api."+name+".httpport"))
 	client, baseURL, err := rpc.HTTPClientFromEndpoint(cfg, httpAddr)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to get a HTTP client from the endpoint %v: %w", httpAddr, err)
@@ -194,7 +195,7 @@ func newHTTPEvaluator(cfg config.View) (evaluator, func(), error) {
 	}, close, nil
 }
 
-func (ec *httpEvaluatorClient) evaluate(ctx context.Context, pc <-chan []*pb.Match, acceptedIds chan<- string) error {
+func (ec *httpEvaluatorClient) evaluate(ctx context.Context, pc <-chan []*pb.Match, results chan<- *pb.EvaluateResponse) error {
 	reqr, reqw := io.Pipe()
 	var wg sync.WaitGroup
 	wg.Add(1)
@@ -271,7 +272,7 @@ func (ec *httpEvaluatorClient) evaluate(ctx context.Context, pc <-chan []*pb.Mat
 				rc <- status.Errorf(codes.Unavailable, "failed to execute jsonpb.UnmarshalString(%s, &proposal): %v.", item.Result, err)
 				return
 			}
-			acceptedIds <- resp.GetMatchId()
+			results <- resp
 		}
 	}()
 
@@ -284,3 +285,12 @@ func (ec *httpEvaluatorClient) evaluate(ctx context.Context, pc <-chan []*pb.Mat
 	}
 	return nil
 }
+
+// evaluatedMatchID returns the id of the match the evaluator accepted or
+// rejected.
+func evaluatedMatchID(resp *pb.EvaluateResponse) string {
+	if resp.GetRejectedMatch() != nil {
+		return resp.GetRejectedMatch().GetMatchId()
+	}
+	return resp.GetMatchId()
+}
@@ -0,0 +1,181 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package synchronizer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"open-match.dev/open-match/internal/config"
+	"open-match.dev/open-match/internal/statestore"
+)
+
+const (
+	configNameLeaderElectionEnabled = "synchronizer.leaderElection.enabled"
+	configNameLeaseDuration         = "synchronizer.leaderElection.leaseDuration"
+	// configNameAdvertiseAddress is the gRPC address backends use to reach
+	// this replica when it is the leader.
+	configNameAdvertiseAddress = "synchronizer.leaderElection.advertiseAddress"
+)
+
+// errNotLeader is returned to synchronize calls reaching a standby, so that
+// the backend retries against the leader.
+var errNotLeader = status.Error(codes.Unavailable, "synchronizer is not the leader")
+
+// leaderElector keeps the synchronizer lease in the statestore while this
+// replica is the leader, and tries to take it when it isn't.  Only the leader
+// runs cycles, so multiple replicas can run with the standbys taking over when
+// the leader's lease expires.  A nil leaderElector is always the leader, for
+// when leader election is disabled.
+type leaderElector struct {
+	store         statestore.Service
+	holder        string
+	leaseDuration time.Duration
+
+	// leaseExpiry is the UnixNano time this replica's lease is known to be
+	// held until.
+	leaseExpiry int64
+
+	m sync.Mutex
+	// lost is closed once this replica stops being the leader.
+	lost chan struct{}
+}
+
+func newLeaderElector(cfg config.View, store statestore.Service) *leaderElector {
+	if !cfg.GetBool(configNameLeaderElectionEnabled) {
+		return nil
+	}
+
+	leaseDuration := 10 * time.Second
+	if cfg.IsSet(configNameLeaseDuration) {
+		leaseDuration = cfg.GetDuration(configNameLeaseDuration)
+	}
+
+	holder := cfg.GetString(configNameAdvertiseAddress)
+	if holder == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			logger.WithError(err).Warning("failed to get hostname for synchronizer leader election")
+		}
+		holder = fmt.Sprintf("%s:%d", hostname, cfg.GetInt("api.synchronizer.grpcport"))
+	}
+
+	return &leaderElector{
+		store:         store,
+		holder:        holder,
+		leaseDuration: leaseDuration,
+	}
+}
+
+// isLeader returns true if this replica should run cycles.
+func (le *leaderElector) isLeader() bool {
+	if le == nil {
+		return true
+	}
+	return time.Now().UnixNano() < atomic.LoadInt64(&le.leaseExpiry)
+}
+
+// leadershipLost returns a channel closed once this replica stops being the
+// leader, which is already closed if it isn't the leader.  The channel of a
+// nil leaderElector is never closed.
+func (le *leaderElector) leadershipLost() <-chan struct{} {
+	if le == nil {
+		return nil
+	}
+	le.m.Lock()
+	defer le.m.Unlock()
+	if le.lost == nil {
+		le.lost = make(chan struct{})
+	}
+	lost := le.lost
+	if !le.isLeader() {
+		close(le.lost)
+		le.lost = nil
+	}
+	return lost
+}
+
+// checkLeadership notifies the holders of leadershipLost once the lease
+// expired or was lost.  The lease may expire between checks, so isLeader is
+// still checked before any change which only the leader may make.
+func (le *leaderElector) checkLeadership() {
+	if le.isLeader() {
+		return
+	}
+	le.m.Lock()
+	defer le.m.Unlock()
+	if le.lost != nil {
+		close(le.lost)
+		le.lost = nil
+	}
+}
+
+// run acquires and renews the lease until the context is done, then releases
+// it.
+func (le *leaderElector) run(ctx context.Context) {
+	ticker := time.NewTicker(le.leaseDuration / 3)
+	defer ticker.Stop()
+
+	for {
+		le.renew(ctx)
+
+		select {
+		case <-ctx.Done():
+			atomic.StoreInt64(&le.leaseExpiry, 0)
+			le.checkLeadership()
+			// The context is done, but releasing still needs to reach the
+			// statestore so a standby can take over without waiting.
+			err := le.store.ReleaseLease(context.Background(), statestore.SynchronizerLease, le.holder)
+			if err != nil {
+				logger.WithError(err).Warning("failed to release synchronizer lease")
+			}
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (le *leaderElector) renew(ctx context.Context) {
+	defer le.checkLeadership()
+	wasLeader := le.isLeader()
+	start := time.Now()
+
+	acquired, err := le.store.AcquireLease(ctx, statestore.SynchronizerLease, le.holder, le.leaseDuration)
+	if err != nil {
+		// Keep acting on the previous renewal, which expires by itself.
+		logger.WithError(err).Error("failed to renew synchronizer lease")
+		return
+	}
+
+	if acquired {
+		atomic.StoreInt64(&le.leaseExpiry, start.Add(le.leaseDuration).UnixNano())
+	} else {
+		atomic.StoreInt64(&le.leaseExpiry, 0)
+	}
+
+	if acquired != wasLeader {
+		logger.WithFields(logrus.Fields{
+			"holder": le.holder,
+			"leader": acquired,
+		}).Info("synchronizer leadership changed")
+	}
+}
@@ -0,0 +1,151 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package synchronizer
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"open-match.dev/open-match/internal/statestore"
+	statestoreTesting "open-match.dev/open-match/internal/statestore/testing"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+func TestLeaderElection(t *testing.T) {
+	ctx := context.Background()
+	cfg := viper.New()
+	store, closer := statestoreTesting.NewStoreServiceForTesting(t, cfg)
+	defer closer()
+
+	require.Nil(t, newLeaderElector(cfg, store))
+	var disabled *leaderElector
+	require.True(t, disabled.isLeader())
+
+	cfg.Set(configNameLeaderElectionEnabled, true)
+	cfg.Set(configNameLeaseDuration, time.Minute)
+	cfg.Set(configNameAdvertiseAddress, "a:1")
+	a := newLeaderElector(cfg, store)
+	cfg.Set(configNameAdvertiseAddress, "b:1")
+	b := newLeaderElector(cfg, store)
+
+	a.renew(ctx)
+	b.renew(ctx)
+	require.True(t, a.isLeader())
+	require.False(t, b.isLeader())
+
+	holder, err := store.GetLeaseHolder(ctx, statestore.SynchronizerLease)
+	require.Nil(t, err)
+	require.Equal(t, "a:1", holder)
+
+	// Stopping the leader releases the lease for a standby to take.
+	runCtx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		a.run(runCtx)
+		close(done)
+	}()
+	cancel()
+	<-done
+	require.False(t, a.isLeader())
+
+	b.renew(ctx)
+	require.True(t, b.isLeader())
+
+	a.renew(ctx)
+	require.False(t, a.isLeader())
+}
+
+// ignoreListRecorder records the tickets added to the ignore list.
+type ignoreListRecorder struct {
+	statestore.Service
+	m       sync.Mutex
+	ignored []string
+}
+
+func (r *ignoreListRecorder) AddTicketsToIgnoreList(ctx context.Context, ids []string) error {
+	r.m.Lock()
+	r.ignored = append(r.ignored, ids...)
+	r.m.Unlock()
+	return r.Service.AddTicketsToIgnoreList(ctx, ids)
+}
+
+func TestLostLeadership(t *testing.T) {
+	ctx := context.Background()
+	cfg := viper.New()
+	store, closer := statestoreTesting.NewStoreServiceForTesting(t, cfg)
+	defer closer()
+
+	cfg.Set("registrationInterval", "10s")
+	cfg.Set("proposalCollectionInterval", "10s")
+	cfg.Set(configNameEarlyExit, true)
+	cfg.Set(configNameLeaderElectionEnabled, true)
+	cfg.Set(configNameLeaseDuration, time.Minute)
+	cfg.Set(configNameAdvertiseAddress, "a:1")
+	le := newLeaderElector(cfg, store)
+	le.renew(ctx)
+	require.True(t, le.isLeader())
+
+	evaluating := make(chan struct{})
+	release := make(chan struct{})
+	eval := evaluatorFunc(func(ctx context.Context, pc <-chan []*pb.Match, results chan<- *pb.EvaluateResponse) error {
+		var all []*pb.Match
+		for proposals := range pc {
+			all = append(all, proposals...)
+		}
+		close(evaluating)
+		<-release
+		for _, m := range all {
+			results <- &pb.EvaluateResponse{MatchId: m.GetMatchId()}
+		}
+		return nil
+	})
+	recorder := &ignoreListRecorder{Service: store}
+	s := newSynchronizerService(cfg, eval, recorder, le)
+	sh := s.getShard("", "")
+
+	a, err := s.register(ctx, sh)
+	require.Nil(t, err)
+	a.m1c.send(mAndM6c{m: &pb.Match{MatchId: "a", Tickets: []*pb.Ticket{{Id: "1"}}}, m7c: a.m7c})
+	a.m1cDone()
+
+	// The lease expires while the evaluator runs.
+	<-evaluating
+	atomic.StoreInt64(&le.leaseExpiry, 0)
+	le.checkLeadership()
+	close(release)
+	// The match is dropped, as the new leader may return its tickets.
+	matches := 0
+	for r := range a.m7c {
+		if r.GetRejectedMatch() == nil {
+			matches++
+		}
+	}
+	require.Zero(t, matches)
+
+	require.True(t, errors.Is(a.cycleCtx.Err(), errNotLeader), "%v", a.cycleCtx.Err())
+	recorder.m.Lock()
+	require.Empty(t, recorder.ignored)
+	recorder.m.Unlock()
+
+	// New calls aren't registered in new cycles.
+	_, err = s.register(ctx, sh)
+	require.Equal(t, errNotLeader, err)
+}
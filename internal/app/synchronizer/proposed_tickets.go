@@ -0,0 +1,72 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package synchronizer
+
+import (
+	"sync"
+)
+
+// maxProposedTickets bounds the tickets remembered as proposed.
+const maxProposedTickets = 100000
+
+// Tickets which aren't matched are proposed again in most cycles, but only the
+// first time they were proposed is kept in their lifecycles.  proposedTickets
+// remembers the tickets already recorded, so that they aren't written to the
+// store again every cycle.  The oldest tickets are forgotten first once it is
+// full, which at worst records them again.
+
+type proposedTickets struct {
+	mu   sync.Mutex
+	seen map[string]struct{}
+	// order is a ring of the remembered tickets, the oldest at next.
+	order []string
+	next  int
+}
+
+func newProposedTickets(size int) *proposedTickets {
+	return &proposedTickets{
+		seen:  make(map[string]struct{}),
+		order: make([]string, 0, size),
+	}
+}
+
+// recorded returns true if the ticket was already recorded as proposed.
+func (pt *proposedTickets) recorded(id string) bool {
+	pt.mu.Lock()
+	defer pt.mu.Unlock()
+
+	_, ok := pt.seen[id]
+	return ok
+}
+
+// add remembers the ticket once it is recorded as proposed, so that failing to
+// record it lets the next cycle try again.
+func (pt *proposedTickets) add(id string) {
+	pt.mu.Lock()
+	defer pt.mu.Unlock()
+
+	if _, ok := pt.seen[id]; ok {
+		return
+	}
+	pt.seen[id] = struct{}{}
+
+	if len(pt.order) < cap(pt.order) {
+		pt.order = append(pt.order, id)
+		return
+	}
+	delete(pt.seen, pt.order[pt.next])
+	pt.order[pt.next] = id
+	pt.next = (pt.next + 1) % len(pt.order)
+}
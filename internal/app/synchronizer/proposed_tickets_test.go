@@ -0,0 +1,44 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package synchronizer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProposedTickets(t *testing.T) {
+	pt := newProposedTickets(2)
+
+	require.False(t, pt.recorded("1"))
+	pt.add("1")
+	pt.add("2")
+	require.True(t, pt.recorded("1"))
+	require.True(t, pt.recorded("2"))
+
+	// Adding a recorded ticket again doesn't forget another.
+	pt.add("1")
+	require.True(t, pt.recorded("2"))
+
+	// The oldest ticket is forgotten once full.
+	pt.add("3")
+	require.False(t, pt.recorded("1"))
+	require.True(t, pt.recorded("2"))
+	require.True(t, pt.recorded("3"))
+	pt.add("1")
+	require.False(t, pt.recorded("2"))
+	require.Len(t, pt.seen, 2)
+}
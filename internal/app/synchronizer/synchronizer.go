@@ -15,52 +15,108 @@
 package synchronizer
 
 import (
+	"context"
+
 	"go.opencensus.io/stats"
 	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
 	"google.golang.org/grpc"
 	"open-match.dev/open-match/internal/appmain"
 	"open-match.dev/open-match/internal/ipb"
 	"open-match.dev/open-match/internal/statestore"
 	"open-match.dev/open-match/internal/telemetry"
+	"open-match.dev/open-match/internal/tenant"
 )
 
 var (
 	iterationLatency        = stats.Float64("open-match.dev/synchronizer/iteration_latency", "Time elapsed of each synchronizer iteration", stats.UnitMilliseconds)
 	registrationWaitTime    = stats.Float64("open-match.dev/synchronizer/registration_wait_time", "Time elapsed of registration wait time", stats.UnitMilliseconds)
 	registrationMMFDoneTime = stats.Float64("open-match.dev/synchronizer/registration_mmf_done_time", "Time elapsed wasted in registration window with done MMFs", stats.UnitMilliseconds)
+	evaluatorStageLatency   = stats.Float64("open-match.dev/synchronizer/evaluator_stage_latency", "Time elapsed of each evaluator stage call", stats.UnitMilliseconds)
+
+	registrationWindow = stats.Float64("open-match.dev/synchronizer/registration_window", "Registration window chosen for each synchronizer iteration", stats.UnitMilliseconds)
+	proposalWindow     = stats.Float64("open-match.dev/synchronizer/proposal_window", "Proposal collection window chosen for each synchronizer iteration", stats.UnitMilliseconds)
+
+	evaluatorStageTag = tag.MustNewKey("evaluator_stage")
+	shardTag          = tag.MustNewKey("shard")
 
 	iterationLatencyView = &view.View{
 		Measure:     iterationLatency,
 		Name:        "open-match.dev/synchronizer/iteration_latency",
 		Description: "Time elapsed of each synchronizer iteration",
 		Aggregation: telemetry.DefaultMillisecondsDistribution,
+		TagKeys:     []tag.Key{tenant.TagKey},
 	}
 	registrationWaitTimeView = &view.View{
 		Measure:     registrationWaitTime,
 		Name:        "open-match.dev/synchronizer/registration_wait_time",
 		Description: "Time elapsed of registration wait time",
 		Aggregation: telemetry.DefaultMillisecondsDistribution,
+		TagKeys:     []tag.Key{tenant.TagKey},
 	}
 	registrationMMFDoneTimeView = &view.View{
 		Measure:     registrationMMFDoneTime,
 		Name:        "open-match.dev/synchronizer/registration_mmf_done_time",
 		Description: "Time elapsed wasted in registration window with done MMFs",
 		Aggregation: telemetry.DefaultMillisecondsDistribution,
+		TagKeys:     []tag.Key{tenant.TagKey},
+	}
+	evaluatorStageLatencyView = &view.View{
+		Measure:     evaluatorStageLatency,
+		Name:        "open-match.dev/synchronizer/evaluator_stage_latency",
+		Description: "Time elapsed of each evaluator stage call",
+		Aggregation: telemetry.DefaultMillisecondsDistribution,
+		TagKeys:     []tag.Key{evaluatorStageTag, tenant.TagKey},
+	}
+	registrationWindowView = &view.View{
+		Measure:     registrationWindow,
+		Name:        "open-match.dev/synchronizer/registration_window",
+		Description: "Registration window chosen for each synchronizer iteration",
+		Aggregation: view.LastValue(),
+		TagKeys:     []tag.Key{shardTag, tenant.TagKey},
+	}
+	proposalWindowView = &view.View{
+		Measure:     proposalWindow,
+		Name:        "open-match.dev/synchronizer/proposal_window",
+		Description: "Proposal collection window chosen for each synchronizer iteration",
+		Aggregation: view.LastValue(),
+		TagKeys:     []tag.Key{shardTag, tenant.TagKey},
 	}
 )
 
 // BindService creates the synchronizer service and binds it to the serving harness.
 func BindService(p *appmain.Params, b *appmain.Bindings) error {
 	store := statestore.New(p.Config())
-	service := newSynchronizerService(p.Config(), newEvaluator(p.Config()), store)
+	leader := newLeaderElector(p.Config(), store)
+	if leader != nil {
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+		go func() {
+			leader.run(ctx)
+			close(done)
+		}()
+		b.AddCloser(func() {
+			cancel()
+			<-done
+		})
+	}
+
+	service := newSynchronizerService(p.Config(), newEvaluator(p.Config()), store, leader)
+	service.audit = p.Audit()
 	b.AddHealthCheckFunc(store.HealthCheck)
 	b.AddHandleFunc(func(s *grpc.Server) {
 		ipb.RegisterSynchronizerServer(s, service)
 	}, nil)
+	if p.Config().GetBool("telemetry.zpages.enable") {
+		b.TelemetryHandleFunc(cyclesEndpoint, service.cycles.ServeHTTP)
+	}
 	b.RegisterViews(
 		iterationLatencyView,
 		registrationWaitTimeView,
 		registrationMMFDoneTimeView,
+		evaluatorStageLatencyView,
+		registrationWindowView,
+		proposalWindowView,
 	)
 	return nil
 }
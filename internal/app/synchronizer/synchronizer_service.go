@@ -17,17 +17,24 @@ package synchronizer
 import (
 	"context"
 	"fmt"
+	"hash/fnv"
 	"io"
+	"math"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.opencensus.io/trace"
 
 	"github.com/sirupsen/logrus"
 	"open-match.dev/open-match/internal/appmain/contextcause"
+	"open-match.dev/open-match/internal/audit"
 	"open-match.dev/open-match/internal/config"
 	"open-match.dev/open-match/internal/ipb"
 	"open-match.dev/open-match/internal/statestore"
+	"open-match.dev/open-match/internal/tenant"
 	"open-match.dev/open-match/pkg/pb"
 )
 
@@ -45,6 +52,11 @@ var (
 // These matches are sent to the evaluator, then the tickets are added to the
 // ignore list.  Finally the matches are returned to the calling stream.
 
+// Synchronize calls name a shard in their first request.  Each shard runs its
+// own cycles, so a slow MMF or evaluator for one shard doesn't hold back the
+// others.  Calls which don't name a shard share the "" shard.  Shards belong to
+// the tenant of the call, so tenants never share a cycle.
+
 // receive from backend                  | Synchronize
 //  -> m1c ->
 // close incoming when done or timed out | newCutoffSender
@@ -55,16 +67,64 @@ var (
 //   -> m4c -> (buffered)
 // send to evaluator                     | wrapEvaluator
 //   -> m5c -> (buffered)
-// add tickets to ignore list            | addMatchesToIgnoreList
+// reject collisions with other shards,  | addMatchesToIgnoreList
+// add tickets to ignore list            |
 //   -> m6c ->
-// fan out to origin synchronize call    | fanInFanOut
+// fan out to origin synchronize call,   | fanInFanOut
+// rejecting matches never evaluated     |
 //   -> (Synchronize call specific ) m7c -> (buffered)
 // return to backend                     | Synchronize
 
+const (
+	// configNameEarlyExit closes the registration window as soon as every
+	// registered call has sent all of its proposals, evaluating immediately
+	// instead of waiting for more calls to register.  This cuts latency for
+	// deployments with few concurrent FetchMatches calls.
+	configNameEarlyExit = "synchronizer.earlyExit"
+
+	// configNameTicketTraceSamplingFraction is the fraction of matched
+	// tickets traced, linked to the cycle and match which consumed them.
+	configNameTicketTraceSamplingFraction = "telemetry.ticketTraceSamplingFraction"
+)
+
 type synchronizerService struct {
-	cfg   config.View
-	store statestore.Service
-	eval  evaluator
+	cfg    config.View
+	store  statestore.Service
+	eval   evaluator
+	leader *leaderElector
+	audit  *audit.Logger
+
+	// claims prevents cycles of different shards, which run concurrently,
+	// from returning matches which share tickets.
+	claims *ticketClaims
+	cycles *cycleHistory
+	// proposed avoids recording tickets as proposed every cycle.
+	proposed *proposedTickets
+
+	shardsLock sync.Mutex
+	// Clients name their tenant, so shards idle for shardIdleTimeout are
+	// evicted.
+	shards    map[shardKey]*shard
+	lastEvict time.Time
+}
+
+// shardIdleTimeout is how long a shard is kept without synchronize calls.
+const shardIdleTimeout = 10 * time.Minute
+
+type shardKey struct {
+	tenant string
+	name   string
+}
+
+// A shard runs its own sequence of cycles, independent of other shards.
+type shard struct {
+	tenant  string
+	name    string
+	windows adaptiveWindows
+
+	// lastUsed is the time of the last synchronize call, guarded by
+	// shardsLock.
+	lastUsed time.Time
 
 	synchronizeRegistration chan *registrationRequest
 
@@ -73,19 +133,55 @@ type synchronizerService struct {
 	startCycle chan struct{}
 }
 
-func newSynchronizerService(cfg config.View, eval evaluator, store statestore.Service) *synchronizerService {
-	s := &synchronizerService{
-		cfg:   cfg,
-		store: store,
-		eval:  eval,
-
-		synchronizeRegistration: make(chan *registrationRequest),
-		startCycle:              make(chan struct{}, 1),
+func newSynchronizerService(cfg config.View, eval evaluator, store statestore.Service, leader *leaderElector) *synchronizerService {
+	return &synchronizerService{
+		cfg:      cfg,
+		store:    store,
+		eval:     eval,
+		leader:   leader,
+		claims:   newTicketClaims(),
+		cycles:   &cycleHistory{cfg: cfg},
+		proposed: newProposedTickets(maxProposedTickets),
+		shards:   make(map[shardKey]*shard),
 	}
+}
 
-	s.startCycle <- struct{}{}
+func (s *synchronizerService) getShard(tenantName, name string) *shard {
+	now := time.Now()
 
-	return s
+	s.shardsLock.Lock()
+	defer s.shardsLock.Unlock()
+
+	if now.Sub(s.lastEvict) >= shardIdleTimeout {
+		for key, sh := range s.shards {
+			if now.Sub(sh.lastUsed) < shardIdleTimeout {
+				continue
+			}
+			// Shards running a cycle are kept.
+			select {
+			case <-sh.startCycle:
+				sh.startCycle <- struct{}{}
+				delete(s.shards, key)
+			default:
+			}
+		}
+		s.lastEvict = now
+	}
+
+	key := shardKey{tenant: tenantName, name: name}
+	sh, ok := s.shards[key]
+	if !ok {
+		sh = &shard{
+			tenant:                  tenantName,
+			name:                    name,
+			synchronizeRegistration: make(chan *registrationRequest),
+			startCycle:              make(chan struct{}, 1),
+		}
+		sh.startCycle <- struct{}{}
+		s.shards[key] = sh
+	}
+	sh.lastUsed = now
+	return sh
 }
 
 func (s *synchronizerService) Synchronize(stream ipb.Synchronizer_SynchronizeServer) error {
@@ -94,14 +190,35 @@ func (s *synchronizerService) Synchronize(stream ipb.Synchronizer_SynchronizeSer
 	// 1. Receive proposals from backend, send them to cycle.
 	// 2. Receive matches and signals from cycle, send them to backend.
 
-	registration := s.register(stream.Context())
-	m6cBuffer := bufferStringChannel(registration.m7c)
+	// Standbys reject the stream, so the backend retries against the leader.
+	if !s.leader.isLeader() {
+		return errNotLeader
+	}
+
+	// The first request names the shard, and is sent without waiting for
+	// start_mmfs.
+	first, err := stream.Recv()
+	if err != nil {
+		if err == io.EOF {
+			return nil
+		}
+		return err
+	}
+
+	registration, err := s.register(stream.Context(), s.getShard(tenant.FromContext(stream.Context()), first.GetShard()))
+	if err != nil {
+		return err
+	}
+	m6cBuffer := bufferEvaluateResponseChannel(registration.m7c)
 	defer func() {
 		for range m6cBuffer {
 		}
 	}()
 
 	go func() {
+		if first.GetProposal() != nil {
+			registration.m1c.send(mAndM6c{m: first.Proposal, m7c: registration.m7c})
+		}
 		for {
 			req, err := stream.Recv()
 			if err != nil {
@@ -110,21 +227,21 @@ func (s *synchronizerService) Synchronize(stream ipb.Synchronizer_SynchronizeSer
 						"error": err.Error(),
 					}).Error("error streaming in synchronizer from backend")
 				}
-				registration.allM1cSent.Done()
+				registration.m1cDone()
 				return
 			}
 			registration.m1c.send(mAndM6c{m: req.Proposal, m7c: registration.m7c})
 		}
 	}()
 
-	err := stream.Send(&ipb.SynchronizeResponse{StartMmfs: true})
+	err = stream.Send(&ipb.SynchronizeResponse{StartMmfs: true})
 	if err != nil {
 		return err
 	}
 
 	for {
 		select {
-		case mIDs, ok := <-m6cBuffer:
+		case results, ok := <-m6cBuffer:
 			if !ok {
 				// Prevent race: An error will result in this channel being
 				// closed as part of cleanup.  If it's especially fast, it may
@@ -132,8 +249,8 @@ func (s *synchronizerService) Synchronize(stream ipb.Synchronizer_SynchronizeSer
 				// potential error.
 				return registration.cycleCtx.Err()
 			}
-			for _, mID := range mIDs {
-				err = stream.Send(&ipb.SynchronizeResponse{MatchId: mID})
+			for _, result := range results {
+				err = stream.Send(&ipb.SynchronizeResponse{MatchId: result.GetMatchId(), RejectedMatch: result.GetRejectedMatch()})
 				if err != nil {
 					logger.WithFields(logrus.Fields{
 						"error": err.Error(),
@@ -176,29 +293,39 @@ type registrationRequest struct {
 }
 
 type registration struct {
-	m1c        *cutoffSender
-	allM1cSent *sync.WaitGroup
-	m7c        chan string
+	m1c *cutoffSender
+	// m1cDone is called once the synchronize call has sent all of its
+	// proposals.
+	m1cDone    func()
+	m7c        chan *pb.EvaluateResponse
 	cancelMmfs chan struct{}
 	cycleCtx   context.Context
 }
 
-func (s synchronizerService) register(ctx context.Context) *registration {
+// register registers the synchronize call in the running cycle of the shard,
+// or else in a new cycle.  Only the leader starts new cycles.
+func (s *synchronizerService) register(ctx context.Context, sh *shard) (*registration, error) {
 	req := &registrationRequest{
 		resp: make(chan *registration),
 		ctx:  ctx,
 	}
 
 	st := time.Now()
-	defer stats.Record(ctx, registrationWaitTime.M(float64(time.Since(st))/float64(time.Millisecond)))
+	defer func() {
+		stats.Record(ctx, registrationWaitTime.M(float64(time.Since(st))/float64(time.Millisecond)))
+	}()
 	for {
 		select {
-		case s.synchronizeRegistration <- req:
-			return <-req.resp
-		case <-s.startCycle:
+		case sh.synchronizeRegistration <- req:
+			return <-req.resp, nil
+		case <-sh.startCycle:
+			if !s.leader.isLeader() {
+				sh.startCycle <- struct{}{}
+				return nil, errNotLeader
+			}
 			go func() {
-				s.runCycle()
-				s.startCycle <- struct{}{}
+				s.runCycle(sh)
+				sh.startCycle <- struct{}{}
 			}()
 		}
 	}
@@ -207,22 +334,64 @@ func (s synchronizerService) register(ctx context.Context) *registration {
 ///////////////////////////////////////
 ///////////////////////////////////////
 
-func (s *synchronizerService) runCycle() {
+func (s *synchronizerService) runCycle(sh *shard) {
 	cst := time.Now()
 	/////////////////////////////////////// Initialize cycle
-	ctx, cancel := contextcause.WithCancelCause(context.Background())
+	ctx, cancel := contextcause.WithCancelCause(tenant.NewContext(context.Background(), sh.tenant))
+	// The evaluator call of the cycle is shared by every synchronize call, so
+	// the cycle is traced on its own, linked to the traces of the calls.
+	ctx, span := trace.StartSpan(ctx, "openmatch.synchronizer.cycle")
+	defer span.End()
+	span.AddAttributes(trace.StringAttribute("shard", sh.name))
+	// A replica losing its lease stops its cycles, as the new leader may run
+	// cycles on the same tickets.
+	lost := s.leader.leadershipLost()
+	go func() {
+		select {
+		case <-lost:
+			cancel(errNotLeader)
+		case <-ctx.Done():
+		}
+	}()
+	cycle := s.claims.startCycle()
+	w := sh.windows.next(s.cfg)
+	_ = stats.RecordWithTags(ctx, []tag.Mutator{tag.Upsert(shardTag, sh.name)},
+		registrationWindow.M(float64(w.registration)/float64(time.Millisecond)),
+		proposalWindow.M(float64(w.proposal)/float64(time.Millisecond)),
+	)
+	record := &cycleRecord{cycleSummary: cycleSummary{
+		Tenant:             sh.tenant,
+		Shard:              sh.name,
+		Start:              cst,
+		RegistrationWindow: jsonDuration(w.registration),
+		ProposalWindow:     jsonDuration(w.proposal),
+	}}
 
 	m2c := make(chan mAndM6c)
 	m3c := make(chan *pb.Match)
 	m4c := make(chan *pb.Match)
-	m5c := make(chan string)
-	m6c := make(chan string)
+	m5c := make(chan *pb.EvaluateResponse)
+	m6c := make(chan *pb.EvaluateResponse)
 
 	m1c := newCutoffSender(m2c)
 	// m7c, unlike other channels, is specific to a synchronize call.  There are
 	// multiple values in a given cycle.
 
 	var allM1cSent sync.WaitGroup
+	// With early exit, registration closes once every registered call is done
+	// sending.  pendingM1c counts the calls still sending, and m1cDoneNotify
+	// is signaled when it reaches zero.
+	var pendingM1c int64
+	m1cDoneNotify := make(chan struct{}, 1)
+	m1cDone := func() {
+		allM1cSent.Done()
+		if atomic.AddInt64(&pendingM1c, -1) == 0 {
+			select {
+			case m1cDoneNotify <- struct{}{}:
+			default:
+			}
+		}
+	}
 
 	registrations := []*registration{}
 	callingCtx := []context.Context{}
@@ -237,10 +406,11 @@ func (s *synchronizerService) runCycle() {
 	}()
 
 	matchTickets := &sync.Map{}
-	go s.cacheMatchIDToTicketIDs(matchTickets, m3c, m4c)
+	go s.cacheMatchIDToTicketIDs(ctx, record, matchTickets, m3c, m4c)
 	go s.wrapEvaluator(ctx, cancel, bufferMatchChannel(m4c), m5c)
 	go func() {
-		s.addMatchesToIgnoreList(ctx, matchTickets, cancel, bufferStringChannel(m5c), m6c)
+		s.addMatchesToIgnoreList(ctx, cycle, record, matchTickets, cancel, bufferEvaluateResponseChannel(m5c), m6c)
+		s.claims.endCycle(cycle)
 		// Wait for ignore list, but not all matches returned, the next cycle
 		// can start now.
 		close(closedOnCycleEnd)
@@ -248,26 +418,43 @@ func (s *synchronizerService) runCycle() {
 
 	/////////////////////////////////////// Run Registration Period
 	rst := time.Now()
-	closeRegistration := time.After(s.registrationInterval())
+	closeRegistration := time.After(w.registration)
+	var earlyExit <-chan struct{}
+	if s.cfg.GetBool(configNameEarlyExit) {
+		earlyExit = m1cDoneNotify
+	}
 Registration:
 	for {
 		select {
-		case req := <-s.synchronizeRegistration:
+		case req := <-sh.synchronizeRegistration:
 			allM1cSent.Add(1)
+			atomic.AddInt64(&pendingM1c, 1)
 			callingCtx = append(callingCtx, req.ctx)
+			linkSpans(trace.FromContext(req.ctx), span)
 			r := &registration{
 				m1c:        m1c,
-				m7c:        make(chan string),
+				m7c:        make(chan *pb.EvaluateResponse),
 				cancelMmfs: make(chan struct{}, 1),
 				cycleCtx:   ctx,
-				allM1cSent: &allM1cSent,
+				m1cDone:    m1cDone,
 			}
 			registrations = append(registrations, r)
 			req.resp <- r
 		case <-closeRegistration:
 			break Registration
+		case <-lost:
+			break Registration
+		case <-earlyExit:
+			// Notifications may be stale, if a call registered since.
+			if atomic.LoadInt64(&pendingM1c) == 0 {
+				break Registration
+			}
 		}
 	}
+	record.mu.Lock()
+	record.Registrations = len(registrations)
+	record.RegistrationDuration = jsonDuration(time.Since(cst))
+	record.mu.Unlock()
 	/////////////////////////////////////// Wait for cycle completion.
 
 	go func() {
@@ -277,13 +464,21 @@ Registration:
 		cancel(fmt.Errorf("canceled because all callers were done"))
 	}()
 
+	pst := time.Now()
+	// mmfLatency is only read once allMmfsDone is closed.
+	var mmfLatency time.Duration
+	allMmfsDone := make(chan struct{})
 	go func() {
 		allM1cSent.Wait()
+		mmfLatency = time.Since(pst)
+		close(allMmfsDone)
+		record.proposalsDone(false)
 		m1c.cutoff()
-		stats.Record(ctx, registrationMMFDoneTime.M(float64((s.registrationInterval()-time.Since(rst))/time.Millisecond)))
+		stats.Record(ctx, registrationMMFDoneTime.M(float64((w.registration-time.Since(rst))/time.Millisecond)))
 	}()
 
-	cancelProposalCollection := time.AfterFunc(s.proposalCollectionInterval(), func() {
+	cancelProposalCollection := time.AfterFunc(w.proposal, func() {
+		record.proposalsDone(true)
 		m1c.cutoff()
 		for _, r := range registrations {
 			r.cancelMmfs <- struct{}{}
@@ -292,8 +487,29 @@ Registration:
 	<-closedOnCycleEnd
 	stats.Record(ctx, iterationLatency.M(float64(time.Since(cst)/time.Millisecond)))
 
+	select {
+	case <-allMmfsDone:
+		sh.windows.observe(w, mmfLatency, mmfLatency >= w.proposal)
+	default:
+		sh.windows.observe(w, 0, true)
+	}
+
 	// Clean up in case it was never needed.
 	cancelProposalCollection.Stop()
+
+	record.end(ctx.Err())
+	s.cycles.add(record)
+}
+
+// linkSpans links the span of a synchronize call and the span of the cycle it
+// registered in, if the call is traced.
+func linkSpans(call, cycle *trace.Span) {
+	if call == nil {
+		return
+	}
+	callSC, cycleSC := call.SpanContext(), cycle.SpanContext()
+	cycle.AddLink(trace.Link{TraceID: callSC.TraceID, SpanID: callSC.SpanID, Type: trace.LinkTypeParent})
+	call.AddLink(trace.Link{TraceID: cycleSC.TraceID, SpanID: cycleSC.SpanID, Type: trace.LinkTypeChild})
 }
 
 ///////////////////////////////////////
@@ -301,16 +517,17 @@ Registration:
 
 type mAndM6c struct {
 	m   *pb.Match
-	m7c chan string
+	m7c chan *pb.EvaluateResponse
 }
 
 // fanInFanOut routes evaluated matches back to it's source synchronize call.
 // Each incoming match is passed along with it's synchronize call's m7c channel.
 // This channel is remembered in a map, and the match is passed to be evaluated.
 // When a match returns from evaluation, it's ID is looked up in the map and the
-// match is returned on that channel.
-func fanInFanOut(m2c <-chan mAndM6c, m3c chan<- *pb.Match, m6c <-chan string) {
-	m6cMap := make(map[string]chan<- string)
+// match is returned on that channel.  Matches the evaluator neither accepted
+// nor rejected are returned as rejected for an unknown reason.
+func fanInFanOut(m2c <-chan mAndM6c, m3c chan<- *pb.Match, m6c <-chan *pb.EvaluateResponse) {
+	m6cMap := make(map[string]chan<- *pb.EvaluateResponse)
 
 	defer func(m2c <-chan mAndM6c) {
 		for range m2c {
@@ -331,15 +548,25 @@ func fanInFanOut(m2c <-chan mAndM6c, m3c chan<- *pb.Match, m6c <-chan string) {
 
 		case m5, ok := <-m6c:
 			if !ok {
+				for id, m7c := range m6cMap {
+					m7c <- &pb.EvaluateResponse{
+						RejectedMatch: &pb.RejectedMatch{
+							MatchId: id,
+							Reason:  pb.RejectedMatch_UNKNOWN,
+						},
+					}
+				}
 				return
 			}
 
-			m7c, ok := m6cMap[m5]
+			id := evaluatedMatchID(m5)
+			m7c, ok := m6cMap[id]
 			if ok {
 				m7c <- m5
+				delete(m6cMap, id)
 			} else {
 				logger.WithFields(logrus.Fields{
-					"matchId": m5,
+					"matchId": id,
 				}).Error("Match ID from evaluator does not match any id sent to it.")
 			}
 		}
@@ -401,7 +628,7 @@ func (c *cutoffSender) cutoff() {
 ///////////////////////////////////////
 
 // Calls the evaluator with the matches.
-func (s *synchronizerService) wrapEvaluator(ctx context.Context, cancel contextcause.CancelErrFunc, m4c <-chan []*pb.Match, m5c chan<- string) {
+func (s *synchronizerService) wrapEvaluator(ctx context.Context, cancel contextcause.CancelErrFunc, m4c <-chan []*pb.Match, m5c chan<- *pb.EvaluateResponse) {
 	err := s.eval.evaluate(ctx, m4c, m5c)
 	if err != nil {
 		logger.WithFields(logrus.Fields{
@@ -415,12 +642,32 @@ func (s *synchronizerService) wrapEvaluator(ctx context.Context, cancel contextc
 ///////////////////////////////////////
 ///////////////////////////////////////
 
-func (s *synchronizerService) cacheMatchIDToTicketIDs(m *sync.Map, m3c <-chan *pb.Match, m4c chan<- *pb.Match) {
+func (s *synchronizerService) cacheMatchIDToTicketIDs(ctx context.Context, record *cycleRecord, m *sync.Map, m3c <-chan *pb.Match, m4c chan<- *pb.Match) {
+	proposed := make(map[string]time.Time)
 	for match := range m3c {
-		m.Store(match.GetMatchId(), getTicketIds(match.GetTickets()))
+		record.proposalReceived()
+		tids := getTicketIds(match.GetTickets())
+		m.Store(match.GetMatchId(), tids)
+		now := time.Now()
+		for _, id := range tids {
+			if _, ok := proposed[id]; !ok && !s.proposed.recorded(id) {
+				proposed[id] = now
+			}
+		}
 		m4c <- match
 	}
 	close(m4c)
+
+	// The store keeps the time tickets were first proposed in any cycle, so
+	// only tickets not proposed in previous cycles are recorded.
+	err := s.store.RecordTicketEvents(ctx, statestore.TicketProposed, proposed)
+	if err != nil {
+		logger.WithError(err).Error("failed to record the proposed tickets")
+		return
+	}
+	for id := range proposed {
+		s.proposed.add(id)
+	}
 }
 
 func getTicketIds(tickets []*pb.Ticket) []string {
@@ -437,33 +684,79 @@ func getTicketIds(tickets []*pb.Ticket) []string {
 // Calls statestore to add all of the tickets returned by the evaluator to the
 // ignorelist.  If it partially fails for whatever reason (not all tickets will
 // nessisarily be in the same call), only the matches which can be safely
-// returned to the Synchronize calls are.
-func (s *synchronizerService) addMatchesToIgnoreList(ctx context.Context, m *sync.Map, cancel contextcause.CancelErrFunc, m5c <-chan []string, m6c chan<- string) {
+// returned to the Synchronize calls are.  Rejected matches are passed through.
+// Accepted matches which share tickets with a match accepted by a concurrent
+// cycle of another shard are rejected as collisions.
+func (s *synchronizerService) addMatchesToIgnoreList(ctx context.Context, cycle *claimCycle, record *cycleRecord, m *sync.Map, cancel contextcause.CancelErrFunc, m5c <-chan []*pb.EvaluateResponse, m6c chan<- *pb.EvaluateResponse) {
 	totalMatches := 0
 	successfulMatches := 0
 	var lastErr error
-	for mIDs := range m5c {
+	traceFraction := s.cfg.GetFloat64(configNameTicketTraceSamplingFraction)
+	for results := range m5c {
+		mIDs := []string{}
 		ids := []string{}
-		for _, mID := range mIDs {
+		matched := make(map[string][]string)
+		for i, result := range results {
+			if result.GetRejectedMatch() != nil {
+				continue
+			}
+			mID := result.GetMatchId()
 			tids, ok := m.Load(mID)
-			if ok {
-				ids = append(ids, tids.([]string)...)
-			} else {
+			if !ok {
 				logger.Errorf("failed to get MatchId %s with its corresponding tickets from the cache", mID)
+				mIDs = append(mIDs, mID)
+				continue
 			}
+			if colliding, ok := s.claims.claim(cycle, mID, tids.([]string)); !ok {
+				results[i] = &pb.EvaluateResponse{
+					RejectedMatch: &pb.RejectedMatch{
+						MatchId:          mID,
+						Reason:           pb.RejectedMatch_COLLISION,
+						CollidingMatchId: colliding,
+					},
+				}
+				continue
+			}
+			mIDs = append(mIDs, mID)
+			ids = append(ids, tids.([]string)...)
+			matched[mID] = tids.([]string)
 		}
 
-		err := s.store.AddTicketsToIgnoreList(ctx, ids)
+		// The lease may have expired since the cycle started, and the new
+		// leader may have proposed the same tickets, so the accepted matches
+		// are dropped like those failing to be added to the ignore list.
+		var err error
+		if s.leader.isLeader() {
+			err = s.store.AddTicketsToIgnoreList(ctx, ids)
+		} else {
+			err = errNotLeader
+		}
+		// Tickets which failed to be added to the ignore list are released,
+		// as their matches are dropped below.
+		if err == nil {
+			s.claims.commit(cycle)
+		} else {
+			s.claims.release(cycle)
+		}
 
 		totalMatches += len(mIDs)
 		if err == nil {
 			successfulMatches += len(mIDs)
+			record.evaluated(len(mIDs), len(results)-len(mIDs), ids)
+			traceMatchedTickets(ctx, traceFraction, matched)
 		} else {
 			lastErr = err
+			record.evaluated(0, len(results), nil)
 		}
+		s.auditDecisions(ctx, record.Shard, results, m, err)
 
-		for _, mID := range mIDs {
-			m6c <- mID
+		for _, result := range results {
+			// Another cycle may return the tickets of matches which aren't in
+			// the ignore list, so those matches are dropped rather than sent.
+			if err != nil && result.GetRejectedMatch() == nil {
+				continue
+			}
+			m6c <- result
 		}
 	}
 
@@ -481,33 +774,75 @@ func (s *synchronizerService) addMatchesToIgnoreList(ctx context.Context, m *syn
 	close(m6c)
 }
 
-///////////////////////////////////////
-///////////////////////////////////////
-
-func (s *synchronizerService) registrationInterval() time.Duration {
-	const (
-		name            = "registrationInterval"
-		defaultInterval = time.Second
-	)
-
-	if !s.cfg.IsSet(name) {
-		return defaultInterval
+// auditDecisions records the decisions of the evaluator on the matches of the
+// cycle.  Accepted matches failing to be added to the ignore list are dropped,
+// which is recorded as the error of their event.
+func (s *synchronizerService) auditDecisions(ctx context.Context, shard string, results []*pb.EvaluateResponse, m *sync.Map, err error) {
+	for _, result := range results {
+		e := audit.Event{
+			Operation: audit.MatchAccepted,
+			MatchIDs:  []string{result.GetMatchId()},
+			Details:   map[string]string{"shard": shard},
+			Error:     audit.ErrorOf(err),
+		}
+		if rejected := result.GetRejectedMatch(); rejected != nil {
+			e.Operation = audit.MatchRejected
+			e.MatchIDs = []string{rejected.GetMatchId()}
+			e.Details["reason"] = rejected.GetReason().String()
+			if rejected.GetCollidingMatchId() != "" {
+				e.Details["collidingMatchId"] = rejected.GetCollidingMatchId()
+			}
+			e.Error = ""
+		}
+		if tids, ok := m.Load(e.MatchIDs[0]); ok {
+			e.TicketIDs = tids.([]string)
+		}
+		s.audit.Log(ctx, e)
 	}
-
-	return s.cfg.GetDuration(name)
 }
 
-func (s *synchronizerService) proposalCollectionInterval() time.Duration {
-	const (
-		name            = "proposalCollectionInterval"
-		defaultInterval = 10 * time.Second
-	)
+///////////////////////////////////////
+///////////////////////////////////////
 
-	if !s.cfg.IsSet(name) {
-		return defaultInterval
+// traceMatchedTickets traces the sampled tickets of the matches, linking each
+// ticket to the cycle and match which consumed it.  Each ticket is traced on
+// its own, as the cycle is only traced if its own trace is sampled.
+func traceMatchedTickets(ctx context.Context, fraction float64, matches map[string][]string) {
+	if fraction <= 0 {
+		return
 	}
+	cycle := trace.FromContext(ctx)
+	for mID, tids := range matches {
+		for _, tid := range tids {
+			if !sampleTicket(tid, fraction) {
+				continue
+			}
+			_, span := trace.StartSpan(context.Background(), "openmatch.synchronizer.ticket", trace.WithSampler(trace.AlwaysSample()))
+			span.AddAttributes(
+				trace.StringAttribute("ticketId", tid),
+				trace.StringAttribute("matchId", mID),
+			)
+			if cycle != nil {
+				sc := cycle.SpanContext()
+				span.AddLink(trace.Link{TraceID: sc.TraceID, SpanID: sc.SpanID, Type: trace.LinkTypeParent})
+			}
+			span.End()
+		}
+	}
+}
 
-	return s.cfg.GetDuration(name)
+// sampleTicket samples the fraction of tickets by their id, so that the same
+// tickets are sampled by every cycle.
+func sampleTicket(id string, fraction float64) bool {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(id))
+	// Ids such as xids share long prefixes, so the hash is mixed further to
+	// spread them evenly (the splitmix64 finalizer).
+	x := h.Sum64()
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	x ^= x >> 31
+	return float64(x) < fraction*math.MaxUint64
 }
 
 ///////////////////////////////////////
@@ -551,23 +886,23 @@ func bufferMatchChannel(in chan *pb.Match) chan []*pb.Match {
 	return out
 }
 
-// bufferStringChannel collects strings from the input, and sends
-// slice of strings on the output.  It never (for long) blocks
+// bufferEvaluateResponseChannel collects evaluator results from the input,
+// and sends slice of results on the output.  It never (for long) blocks
 // the input channel, always appending to the slice which will
 // next be used for output.  Used before external calls, so that
 // network won't back up internal processing.
-func bufferStringChannel(in chan string) chan []string {
-	out := make(chan []string)
+func bufferEvaluateResponseChannel(in chan *pb.EvaluateResponse) chan []*pb.EvaluateResponse {
+	out := make(chan []*pb.EvaluateResponse)
 	go func() {
-		var a []string
+		var a []*pb.EvaluateResponse
 
 	outerLoop:
 		for {
-			mID, ok := <-in
+			r, ok := <-in
 			if !ok {
 				break outerLoop
 			}
-			a = []string{mID}
+			a = []*pb.EvaluateResponse{r}
 
 			for len(a) > 0 {
 				select {
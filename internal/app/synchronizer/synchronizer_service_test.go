@@ -0,0 +1,265 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package synchronizer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rs/xid"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"open-match.dev/open-match/internal/audit"
+	"open-match.dev/open-match/internal/statestore"
+	statestoreTesting "open-match.dev/open-match/internal/statestore/testing"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+func TestEarlyExit(t *testing.T) {
+	ctx := context.Background()
+	cfg := viper.New()
+	store, closer := statestoreTesting.NewStoreServiceForTesting(t, cfg)
+	defer closer()
+
+	cfg.Set("registrationInterval", "10s")
+	cfg.Set("proposalCollectionInterval", "10s")
+	cfg.Set(configNameEarlyExit, true)
+
+	// Like the default evaluator, only decides once all proposals are in.
+	eval := evaluatorFunc(func(ctx context.Context, pc <-chan []*pb.Match, results chan<- *pb.EvaluateResponse) error {
+		var all []*pb.Match
+		for proposals := range pc {
+			all = append(all, proposals...)
+		}
+		for _, m := range all {
+			results <- &pb.EvaluateResponse{MatchId: m.GetMatchId()}
+		}
+		return nil
+	})
+	s := newSynchronizerService(cfg, eval, store, nil)
+	sh := s.getShard("", "")
+
+	start := time.Now()
+	a, err := s.register(ctx, sh)
+	require.Nil(t, err)
+	b, err := s.register(ctx, sh)
+	require.Nil(t, err)
+
+	a.m1c.send(mAndM6c{m: &pb.Match{MatchId: "a"}, m7c: a.m7c})
+	a.m1cDone()
+
+	// b is still sending, so the cycle is still registering.
+	select {
+	case r := <-a.m7c:
+		require.FailNow(t, "cycle evaluated before all calls were done sending", "%v", r)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	b.m1cDone()
+	r := <-a.m7c
+	require.Equal(t, "a", r.GetMatchId())
+	_, ok := <-a.m7c
+	require.False(t, ok)
+	_, ok = <-b.m7c
+	require.False(t, ok)
+	require.True(t, time.Since(start) < time.Second, "%s", time.Since(start))
+}
+
+func TestProposedTicketsRecorded(t *testing.T) {
+	ctx := context.Background()
+	cfg := viper.New()
+	store, closer := statestoreTesting.NewStoreServiceForTesting(t, cfg)
+	defer closer()
+
+	cfg.Set("registrationInterval", "10s")
+	cfg.Set("proposalCollectionInterval", "10s")
+	cfg.Set(configNameEarlyExit, true)
+	cfg.Set(configNameTicketTraceSamplingFraction, 1)
+
+	eval := evaluatorFunc(func(ctx context.Context, pc <-chan []*pb.Match, results chan<- *pb.EvaluateResponse) error {
+		for proposals := range pc {
+			for _, m := range proposals {
+				results <- &pb.EvaluateResponse{MatchId: m.GetMatchId()}
+			}
+		}
+		return nil
+	})
+	s := newSynchronizerService(cfg, eval, store, nil)
+	sh := s.getShard("", "")
+
+	start := time.Now()
+	a, err := s.register(ctx, sh)
+	require.Nil(t, err)
+	a.m1c.send(mAndM6c{m: &pb.Match{MatchId: "a", Tickets: []*pb.Ticket{{Id: "1"}, {Id: "2"}}}, m7c: a.m7c})
+	a.m1cDone()
+	for range a.m7c {
+	}
+
+	// The proposed tickets are recorded once the proposals are collected, and
+	// then remembered so that later cycles don't record them again.
+	require.Eventually(t, func() bool {
+		lifecycles, err := store.GetTicketLifecycles(ctx, []string{"1", "2"})
+		require.Nil(t, err)
+		return len(lifecycles) == 2
+	}, time.Second, 10*time.Millisecond)
+	require.Eventually(t, func() bool {
+		return s.proposed.recorded("1") && s.proposed.recorded("2")
+	}, time.Second, 10*time.Millisecond)
+
+	lifecycles, err := store.GetTicketLifecycles(ctx, []string{"1"})
+	require.Nil(t, err)
+	proposed := lifecycles["1"].Times[statestore.TicketProposed]
+	require.False(t, proposed.Before(start), "%s", proposed)
+}
+
+// failingLifecycles fails to record the events of tickets.
+type failingLifecycles struct {
+	statestore.Service
+}
+
+func (failingLifecycles) RecordTicketEvents(ctx context.Context, event statestore.TicketEvent, times map[string]time.Time) error {
+	return errors.New("redis is down")
+}
+
+func TestProposedTicketsRecordFailure(t *testing.T) {
+	s := newSynchronizerService(viper.New(), nil, failingLifecycles{}, nil)
+
+	m3c := make(chan *pb.Match, 1)
+	m4c := make(chan *pb.Match, 1)
+	m3c <- &pb.Match{MatchId: "a", Tickets: []*pb.Ticket{{Id: "1"}}}
+	close(m3c)
+	s.cacheMatchIDToTicketIDs(context.Background(), &cycleRecord{}, &sync.Map{}, m3c, m4c)
+
+	// The next cycle records the ticket again.
+	require.False(t, s.proposed.recorded("1"))
+}
+
+// failingIgnoreList fails to add tickets to the ignore list.
+type failingIgnoreList struct {
+	statestore.Service
+}
+
+func (failingIgnoreList) AddTicketsToIgnoreList(ctx context.Context, ids []string) error {
+	return errors.New("redis is down")
+}
+
+func TestIgnoreListFailure(t *testing.T) {
+	ctx := context.Background()
+	cfg := viper.New()
+	store, closer := statestoreTesting.NewStoreServiceForTesting(t, cfg)
+	defer closer()
+
+	cfg.Set("registrationInterval", "10s")
+	cfg.Set("proposalCollectionInterval", "10s")
+	cfg.Set(configNameEarlyExit, true)
+
+	eval := evaluatorFunc(func(ctx context.Context, pc <-chan []*pb.Match, results chan<- *pb.EvaluateResponse) error {
+		for proposals := range pc {
+			for _, m := range proposals {
+				results <- &pb.EvaluateResponse{MatchId: m.GetMatchId()}
+			}
+		}
+		return nil
+	})
+	s := newSynchronizerService(cfg, eval, failingIgnoreList{store}, nil)
+	sh := s.getShard("", "")
+
+	a, err := s.register(ctx, sh)
+	require.Nil(t, err)
+	a.m1c.send(mAndM6c{m: &pb.Match{MatchId: "a", Tickets: []*pb.Ticket{{Id: "1"}}}, m7c: a.m7c})
+	a.m1cDone()
+
+	for r := range a.m7c {
+		require.NotNil(t, r.GetRejectedMatch(), "match %s returned without its tickets in the ignore list", r.GetMatchId())
+	}
+
+	// The claims on the dropped match's tickets are released.
+	c := s.claims.startCycle()
+	_, ok := s.claims.claim(c, "b", []string{"1"})
+	require.True(t, ok)
+}
+
+func TestShardEviction(t *testing.T) {
+	s := newSynchronizerService(viper.New(), nil, nil, nil)
+	idle := s.getShard("a", "")
+	require.Same(t, idle, s.getShard("a", ""))
+	running := s.getShard("b", "")
+	<-running.startCycle
+
+	for _, sh := range []*shard{idle, running} {
+		sh.lastUsed = sh.lastUsed.Add(-shardIdleTimeout)
+	}
+	s.lastEvict = s.lastEvict.Add(-shardIdleTimeout)
+	s.getShard("c", "")
+
+	// Only the idle shard without a running cycle is evicted.
+	require.Len(t, s.shards, 2)
+	require.Same(t, running, s.getShard("b", ""))
+	require.True(t, idle != s.getShard("a", ""))
+}
+
+func TestSampleTicket(t *testing.T) {
+	ids := []string{}
+	for i := 0; i < 1000; i++ {
+		ids = append(ids, xid.New().String())
+	}
+	sampled := 0
+	for _, id := range ids {
+		require.False(t, sampleTicket(id, 0))
+		require.True(t, sampleTicket(id, 1))
+		if sampleTicket(id, 0.5) {
+			sampled++
+			// The same tickets are sampled every time.
+			require.True(t, sampleTicket(id, 0.5))
+		}
+	}
+	require.InDelta(t, 500, sampled, 100)
+}
+
+func TestAuditDecisions(t *testing.T) {
+	require := require.New(t)
+	buf := &bytes.Buffer{}
+	s := &synchronizerService{audit: audit.NewWriter(buf, "synchronizer")}
+
+	m := &sync.Map{}
+	m.Store("m1", []string{"t1", "t2"})
+	m.Store("m2", []string{"t2", "t3"})
+	s.auditDecisions(context.Background(), "1v1", []*pb.EvaluateResponse{
+		{MatchId: "m1"},
+		{RejectedMatch: &pb.RejectedMatch{MatchId: "m2", Reason: pb.RejectedMatch_COLLISION, CollidingMatchId: "m1"}},
+	}, m, nil)
+
+	d := json.NewDecoder(buf)
+	var accepted, rejected audit.Event
+	require.Nil(d.Decode(&accepted))
+	require.Nil(d.Decode(&rejected))
+	require.False(d.More())
+
+	require.Equal(audit.MatchAccepted, accepted.Operation)
+	require.Equal([]string{"m1"}, accepted.MatchIDs)
+	require.Equal([]string{"t1", "t2"}, accepted.TicketIDs)
+	require.Equal(map[string]string{"shard": "1v1"}, accepted.Details)
+
+	require.Equal(audit.MatchRejected, rejected.Operation)
+	require.Equal([]string{"m2"}, rejected.MatchIDs)
+	require.Equal([]string{"t2", "t3"}, rejected.TicketIDs)
+	require.Equal("COLLISION", rejected.Details["reason"])
+	require.Equal("m1", rejected.Details["collidingMatchId"])
+}
@@ -0,0 +1,138 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package synchronizer
+
+import (
+	"sync"
+	"time"
+
+	"open-match.dev/open-match/internal/config"
+)
+
+const (
+	configNameAdaptiveWindowsEnabled = "synchronizer.adaptiveWindows.enabled"
+	// The registration window shrinks down to this, but never grows past
+	// registrationInterval.
+	configNameMinRegistrationInterval = "synchronizer.adaptiveWindows.minRegistrationInterval"
+	// The proposal window varies between these, starting at
+	// proposalCollectionInterval.
+	configNameMinProposalCollectionInterval = "synchronizer.adaptiveWindows.minProposalCollectionInterval"
+	configNameMaxProposalCollectionInterval = "synchronizer.adaptiveWindows.maxProposalCollectionInterval"
+
+	defaultMinInterval = 100 * time.Millisecond
+	// The default cap is this many times proposalCollectionInterval.
+	defaultMaxProposalCollectionFactor = 4
+
+	// latencyHeadroom is how much longer than the typical MMF latency the
+	// proposal window is kept, so MMFs which are slightly slower than usual
+	// aren't cancelled.
+	latencyHeadroom = 2
+	// latencySmoothing is the weight of the latest cycle in the typical MMF
+	// latency.
+	latencySmoothing = 0.3
+)
+
+// adaptiveWindows chooses the window sizes of a shard's cycles from the MMF
+// latency of previous cycles.  The MMF latency of a cycle is the time from the
+// registration window closing to the last MMF finishing.
+//
+// When a cycle's MMFs are cancelled, the proposal window is doubled, up to the
+// cap.  When they finish early, the proposal window moves towards twice the
+// typical MMF latency, shrinking by at most half each cycle.  The registration
+// window follows the typical MMF latency: there is no reason to hold back a
+// cycle for registrations much longer than the cycle's MMFs take.
+//
+// When disabled, the configured intervals are used as is.
+type adaptiveWindows struct {
+	mu sync.Mutex
+	// Zero until the first cycle is observed.
+	proposal time.Duration
+	latency  time.Duration
+}
+
+type windows struct {
+	registration time.Duration
+	proposal     time.Duration
+}
+
+// next returns the windows for the next cycle.
+func (aw *adaptiveWindows) next(cfg config.View) windows {
+	w := windows{
+		registration: durationOrDefault(cfg, "registrationInterval", time.Second),
+		proposal:     durationOrDefault(cfg, "proposalCollectionInterval", 10*time.Second),
+	}
+	if !cfg.GetBool(configNameAdaptiveWindowsEnabled) {
+		return w
+	}
+
+	aw.mu.Lock()
+	defer aw.mu.Unlock()
+
+	if aw.proposal == 0 {
+		return w
+	}
+
+	minRegistration := durationOrDefault(cfg, configNameMinRegistrationInterval, defaultMinInterval)
+	minProposal := durationOrDefault(cfg, configNameMinProposalCollectionInterval, defaultMinInterval)
+	maxProposal := durationOrDefault(cfg, configNameMaxProposalCollectionInterval, defaultMaxProposalCollectionFactor*w.proposal)
+
+	w.registration = clampDuration(aw.latency, minRegistration, w.registration)
+	w.proposal = clampDuration(aw.proposal, minProposal, maxProposal)
+	return w
+}
+
+// observe updates the windows from a completed cycle.  latency is ignored if
+// the MMFs were cancelled.
+func (aw *adaptiveWindows) observe(used windows, latency time.Duration, cancelled bool) {
+	aw.mu.Lock()
+	defer aw.mu.Unlock()
+
+	if cancelled {
+		// The MMFs took at least the whole window.
+		latency = used.proposal
+	}
+	if aw.latency == 0 {
+		aw.latency = latency
+	} else {
+		aw.latency = time.Duration(latencySmoothing*float64(latency) + (1-latencySmoothing)*float64(aw.latency))
+	}
+
+	if cancelled {
+		aw.proposal = 2 * used.proposal
+		return
+	}
+	target := latencyHeadroom * aw.latency
+	if target < used.proposal/2 {
+		target = used.proposal / 2
+	}
+	aw.proposal = target
+}
+
+func durationOrDefault(cfg config.View, name string, d time.Duration) time.Duration {
+	if !cfg.IsSet(name) {
+		return d
+	}
+	return cfg.GetDuration(name)
+}
+
+func clampDuration(d, min, max time.Duration) time.Duration {
+	if d > max {
+		d = max
+	}
+	if d < min {
+		d = min
+	}
+	return d
+}
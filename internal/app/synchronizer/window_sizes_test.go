@@ -0,0 +1,74 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package synchronizer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdaptiveWindows(t *testing.T) {
+	cfg := viper.New()
+	cfg.Set("registrationInterval", "1s")
+	cfg.Set("proposalCollectionInterval", "2s")
+
+	aw := &adaptiveWindows{}
+	configured := windows{registration: time.Second, proposal: 2 * time.Second}
+
+	// Disabled uses the config as is.
+	aw.observe(configured, 100*time.Millisecond, false)
+	require.Equal(t, configured, aw.next(cfg))
+
+	cfg.Set(configNameAdaptiveWindowsEnabled, true)
+	aw = &adaptiveWindows{}
+	require.Equal(t, configured, aw.next(cfg))
+
+	// Early MMFs shrink the proposal window by at most half, and the
+	// registration window to the MMF latency.
+	aw.observe(configured, 100*time.Millisecond, false)
+	w := aw.next(cfg)
+	require.Equal(t, windows{registration: 100 * time.Millisecond, proposal: time.Second}, w)
+
+	aw.observe(w, 100*time.Millisecond, false)
+	w = aw.next(cfg)
+	require.Equal(t, windows{registration: 100 * time.Millisecond, proposal: 500 * time.Millisecond}, w)
+
+	aw.observe(w, 100*time.Millisecond, false)
+	w = aw.next(cfg)
+	require.Equal(t, 250*time.Millisecond, w.proposal)
+
+	aw.observe(w, 100*time.Millisecond, false)
+	w = aw.next(cfg)
+	require.Equal(t, 200*time.Millisecond, w.proposal)
+
+	// Cancelled MMFs double the proposal window, up to the cap.
+	aw.observe(w, 0, true)
+	w = aw.next(cfg)
+	require.Equal(t, 400*time.Millisecond, w.proposal)
+
+	for i := 0; i < 10; i++ {
+		aw.observe(w, 0, true)
+		w = aw.next(cfg)
+	}
+	require.Equal(t, 8*time.Second, w.proposal)
+	require.Equal(t, time.Second, w.registration)
+
+	cfg.Set(configNameMaxProposalCollectionInterval, "3s")
+	w = aw.next(cfg)
+	require.Equal(t, 3*time.Second, w.proposal)
+}
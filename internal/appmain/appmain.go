@@ -17,6 +17,7 @@ package appmain
 
 import (
 	"context"
+	"flag"
 	"net"
 	"net/http"
 	"os"
@@ -26,6 +27,7 @@ import (
 	"go.opencensus.io/stats/view"
 
 	"github.com/sirupsen/logrus"
+	"open-match.dev/open-match/internal/audit"
 	"open-match.dev/open-match/internal/config"
 	"open-match.dev/open-match/internal/logging"
 	"open-match.dev/open-match/internal/rpc"
@@ -40,14 +42,25 @@ var (
 )
 
 // RunApplication starts and runs the given application forever.  For use in
-// main functions to run the full application.
+// main functions to run the full application.  The configuration is located
+// and overridden by the -config-dir and -set flags, see config.Options.
 func RunApplication(serviceName string, bindService Bind) {
+	if len(os.Args) > 1 && os.Args[1] == ValidateConfigCommand {
+		os.Exit(runValidateConfig(os.Args[2:], os.Stdout))
+	}
+
+	var opts config.Options
+	fs := flag.NewFlagSet(serviceName, flag.ExitOnError)
+	opts.RegisterFlags(fs)
+	// ExitOnError exits on errors.
+	_ = fs.Parse(os.Args[1:])
+
 	c := make(chan os.Signal, 1)
 	// SIGTERM is signaled by k8s when it wants a pod to stop.
 	signal.Notify(c, syscall.SIGTERM, syscall.SIGINT)
 
 	readConfig := func() (config.View, error) {
-		return config.Read()
+		return config.ReadOptions(opts)
 	}
 
 	a, err := NewApplication(serviceName, bindService, readConfig, net.Listen)
@@ -70,6 +83,7 @@ type Bind func(p *Params, b *Bindings) error
 type Params struct {
 	config      config.View
 	serviceName string
+	audit       *audit.Logger
 }
 
 // Config provides the configuration for the application.
@@ -83,6 +97,12 @@ func (p *Params) ServiceName() string {
 	return p.serviceName
 }
 
+// Audit is the audit log of the application, which is nil, discarding events,
+// if audit.sink isn't configured.
+func (p *Params) Audit() *audit.Logger {
+	return p.audit
+}
+
 // Bindings allows applications to bind various functions to the running servers.
 type Bindings struct {
 	sp       *rpc.ServerParams
@@ -156,7 +176,11 @@ func NewApplication(serviceName string, bindService Bind, getCfg func() (config.
 			"error": err.Error(),
 		}).Fatalf("cannot read configuration.")
 	}
-	logging.ConfigureLogging(cfg)
+	if err = validateConfig(cfg); err != nil {
+		return nil, err
+	}
+	// The logging level and format apply as soon as they are changed.
+	unsubscribeLogging := config.Subscribe(cfg, "logging", logging.ConfigureLogging)
 	sp, err := rpc.NewServerParamsFromConfig(cfg, "api."+serviceName, listen)
 	if err != nil {
 		logger.WithFields(logrus.Fields{
@@ -172,6 +196,8 @@ func NewApplication(serviceName string, bindService Bind, getCfg func() (config.
 		a:  a,
 		sp: sp,
 	}
+	b.AddCloser(unsubscribeLogging)
+	b.RegisterViews(config.ChangesAppliedView)
 
 	err = telemetry.Setup(p, b)
 	if err != nil {
@@ -180,6 +206,14 @@ func NewApplication(serviceName string, bindService Bind, getCfg func() (config.
 		return nil, err
 	}
 
+	p.audit, err = audit.New(cfg, serviceName)
+	if err != nil {
+		surpressedErr := a.Stop() // Don't care about additional errors stopping.
+		_ = surpressedErr
+		return nil, err
+	}
+	b.AddCloserErr(p.audit.Close)
+
 	err = bindService(p, b)
 	if err != nil {
 		surpressedErr := a.Stop() // Don't care about additional errors stopping.
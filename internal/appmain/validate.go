@@ -0,0 +1,92 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package appmain
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/pkg/errors"
+	"open-match.dev/open-match/internal/config"
+)
+
+// ValidateConfigCommand is the subcommand of every Open Match server which
+// validates config files offline, and prints the effective configuration:
+//
+//	<server> validate-config [-defaults FILE] [OVERRIDE_FILE]
+const ValidateConfigCommand = "validate-config"
+
+// validateConfig logs the unknown and mistyped keys of the configuration, and
+// returns an error if there are any and the configuration is strict.
+func validateConfig(cfg config.View) error {
+	settings, ok := cfg.(config.Settings)
+	if !ok {
+		return nil
+	}
+
+	problems := config.Validate(settings)
+	for _, p := range problems {
+		logger.WithField("key", p.Key).Warningf("invalid configuration: %s", p.Message)
+	}
+	if len(problems) > 0 && cfg.GetBool(config.ConfigNameStrict) {
+		return errors.Errorf("configuration has %d invalid keys, and %s is set", len(problems), config.ConfigNameStrict)
+	}
+	return nil
+}
+
+// runValidateConfig runs the validate-config subcommand, returning its exit
+// code.
+func runValidateConfig(args []string, out io.Writer) int {
+	fs := flag.NewFlagSet(ValidateConfigCommand, flag.ContinueOnError)
+	fs.SetOutput(out)
+	defaultFile := fs.String("defaults", "", "Config file of the default values, matchmaker_config_default.yaml.")
+	fs.Usage = func() {
+		fmt.Fprintf(out, "Usage: %s [-defaults FILE] [OVERRIDE_FILE]\n", ValidateConfigCommand)
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() > 1 {
+		fs.Usage()
+		return 2
+	}
+
+	cfg, err := config.ReadFiles(*defaultFile, fs.Arg(0))
+	if err != nil {
+		fmt.Fprintln(out, err)
+		return 1
+	}
+
+	problems := config.Validate(cfg)
+	for _, p := range problems {
+		fmt.Fprintf(out, "invalid: %s\n", p)
+	}
+
+	fmt.Fprintln(out, "# Effective configuration")
+	for _, s := range config.Effective(cfg) {
+		if s.Default {
+			fmt.Fprintf(out, "%s: %v # default\n", s.Key, s.Value)
+		} else {
+			fmt.Fprintf(out, "%s: %v\n", s.Key, s.Value)
+		}
+	}
+
+	if len(problems) > 0 {
+		return 1
+	}
+	return 0
+}
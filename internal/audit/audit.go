@@ -0,0 +1,167 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package audit records the operations changing the state of tickets and
+// matches, so that disputes such as "why was I put in that match?" can be
+// settled.  Events are appended as JSON lines to the sink configured by
+// audit.sink, independently of the logging level and format.
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"open-match.dev/open-match/internal/config"
+	"open-match.dev/open-match/internal/rpc"
+	"open-match.dev/open-match/internal/tenant"
+)
+
+// ConfigNameSink is the destination of the audit log: stdout, or the path of
+// a file it is appended to.  The audit log is disabled if empty.
+const ConfigNameSink = "audit.sink"
+
+// Operations recorded in the audit log.
+const (
+	CreateTicket      = "CreateTicket"
+	DeleteTicket      = "DeleteTicket"
+	AssignTickets     = "AssignTickets"
+	AssignMatch       = "AssignMatch"
+	ReleaseTickets    = "ReleaseTickets"
+	ReleaseMatch      = "ReleaseMatch"
+	ReleaseAllTickets = "ReleaseAllTickets"
+	// MatchAccepted and MatchRejected are the decisions of the evaluator on
+	// the proposals of a synchronizer cycle.
+	MatchAccepted = "MatchAccepted"
+	MatchRejected = "MatchRejected"
+)
+
+var logger = logrus.WithFields(logrus.Fields{
+	"app":       "openmatch",
+	"component": "audit",
+})
+
+// Event is a line of the audit log.
+type Event struct {
+	Time      time.Time `json:"time"`
+	Service   string    `json:"service"`
+	Operation string    `json:"operation"`
+	// Caller is the authenticated identity of the caller, or else its
+	// address, as "identity:<name>" or "address:<ip>".  Empty for operations
+	// not made on behalf of a caller, such as evaluator decisions.
+	Caller    string   `json:"caller,omitempty"`
+	Tenant    string   `json:"tenant,omitempty"`
+	TicketIDs []string `json:"ticketIds,omitempty"`
+	MatchIDs  []string `json:"matchIds,omitempty"`
+	// Details are attributes specific to the operation, such as the
+	// connection of an assignment or the reason a match was rejected.
+	Details map[string]string `json:"details,omitempty"`
+	// Error is set if the operation failed, possibly after changing the
+	// state of some of the tickets.
+	Error string `json:"error,omitempty"`
+}
+
+// Logger appends events to the audit log.  A nil Logger discards them.
+type Logger struct {
+	service string
+
+	m     sync.Mutex
+	w     io.Writer
+	close func() error
+}
+
+// New returns the audit logger of the service, or nil if audit.sink isn't
+// configured.
+func New(cfg config.View, service string) (*Logger, error) {
+	switch sink := cfg.GetString(ConfigNameSink); sink {
+	case "":
+		return nil, nil
+	case "stdout":
+		return NewWriter(os.Stdout, service), nil
+	default:
+		f, err := os.OpenFile(sink, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+		if err != nil {
+			return nil, err
+		}
+		l := NewWriter(f, service)
+		l.close = f.Close
+		return l, nil
+	}
+}
+
+// NewWriter returns an audit logger of the service writing to w.
+func NewWriter(w io.Writer, service string) *Logger {
+	return &Logger{
+		service: service,
+		w:       w,
+	}
+}
+
+// Log appends the event to the audit log.  The time, caller and tenant of the
+// event are taken from the context unless set.  Failing to write is logged,
+// and doesn't fail the operation.
+func (l *Logger) Log(ctx context.Context, e Event) {
+	if l == nil {
+		return
+	}
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+	e.Time = e.Time.UTC()
+	e.Service = l.service
+	if e.Caller == "" {
+		e.Caller = rpc.CallerOf(ctx)
+	}
+	if e.Tenant == "" {
+		e.Tenant = tenant.FromContext(ctx)
+	}
+
+	b, err := json.Marshal(e)
+	if err != nil {
+		logger.WithError(err).Error("failed to marshal audit event")
+		return
+	}
+	b = append(b, '\n')
+
+	l.m.Lock()
+	defer l.m.Unlock()
+	if _, err = l.w.Write(b); err != nil {
+		logger.WithFields(logrus.Fields{
+			"error":     err.Error(),
+			"operation": e.Operation,
+		}).Error("failed to write audit event")
+	}
+}
+
+// Close closes the file of the audit log.
+func (l *Logger) Close() error {
+	if l == nil || l.close == nil {
+		return nil
+	}
+	l.m.Lock()
+	defer l.m.Unlock()
+	return l.close()
+}
+
+// ErrorOf returns the message of err, or "" if err is nil.
+func ErrorOf(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
@@ -0,0 +1,106 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"open-match.dev/open-match/internal/auth"
+	"open-match.dev/open-match/internal/tenant"
+)
+
+func TestLog(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l := NewWriter(buf, "backend")
+
+	ctx := tenant.NewContext(auth.NewContext(context.Background(), "director"), "game-a")
+	l.Log(ctx, Event{
+		Time:      time.Date(2020, 3, 1, 12, 0, 0, 0, time.UTC),
+		Operation: AssignMatch,
+		TicketIDs: []string{"t1", "t2"},
+		MatchIDs:  []string{"m1"},
+		Details:   map[string]string{"connection": "10.0.0.1:7777"},
+	})
+	l.Log(context.Background(), Event{
+		Time:      time.Date(2020, 3, 1, 12, 0, 1, 0, time.UTC),
+		Operation: ReleaseAllTickets,
+		Error:     ErrorOf(errors.New("redis is down")),
+	})
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	require.Len(t, lines, 2)
+	assert.JSONEq(t, `{
+		"time": "2020-03-01T12:00:00Z",
+		"service": "backend",
+		"operation": "AssignMatch",
+		"caller": "identity:director",
+		"tenant": "game-a",
+		"ticketIds": ["t1", "t2"],
+		"matchIds": ["m1"],
+		"details": {"connection": "10.0.0.1:7777"}
+	}`, lines[0])
+	assert.JSONEq(t, `{
+		"time": "2020-03-01T12:00:01Z",
+		"service": "backend",
+		"operation": "ReleaseAllTickets",
+		"error": "redis is down"
+	}`, lines[1])
+}
+
+func TestNewFileSink(t *testing.T) {
+	require := require.New(t)
+	dir, err := ioutil.TempDir("", "audit")
+	require.Nil(err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "audit.log")
+	require.Nil(ioutil.WriteFile(path, []byte("{}\n"), 0600))
+
+	cfg := viper.New()
+	cfg.Set(ConfigNameSink, path)
+	l, err := New(cfg, "frontend")
+	require.Nil(err)
+	require.NotNil(l)
+	l.Log(context.Background(), Event{Operation: CreateTicket, TicketIDs: []string{"t1"}})
+	require.Nil(l.Close())
+
+	// Events are appended to the existing log.
+	b, err := ioutil.ReadFile(path)
+	require.Nil(err)
+	lines := strings.Split(strings.TrimSuffix(string(b), "\n"), "\n")
+	require.Len(lines, 2)
+	assert.Equal(t, "{}", lines[0])
+	assert.Contains(t, lines[1], `"operation":"CreateTicket"`)
+}
+
+func TestDisabled(t *testing.T) {
+	l, err := New(viper.New(), "frontend")
+	assert.Nil(t, err)
+	assert.Nil(t, l)
+
+	// A nil logger discards events.
+	l.Log(context.Background(), Event{Operation: CreateTicket})
+	assert.Nil(t, l.Close())
+}
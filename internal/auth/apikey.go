@@ -0,0 +1,57 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"crypto/subtle"
+)
+
+// apiKeyAuthenticator accepts a static list of API keys, each belonging to an
+// identity.  An identity may have several keys, to rotate them.
+type apiKeyAuthenticator struct {
+	keys []apiKey
+}
+
+type apiKey struct {
+	identity string
+	key      []byte
+}
+
+func newAPIKeyAuthenticator(identityKeys map[string][]string) *apiKeyAuthenticator {
+	a := &apiKeyAuthenticator{}
+	for identity, keys := range identityKeys {
+		for _, key := range keys {
+			if key != "" {
+				a.keys = append(a.keys, apiKey{identity: identity, key: []byte(key)})
+			}
+		}
+	}
+	return a
+}
+
+func (a *apiKeyAuthenticator) Authenticate(token string) (string, error) {
+	// Every key is compared, so that the time taken doesn't tell which key
+	// matched.
+	identity := ""
+	for _, k := range a.keys {
+		if subtle.ConstantTimeCompare(k.key, []byte(token)) == 1 {
+			identity = k.identity
+		}
+	}
+	if identity == "" {
+		return "", errInvalidToken
+	}
+	return identity, nil
+}
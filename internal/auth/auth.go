@@ -0,0 +1,162 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package auth authenticates callers of a service by the bearer token of
+// their requests, either a JWT signed by a key of a locally configured JWKS
+// file, or a static API key.  The identity of the caller is set on the
+// context of the request.
+//
+// HTTP requests are authenticated by the same interceptors, as the HTTP proxy
+// forwards the Authorization header to the gRPC service.
+package auth
+
+import (
+	"context"
+	"strings"
+
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"open-match.dev/open-match/internal/config"
+)
+
+const (
+	// Config names under the prefix of a service.
+	configNameJWKSFile = "auth.jwksFile"
+	configNameIssuer   = "auth.issuer"
+	configNameAudience = "auth.audience"
+	// configNameAPIKeys maps each identity to the API keys it may use.
+	configNameAPIKeys = "auth.apiKeys"
+	// ConfigNameBindTicketOwner, under the prefix of the frontend, restricts
+	// tickets to the identity which created them.
+	ConfigNameBindTicketOwner = "auth.bindTicketOwner"
+	// ConfigNameMaxTicketsPerOwner, under the prefix of the frontend, limits
+	// how many tickets each identity may have at once.
+	ConfigNameMaxTicketsPerOwner = "auth.maxActiveTicketsPerOwner"
+
+	authorizationKey = "authorization"
+	bearerPrefix     = "bearer "
+)
+
+var (
+	logger = logrus.WithFields(logrus.Fields{
+		"app":       "openmatch",
+		"component": "auth",
+	})
+
+	errInvalidToken = status.Error(codes.Unauthenticated, "invalid bearer token")
+)
+
+// Authenticator returns the identity of the caller presenting the token.
+type Authenticator interface {
+	Authenticate(token string) (string, error)
+}
+
+// FromConfig returns the authenticator configured for the service of the
+// prefix, or nil if the service doesn't require authentication.
+func FromConfig(cfg config.View, prefix string) (Authenticator, error) {
+	var authenticators chain
+
+	if apiKeys := cfg.GetStringMapStringSlice(prefix + "." + configNameAPIKeys); len(apiKeys) > 0 {
+		authenticators = append(authenticators, newAPIKeyAuthenticator(apiKeys))
+	}
+
+	if jwksFile := cfg.GetString(prefix + "." + configNameJWKSFile); jwksFile != "" {
+		a, err := newJWTAuthenticatorFromFile(jwksFile, cfg.GetString(prefix+"."+configNameIssuer), cfg.GetString(prefix+"."+configNameAudience))
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot load %s.%s", prefix, configNameJWKSFile)
+		}
+		authenticators = append(authenticators, a)
+	}
+
+	if len(authenticators) == 0 {
+		return nil, nil
+	}
+	return authenticators, nil
+}
+
+// chain authenticates the token with the first authenticator accepting it.
+type chain []Authenticator
+
+func (c chain) Authenticate(token string) (string, error) {
+	err := errInvalidToken
+	for _, a := range c {
+		var identity string
+		identity, err = a.Authenticate(token)
+		if err == nil {
+			return identity, nil
+		}
+	}
+	return "", err
+}
+
+type contextKey struct{}
+
+// NewContext returns a context of the authenticated caller.
+func NewContext(ctx context.Context, identity string) context.Context {
+	return context.WithValue(ctx, contextKey{}, identity)
+}
+
+// FromContext returns the identity of the authenticated caller, or "" if the
+// service doesn't require authentication.
+func FromContext(ctx context.Context) string {
+	identity, _ := ctx.Value(contextKey{}).(string)
+	return identity
+}
+
+func authenticate(ctx context.Context, a Authenticator) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(authorizationKey)
+	if len(values) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "a bearer token is required")
+	}
+	if len(values[0]) <= len(bearerPrefix) || !strings.EqualFold(values[0][:len(bearerPrefix)], bearerPrefix) {
+		return nil, status.Error(codes.Unauthenticated, "authorization must be a bearer token")
+	}
+
+	identity, err := a.Authenticate(strings.TrimSpace(values[0][len(bearerPrefix):]))
+	if err != nil {
+		logger.WithError(err).Debug("failed to authenticate the caller")
+		return nil, err
+	}
+	return NewContext(ctx, identity), nil
+}
+
+// UnaryServerInterceptor authenticates the callers of the service.
+func UnaryServerInterceptor(a Authenticator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := authenticate(ctx, a)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor authenticates the callers of the service.
+func StreamServerInterceptor(a Authenticator) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authenticate(stream.Context(), a)
+		if err != nil {
+			return err
+		}
+		wrapped := grpc_middleware.WrapServerStream(stream)
+		wrapped.WrappedContext = ctx
+		return handler(srv, wrapped)
+	}
+}
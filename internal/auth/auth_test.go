@@ -0,0 +1,90 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestFromConfig(t *testing.T) {
+	cfg := viper.New()
+	a, err := FromConfig(cfg, "api.frontend")
+	require.Nil(t, err)
+	require.Nil(t, a)
+
+	cfg.Set("api.frontend.auth.jwksFile", "/does/not/exist")
+	_, err = FromConfig(cfg, "api.frontend")
+	require.NotNil(t, err)
+
+	cfg = viper.New()
+	cfg.Set("api.frontend.auth.apiKeys", map[string][]string{
+		"game-server": {"old-key", "new-key"},
+		"lobby":       {"lobby-key"},
+	})
+	a, err = FromConfig(cfg, "api.frontend")
+	require.Nil(t, err)
+
+	for token, want := range map[string]string{
+		"old-key":   "game-server",
+		"new-key":   "game-server",
+		"lobby-key": "lobby",
+	} {
+		identity, err := a.Authenticate(token)
+		require.Nil(t, err)
+		require.Equal(t, want, identity)
+	}
+	_, err = a.Authenticate("wrong-key")
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+	_, err = a.Authenticate("")
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	interceptor := UnaryServerInterceptor(newAPIKeyAuthenticator(map[string][]string{"lobby": {"key"}}))
+	var got string
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		got = FromContext(ctx)
+		return nil, nil
+	}
+
+	for authorization, wantCode := range map[string]codes.Code{
+		"":           codes.Unauthenticated,
+		"key":        codes.Unauthenticated,
+		"Basic key":  codes.Unauthenticated,
+		"Bearer bad": codes.Unauthenticated,
+		"Bearer key": codes.OK,
+		"bearer key": codes.OK,
+	} {
+		got = ""
+		md := metadata.MD{}
+		if authorization != "" {
+			md = metadata.Pairs("authorization", authorization)
+		}
+		ctx := metadata.NewIncomingContext(context.Background(), md)
+		_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{}, handler)
+		require.Equal(t, wantCode, status.Code(err), authorization)
+		if wantCode == codes.OK {
+			require.Equal(t, "lobby", got)
+		}
+	}
+}
@@ -0,0 +1,283 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	_ "crypto/sha256" // Registers SHA-256 for crypto.Hash.
+	_ "crypto/sha512" // Registers SHA-384 and SHA-512 for crypto.Hash.
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// jwtAuthenticator accepts JWTs signed by a key of a JSON Web Key Set, whose
+// subject is the identity of the caller.  Only asymmetric RS* and ES*
+// signatures are supported.
+type jwtAuthenticator struct {
+	keys     []*jwk
+	issuer   string
+	audience string
+	now      func() time.Time
+}
+
+// jwk is a JSON Web Key, as defined by RFC 7517.
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	// RSA public keys.
+	N string `json:"n"`
+	E string `json:"e"`
+	// EC public keys.
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+
+	publicKey crypto.PublicKey
+}
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+type jwtClaims struct {
+	Subject   string          `json:"sub"`
+	Issuer    string          `json:"iss"`
+	Audience  json.RawMessage `json:"aud"`
+	ExpiresAt *int64          `json:"exp"`
+	NotBefore *int64          `json:"nbf"`
+}
+
+var jwtAlgorithms = map[string]struct {
+	kty  string
+	hash crypto.Hash
+}{
+	"RS256": {"RSA", crypto.SHA256},
+	"RS384": {"RSA", crypto.SHA384},
+	"RS512": {"RSA", crypto.SHA512},
+	"ES256": {"EC", crypto.SHA256},
+	"ES384": {"EC", crypto.SHA384},
+	"ES512": {"EC", crypto.SHA512},
+}
+
+func newJWTAuthenticatorFromFile(jwksFile, issuer, audience string) (*jwtAuthenticator, error) {
+	data, err := ioutil.ReadFile(jwksFile)
+	if err != nil {
+		return nil, err
+	}
+	return newJWTAuthenticator(data, issuer, audience)
+}
+
+func newJWTAuthenticator(jwks []byte, issuer, audience string) (*jwtAuthenticator, error) {
+	set := struct {
+		Keys []*jwk `json:"keys"`
+	}{}
+	if err := json.Unmarshal(jwks, &set); err != nil {
+		return nil, errors.Wrap(err, "invalid JWKS")
+	}
+	if len(set.Keys) == 0 {
+		return nil, errors.New("JWKS has no keys")
+	}
+
+	for _, k := range set.Keys {
+		var err error
+		k.publicKey, err = k.parse()
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid key %q in JWKS", k.Kid)
+		}
+	}
+
+	return &jwtAuthenticator{
+		keys:     set.Keys,
+		issuer:   issuer,
+		audience: audience,
+		now:      time.Now,
+	}, nil
+}
+
+func (k *jwk) parse() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		if !e.IsInt64() || e.Int64() > 1<<31-1 {
+			return nil, errors.New("RSA exponent is too large")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		if !curve.IsOnCurve(x, y) {
+			return nil, errors.New("EC point is not on the curve")
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) == 0 {
+		return nil, errors.New("missing key parameter")
+	}
+	return new(big.Int).SetBytes(b), nil
+}
+
+func (a *jwtAuthenticator) Authenticate(token string) (string, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return "", errInvalidToken
+	}
+
+	var header jwtHeader
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return "", errInvalidToken
+	}
+	alg, ok := jwtAlgorithms[header.Alg]
+	if !ok {
+		return "", status.Errorf(codes.Unauthenticated, "unsupported JWT algorithm %q", header.Alg)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return "", errInvalidToken
+	}
+
+	digest := alg.hash.New()
+	digest.Write([]byte(parts[0] + "." + parts[1]))
+	hashed := digest.Sum(nil)
+
+	verified := false
+	for _, k := range a.keys {
+		if k.Kty != alg.kty || (header.Kid != "" && k.Kid != header.Kid) || (k.Alg != "" && k.Alg != header.Alg) {
+			continue
+		}
+		if verify(k.publicKey, alg.hash, hashed, signature) {
+			verified = true
+			break
+		}
+	}
+	if !verified {
+		return "", status.Error(codes.Unauthenticated, "invalid JWT signature")
+	}
+
+	var claims jwtClaims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return "", errInvalidToken
+	}
+	return a.validate(&claims)
+}
+
+func (a *jwtAuthenticator) validate(claims *jwtClaims) (string, error) {
+	now := a.now().Unix()
+	if claims.ExpiresAt == nil || now >= *claims.ExpiresAt {
+		return "", status.Error(codes.Unauthenticated, "JWT expired")
+	}
+	if claims.NotBefore != nil && now < *claims.NotBefore {
+		return "", status.Error(codes.Unauthenticated, "JWT not valid yet")
+	}
+	if a.issuer != "" && claims.Issuer != a.issuer {
+		return "", status.Errorf(codes.Unauthenticated, "JWT issuer %q is not trusted", claims.Issuer)
+	}
+	if a.audience != "" && !hasAudience(claims.Audience, a.audience) {
+		return "", status.Errorf(codes.Unauthenticated, "JWT is not intended for audience %q", a.audience)
+	}
+	if claims.Subject == "" {
+		return "", status.Error(codes.Unauthenticated, "JWT has no subject")
+	}
+	return claims.Subject, nil
+}
+
+// hasAudience returns whether the aud claim, either a string or an array of
+// strings, contains the audience.
+func hasAudience(raw json.RawMessage, audience string) bool {
+	var single string
+	if json.Unmarshal(raw, &single) == nil {
+		return single == audience
+	}
+	var multiple []string
+	if json.Unmarshal(raw, &multiple) == nil {
+		for _, aud := range multiple {
+			if aud == audience {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func verify(publicKey crypto.PublicKey, hash crypto.Hash, hashed, signature []byte) bool {
+	switch key := publicKey.(type) {
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(key, hash, hashed, signature) == nil
+	case *ecdsa.PublicKey:
+		size := (key.Curve.Params().BitSize + 7) / 8
+		if len(signature) != 2*size {
+			return false
+		}
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+		return ecdsa.Verify(key, hashed, r, s)
+	default:
+		return false
+	}
+}
+
+func decodeSegment(segment string, v interface{}) error {
+	b, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
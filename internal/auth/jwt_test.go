@@ -0,0 +1,138 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func encodeSegment(t *testing.T, v interface{}) string {
+	b, err := json.Marshal(v)
+	require.Nil(t, err)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func signRS256(t *testing.T, key *rsa.PrivateKey, kid string, claims map[string]interface{}) string {
+	signing := encodeSegment(t, map[string]string{"alg": "RS256", "kid": kid}) + "." + encodeSegment(t, claims)
+	hashed := crypto.SHA256.New()
+	hashed.Write([]byte(signing))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, hashed.Sum(nil))
+	require.Nil(t, err)
+	return signing + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+func signES256(t *testing.T, key *ecdsa.PrivateKey, kid string, claims map[string]interface{}) string {
+	signing := encodeSegment(t, map[string]string{"alg": "ES256", "kid": kid}) + "." + encodeSegment(t, claims)
+	hashed := crypto.SHA256.New()
+	hashed.Write([]byte(signing))
+	r, s, err := ecdsa.Sign(rand.Reader, key, hashed.Sum(nil))
+	require.Nil(t, err)
+	sig := make([]byte, 64)
+	rb, sb := r.Bytes(), s.Bytes()
+	copy(sig[32-len(rb):32], rb)
+	copy(sig[64-len(sb):], sb)
+	return signing + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+func TestJWTAuthenticator(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.Nil(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.Nil(t, err)
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.Nil(t, err)
+
+	b64 := func(i *big.Int) string { return base64.RawURLEncoding.EncodeToString(i.Bytes()) }
+	jwks := fmt.Sprintf(`{"keys": [
+		{"kty": "RSA", "kid": "rsa", "n": %q, "e": %q},
+		{"kty": "EC", "kid": "ec", "crv": "P-256", "x": %q, "y": %q}
+	]}`, b64(rsaKey.N), b64(big.NewInt(int64(rsaKey.E))), b64(ecKey.X), b64(ecKey.Y))
+
+	a, err := newJWTAuthenticator([]byte(jwks), "issuer", "open-match")
+	require.Nil(t, err)
+	now := time.Unix(1600000000, 0)
+	a.now = func() time.Time { return now }
+
+	claims := func(edit func(map[string]interface{})) map[string]interface{} {
+		c := map[string]interface{}{
+			"sub": "player",
+			"iss": "issuer",
+			"aud": []string{"other", "open-match"},
+			"exp": now.Add(time.Minute).Unix(),
+		}
+		if edit != nil {
+			edit(c)
+		}
+		return c
+	}
+
+	for _, tc := range []struct {
+		name  string
+		token string
+		valid bool
+	}{
+		{"RS256", signRS256(t, rsaKey, "rsa", claims(nil)), true},
+		{"ES256", signES256(t, ecKey, "ec", claims(nil)), true},
+		{"AudienceString", signRS256(t, rsaKey, "rsa", claims(func(c map[string]interface{}) { c["aud"] = "open-match" })), true},
+		{"NoKid", signRS256(t, rsaKey, "", claims(nil)), true},
+		{"WrongKid", signRS256(t, rsaKey, "ec", claims(nil)), false},
+		{"UnknownKey", signRS256(t, otherKey, "rsa", claims(nil)), false},
+		{"Expired", signRS256(t, rsaKey, "rsa", claims(func(c map[string]interface{}) { c["exp"] = now.Unix() })), false},
+		{"NoExpiry", signRS256(t, rsaKey, "rsa", claims(func(c map[string]interface{}) { delete(c, "exp") })), false},
+		{"NotYetValid", signRS256(t, rsaKey, "rsa", claims(func(c map[string]interface{}) { c["nbf"] = now.Add(time.Second).Unix() })), false},
+		{"WrongIssuer", signRS256(t, rsaKey, "rsa", claims(func(c map[string]interface{}) { c["iss"] = "other" })), false},
+		{"WrongAudience", signRS256(t, rsaKey, "rsa", claims(func(c map[string]interface{}) { c["aud"] = "other" })), false},
+		{"NoSubject", signRS256(t, rsaKey, "rsa", claims(func(c map[string]interface{}) { delete(c, "sub") })), false},
+		{"AlgNone", encodeSegment(t, map[string]string{"alg": "none"}) + "." + encodeSegment(t, claims(nil)) + ".", false},
+		{"Malformed", "not-a-jwt", false},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			identity, err := a.Authenticate(tc.token)
+			if tc.valid {
+				require.Nil(t, err)
+				require.Equal(t, "player", identity)
+			} else {
+				require.Equal(t, codes.Unauthenticated, status.Code(err))
+			}
+		})
+	}
+}
+
+func TestInvalidJWKS(t *testing.T) {
+	for _, jwks := range []string{
+		`not json`,
+		`{"keys": []}`,
+		`{"keys": [{"kty": "oct", "k": "c2VjcmV0"}]}`,
+		`{"keys": [{"kty": "EC", "crv": "P-256", "x": "AQ", "y": "AQ"}]}`,
+	} {
+		_, err := newJWTAuthenticator([]byte(jwks), "", "")
+		require.NotNil(t, err, jwks)
+	}
+}
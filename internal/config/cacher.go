@@ -15,6 +15,7 @@
 package config
 
 import (
+	"reflect"
 	"sync"
 	"time"
 )
@@ -100,6 +101,7 @@ type viewChangeDetector struct {
 	getInt64       map[string]int64
 	getFloat64     map[string]float64
 	getStringSlice map[string][]string
+	getStringMap   map[string]map[string][]string
 	getBool        map[string]bool
 	getDuration    map[string]time.Duration
 }
@@ -113,6 +115,7 @@ func newViewChangeDetector(cfg View) *viewChangeDetector {
 		getInt64:       make(map[string]int64),
 		getFloat64:     make(map[string]float64),
 		getStringSlice: make(map[string][]string),
+		getStringMap:   make(map[string]map[string][]string),
 		getBool:        make(map[string]bool),
 		getDuration:    make(map[string]time.Duration),
 	}
@@ -154,6 +157,12 @@ func (r *viewChangeDetector) GetStringSlice(k string) []string {
 	return v
 }
 
+func (r *viewChangeDetector) GetStringMapStringSlice(k string) map[string][]string {
+	v := r.cfg.GetStringMapStringSlice(k)
+	r.getStringMap[k] = v
+	return v
+}
+
 func (r *viewChangeDetector) GetBool(k string) bool {
 	v := r.cfg.GetBool(k)
 	r.getBool[k] = v
@@ -210,6 +219,12 @@ func (r *viewChangeDetector) hasChanges() bool {
 		}
 	}
 
+	for k, v := range r.getStringMap {
+		if !reflect.DeepEqual(r.cfg.GetStringMapStringSlice(k), v) {
+			return true
+		}
+	}
+
 	for k, v := range r.getBool {
 		if r.cfg.GetBool(k) != v {
 			return true
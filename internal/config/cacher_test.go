@@ -111,6 +111,16 @@ var getTests = []struct {
 			return len(cfg.GetStringSlice("foo"))
 		},
 	},
+	{
+		name:           "GetStringMapStringSlice",
+		firstValue:     map[string][]string{"a": {"1", "2"}},
+		firstExpected:  "2",
+		secondValue:    map[string][]string{"a": {"1", "4"}},
+		secondExpected: "4",
+		getValue: func(cfg View) interface{} {
+			return cfg.GetStringMapStringSlice("foo")["a"][1]
+		},
+	},
 	{
 		name:           "GetBool",
 		firstValue:     true,
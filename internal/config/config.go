@@ -24,49 +24,105 @@ import (
 )
 
 // Read sets default to a viper instance and read user config to override these defaults.
-func Read() (*viper.Viper, error) {
-	var err error
+// Changes to the user config are applied to the subscriptions of the returned configuration.
+func Read() (*Watched, error) {
+	return ReadOptions(Options{})
+}
+
+// ReadOptions is Read, locating the config files and overriding their values
+// as set by the options.  Missing config files are skipped if the config
+// directory, environment variables or -set flags are explicitly provided, so
+// that the configuration may be entirely set by them.
+func ReadOptions(o Options) (*Watched, error) {
+	optional := o.explicit()
+
 	// read configs from config/default/matchmaker_config_default.yaml
 	// matchmaker_config_default provides default values for all of the possible tunnable parameters in Open Match
 	dcfg := viper.New()
 	dcfg.SetConfigType("yaml")
-	dcfg.AddConfigPath(".")
-	// The config path needs to be the same as the volumeMount path defined via helm
-	dcfg.AddConfigPath("/app/config/default")
+	for _, path := range o.configPaths("default") {
+		dcfg.AddConfigPath(path)
+	}
 	dcfg.SetConfigName("matchmaker_config_default")
-	err = dcfg.ReadInConfig()
-	if err != nil {
+	if err := readInConfig(dcfg, optional); err != nil {
 		return nil, fmt.Errorf("fatal error reading default config file, desc: %s", err.Error())
 	}
 
 	// read configs from config/override/matchmaker_config_default.yaml
 	// matchmaker_config_override overrides default values specified in matchmaker_config_default
-	cfg := viper.New()
-
-	// set defaults for cfg using settings in dcfg
-	for k, v := range dcfg.AllSettings() {
-		cfg.SetDefault(k, v)
-	}
+	cfg := withDefaults(dcfg)
 
 	cfg.SetConfigType("yaml")
-	cfg.AddConfigPath(".")
-	// The config path needs to be the same as the volumeMountPath defined via helm
-	cfg.AddConfigPath("/app/config/override")
+	for _, path := range o.configPaths("override") {
+		cfg.AddConfigPath(path)
+	}
 	cfg.SetConfigName("matchmaker_config_override")
-	err = cfg.ReadInConfig()
-	if err != nil {
+	if err := readInConfig(cfg, optional); err != nil {
 		return nil, fmt.Errorf("fatal error reading override config file, desc: %s", err.Error())
 	}
 
+	bindEnv(cfg)
+	for k, v := range o.Set {
+		cfg.Set(k, v)
+	}
+
+	w := NewWatched(cfg)
+	if cfg.ConfigFileUsed() == "" {
+		// There is no config file to watch.
+		return w, nil
+	}
+
 	// Look for updates to the config; in Kubernetes, this is implemented using
 	// a ConfigMap that is written to the matchmaker_config_override.yaml file, which is
 	// what the Open Match components using Viper monitor for changes.
 	// More details about Open Match's use of Kubernetes ConfigMaps at:
 	// https://open-match.dev/open-match/issues/42
 	cfg.WatchConfig() // Watch and re-read config file.
-	// Write a log when the configuration changes.
+	// Write a log and apply the changes when the configuration changes.
 	cfg.OnConfigChange(func(event fsnotify.Event) {
 		log.Printf("Server configuration changed, operation: %v, filename: %s", event.Op, event.Name)
+		w.Reload()
 	})
+	return w, nil
+}
+
+// readInConfig reads the config file of cfg, skipping it if it is optional
+// and missing.
+func readInConfig(cfg *viper.Viper, optional bool) error {
+	err := cfg.ReadInConfig()
+	if _, ok := err.(viper.ConfigFileNotFoundError); ok && optional {
+		logger.Warningf("skipping config file: %s", err.Error())
+		return nil
+	}
+	return err
+}
+
+// ReadFiles reads the default and override config files, either of which may
+// be empty, the same way as Read but without watching them for changes.
+func ReadFiles(defaultFile, overrideFile string) (*viper.Viper, error) {
+	dcfg := viper.New()
+	if defaultFile != "" {
+		dcfg.SetConfigFile(defaultFile)
+		if err := dcfg.ReadInConfig(); err != nil {
+			return nil, fmt.Errorf("error reading default config file %s, desc: %s", defaultFile, err.Error())
+		}
+	}
+
+	cfg := withDefaults(dcfg)
+	if overrideFile != "" {
+		cfg.SetConfigFile(overrideFile)
+		if err := cfg.ReadInConfig(); err != nil {
+			return nil, fmt.Errorf("error reading override config file %s, desc: %s", overrideFile, err.Error())
+		}
+	}
 	return cfg, nil
 }
+
+// withDefaults returns a new config defaulting to the settings of dcfg.
+func withDefaults(dcfg *viper.Viper) *viper.Viper {
+	cfg := viper.New()
+	for k, v := range dcfg.AllSettings() {
+		cfg.SetDefault(k, v)
+	}
+	return cfg
+}
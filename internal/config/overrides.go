@@ -0,0 +1,138 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+const (
+	// EnvPrefix prefixes the environment variables overriding configuration
+	// keys.  The key redis.pool.maxActive is overridden by
+	// OM_REDIS_POOL_MAXACTIVE.
+	EnvPrefix = "OM"
+	// EnvConfigDir is the environment variable of the config directory, used
+	// when Options.Dir isn't set.
+	EnvConfigDir = "OM_CONFIG_DIR"
+)
+
+// Options locates the config files read by ReadOptions, and overrides their
+// values.  Values are taken, from highest to lowest precedence, from:
+//
+//  1. Set, the -set flags.
+//  2. Environment variables, such as OM_REDIS_HOSTNAME.
+//  3. matchmaker_config_override.yaml.
+//  4. matchmaker_config_default.yaml.
+//  5. The defaults of each setting in code.
+type Options struct {
+	// Dir contains the config files, either directly or in the default and
+	// override subdirectories.  If empty, EnvConfigDir is used, and then the
+	// working directory and the /app/config volume mounts of Kubernetes.
+	Dir string
+	// Set are the values of keys overriding every other source.
+	Set map[string]string
+}
+
+// RegisterFlags registers -config-dir and -set flags setting the options.
+func (o *Options) RegisterFlags(fs *flag.FlagSet) {
+	if o.Set == nil {
+		o.Set = make(map[string]string)
+	}
+	fs.StringVar(&o.Dir, "config-dir", o.Dir, "Directory of the config files, defaulting to $"+EnvConfigDir+".")
+	fs.Var(setFlag(o.Set), "set", "Configuration value as key=value, overriding config files and environment variables.  May be repeated.")
+}
+
+// setFlag is a repeated key=value flag.
+type setFlag map[string]string
+
+func (f setFlag) String() string {
+	var pairs []string
+	for k, v := range f {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+func (f setFlag) Set(value string) error {
+	i := strings.Index(value, "=")
+	if i <= 0 {
+		return fmt.Errorf("expected key=value, got %q", value)
+	}
+	f[value[:i]] = value[i+1:]
+	return nil
+}
+
+// dir returns the config directory of the options, or "" if there is none.
+func (o Options) dir() string {
+	if o.Dir != "" {
+		return o.Dir
+	}
+	return os.Getenv(EnvConfigDir)
+}
+
+// explicit returns true if the configuration is explicitly provided by the
+// options, the config directory or environment variables.
+func (o Options) explicit() bool {
+	if o.dir() != "" || len(o.Set) != 0 {
+		return true
+	}
+	for _, env := range os.Environ() {
+		if strings.HasPrefix(env, EnvPrefix+"_") {
+			return true
+		}
+	}
+	return false
+}
+
+// configPaths returns the directories searched for a config file, whose
+// volume mount in Kubernetes is /app/config/<subdir>.
+func (o Options) configPaths(subdir string) []string {
+	if dir := o.dir(); dir != "" {
+		return []string{dir, dir + "/" + subdir}
+	}
+	// The config path needs to be the same as the volumeMount path defined via helm
+	return []string{".", "/app/config/" + subdir}
+}
+
+// bindEnv overrides the keys of cfg with environment variables.
+func bindEnv(cfg *viper.Viper) {
+	cfg.SetEnvPrefix(EnvPrefix)
+	cfg.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	cfg.AutomaticEnv()
+
+	// AutomaticEnv only applies when reading a key, so keys known to be set
+	// in the environment are bound to be listed by AllKeys, and validated.
+	for _, key := range Schema {
+		if strings.Contains(key.Name, "*") {
+			continue
+		}
+		if _, ok := os.LookupEnv(envName(key.Name)); ok {
+			// BindEnv only fails without a key.
+			_ = cfg.BindEnv(key.Name)
+		}
+	}
+}
+
+// envName returns the environment variable overriding the key.
+func envName(key string) string {
+	return EnvPrefix + "_" + strings.ToUpper(strings.Replace(key, ".", "_", -1))
+}
@@ -0,0 +1,148 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReadOptionsPrecedence(t *testing.T) {
+	dir, err := ioutil.TempDir("", "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	for _, sub := range []string{"default", "override"} {
+		if err := os.Mkdir(filepath.Join(dir, sub), 0777); err != nil {
+			t.Fatal(err)
+		}
+	}
+	yaml := []byte(`
+redis.hostname: default
+redis.port: 1
+redis.user: default
+logging.level: default
+`)
+	if err := ioutil.WriteFile(filepath.Join(dir, "default", "matchmaker_config_default.yaml"), yaml, 0666); err != nil {
+		t.Fatal(err)
+	}
+	yaml = []byte(`
+redis.hostname: override
+redis.port: 2
+logging.level: override
+`)
+	if err := ioutil.WriteFile(filepath.Join(dir, "override", "matchmaker_config_override.yaml"), yaml, 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	os.Setenv("OM_REDIS_PORT", "3")
+	defer os.Unsetenv("OM_REDIS_PORT")
+	os.Setenv("OM_LOGGING_LEVEL", "env")
+	defer os.Unsetenv("OM_LOGGING_LEVEL")
+
+	cfg, err := ReadOptions(Options{
+		Dir: dir,
+		Set: map[string]string{"logging.level": "flag"},
+	})
+	if err != nil {
+		t.Fatalf("cannot load config, %s", err)
+	}
+
+	for key, want := range map[string]string{
+		"redis.user":     "default",
+		"redis.hostname": "override",
+		"redis.port":     "3",
+		"logging.level":  "flag",
+	} {
+		if got := cfg.GetString(key); got != want {
+			t.Errorf("cfg.GetString(%q) = %s, expected %s", key, got, want)
+		}
+	}
+	if got := cfg.GetInt("redis.port"); got != 3 {
+		t.Errorf("cfg.GetInt('redis.port') = %d, expected 3", got)
+	}
+	if problems := Validate(cfg); len(problems) != 0 {
+		t.Errorf("Validate(cfg) = %v, expected no problems", problems)
+	}
+}
+
+func TestReadOptionsWithoutFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	os.Setenv(EnvConfigDir, dir)
+	defer os.Unsetenv(EnvConfigDir)
+	os.Setenv("OM_API_FRONTEND_HOSTNAME", "localhost")
+	defer os.Unsetenv("OM_API_FRONTEND_HOSTNAME")
+
+	cfg, err := ReadOptions(Options{})
+	if err != nil {
+		t.Fatalf("cannot load config, %s", err)
+	}
+	if got := cfg.GetString("api.frontend.hostname"); got != "localhost" {
+		t.Errorf("cfg.GetString('api.frontend.hostname') = %s, expected localhost", got)
+	}
+	if cfg.IsSet("redis.hostname") {
+		t.Errorf("cfg.IsSet('redis.hostname') = true, expected false")
+	}
+}
+
+func TestReadOptionsRequiresFiles(t *testing.T) {
+	// Neither the working directory nor /app/config have config files.
+	if _, err := ReadOptions(Options{}); err == nil {
+		t.Errorf("ReadOptions(Options{}) succeeded, expected missing config files to fail")
+	}
+
+	cfg, err := ReadOptions(Options{Set: map[string]string{"api.frontend.hostname": "localhost"}})
+	if err != nil {
+		t.Fatalf("cannot load config, %s", err)
+	}
+	if got := cfg.GetString("api.frontend.hostname"); got != "localhost" {
+		t.Errorf("cfg.GetString('api.frontend.hostname') = %s, expected localhost", got)
+	}
+}
+
+func TestRegisterFlags(t *testing.T) {
+	var o Options
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(ioutil.Discard)
+	o.RegisterFlags(fs)
+
+	err := fs.Parse([]string{"-config-dir", "/tmp/om", "-set", "redis.hostname=localhost", "-set", "api.frontend.auth.issuer=a=b"})
+	if err != nil {
+		t.Fatalf("fs.Parse() = %v", err)
+	}
+	if o.Dir != "/tmp/om" {
+		t.Errorf("o.Dir = %s, expected /tmp/om", o.Dir)
+	}
+	if got := o.Set["redis.hostname"]; got != "localhost" {
+		t.Errorf("o.Set['redis.hostname'] = %s, expected localhost", got)
+	}
+	if got := o.Set["api.frontend.auth.issuer"]; got != "a=b" {
+		t.Errorf("o.Set['api.frontend.auth.issuer'] = %s, expected a=b", got)
+	}
+
+	if err := fs.Parse([]string{"-set", "novalue"}); err == nil {
+		t.Errorf("fs.Parse(-set novalue) = nil, expected an error")
+	}
+}
@@ -0,0 +1,149 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+// Type is the type of the value of a configuration key.
+type Type string
+
+// Types of configuration values.  Map keys accept any nested keys, such as
+// the identities of api.<service>.auth.apiKeys.
+const (
+	TypeString      Type = "string"
+	TypeInt         Type = "int"
+	TypeFloat       Type = "float"
+	TypeBool        Type = "bool"
+	TypeDuration    Type = "duration"
+	TypeStringSlice Type = "[]string"
+	TypeMap         Type = "map"
+)
+
+// Key describes a configuration key known to Open Match.
+type Key struct {
+	// Name is the dotted path of the key.  A "*" segment matches any name,
+	// such as the name of a service or tenant.
+	Name string
+	Type Type
+	// Default is the value used when the key isn't set, or "" if there is
+	// none.
+	Default     string
+	Description string
+}
+
+// ConfigNameStrict fails the startup of services whose configuration has
+// unknown or mistyped keys, which are otherwise only logged.
+const ConfigNameStrict = "config.strict"
+
+// Schema lists every configuration key known to Open Match.
+var Schema = []Key{
+	{ConfigNameStrict, TypeBool, "false", "Fail to start on unknown or mistyped configuration keys."},
+
+	{"logging.level", TypeString, "info", "Minimum level of logs: trace, debug, info, warning, error, fatal or panic."},
+	{"logging.format", TypeString, "text", "Format of logs: text, json or stackdriver."},
+	{"logging.rpc", TypeBool, "false", "Log every RPC, with its payload at the debug level."},
+
+	{"audit.sink", TypeString, "", "Destination of the audit log of state-changing operations: stdout, or the path of a file it is appended to.  Disabled if empty."},
+
+	{"backoff.initialInterval", TypeDuration, "", "Initial retry interval of retried calls."},
+	{"backoff.maxInterval", TypeDuration, "", "Maximum retry interval of retried calls."},
+	{"backoff.multiplier", TypeFloat, "", "Multiplier of the retry interval after each retry."},
+	{"backoff.randFactor", TypeFloat, "", "Randomization of the retry interval."},
+	{"backoff.maxElapsedTime", TypeDuration, "", "Maximum time spent retrying a call."},
+
+	{"api.*.hostname", TypeString, "", "Hostname of the service."},
+	{"api.*.grpcport", TypeInt, "", "gRPC port of the service."},
+	{"api.*.httpport", TypeInt, "", "HTTP port of the service."},
+	{"api.*.authorization", TypeMap, "", "Methods of the service each client certificate identity may call."},
+	{"api.*.auth.apiKeys", TypeMap, "", "API keys of each identity allowed to call the service."},
+	{"api.*.auth.jwksFile", TypeString, "", "JSON Web Key Set verifying the JWTs of callers of the service."},
+	{"api.*.auth.issuer", TypeString, "", "Required issuer of JWTs."},
+	{"api.*.auth.audience", TypeString, "", "Required audience of JWTs."},
+	{"api.*.auth.bindTicketOwner", TypeBool, "false", "Restrict tickets to the identity which created them."},
+	{"api.*.auth.maxActiveTicketsPerOwner", TypeInt, "0", "Maximum tickets of each identity, or 0 for no limit."},
+	{"api.*.rateLimit.requestsPerSecond", TypeFloat, "", "Calls per second of each caller to each method of the service."},
+	{"api.*.rateLimit.burst", TypeInt, "", "Calls each caller may make at once to each method of the service."},
+	{"api.*.rateLimit.methods", TypeMap, "", "Rate limits of single methods of the service."},
+	{"api.clientHealthCheck", TypeBool, "false", "Only call servers reporting serving through gRPC health checking."},
+	{"api.tls.certificateFile", TypeString, "", "Public certificate of servers."},
+	{"api.tls.privateKey", TypeString, "", "Private key of servers."},
+	{"api.tls.rootCertificateFile", TypeString, "", "Root CA certificate trusted by servers and clients."},
+	{"api.tls.trustedCertificatePath", TypeString, "", "Unused, kept for existing configurations."},
+	{"api.tls.requireClientCertificate", TypeBool, "false", "Require clients to present a certificate signed by the root CA."},
+	{"api.tls.clientCertificateFile", TypeString, "", "Certificate presented by clients to servers requiring a client certificate."},
+	{"api.tls.clientPrivateKey", TypeString, "", "Private key of the client certificate."},
+
+	{"redis.hostname", TypeString, "", "Hostname of Redis."},
+	{"redis.port", TypeInt, "", "Port of Redis."},
+	{"redis.user", TypeString, "", "User of Redis."},
+	{"redis.usePassword", TypeBool, "false", "Authenticate to Redis with the password of passwordPath."},
+	{"redis.passwordPath", TypeString, "", "File containing the password of Redis."},
+	{"redis.sentinelHostname", TypeString, "", "Hostname of Redis Sentinel, used instead of hostname when set."},
+	{"redis.sentinelPort", TypeInt, "", "Port of Redis Sentinel."},
+	{"redis.sentinelMaster", TypeString, "", "Master set of Redis Sentinel."},
+	{"redis.sentinelUsePassword", TypeBool, "false", "Authenticate to Redis Sentinel."},
+	{"redis.pool.maxIdle", TypeInt, "", "Maximum idle Redis connections."},
+	{"redis.pool.maxActive", TypeInt, "", "Maximum Redis connections, or 0 for no limit."},
+	{"redis.pool.idleTimeout", TypeDuration, "", "Time after which idle Redis connections are closed."},
+	{"redis.pool.healthCheckTimeout", TypeDuration, "", "Timeout of Redis health checks."},
+
+	{"telemetry.reportingPeriod", TypeDuration, "", "Period of metric reports."},
+	{"telemetry.traceSamplingFraction", TypeFloat, "", "Fraction of traced requests."},
+	{"telemetry.ticketTraceSamplingFraction", TypeFloat, "0", "Fraction of matched tickets traced, linked to the synchronizer cycle and match which consumed them."},
+	{"telemetry.zpages.enable", TypeBool, "false", "Serve zPages."},
+	{"telemetry.jaeger.enable", TypeBool, "false", "Export traces to Jaeger."},
+	{"telemetry.jaeger.agentEndpoint", TypeString, "", "Jaeger agent endpoint."},
+	{"telemetry.jaeger.collectorEndpoint", TypeString, "", "Jaeger collector endpoint."},
+	{"telemetry.prometheus.enable", TypeBool, "false", "Serve Prometheus metrics."},
+	{"telemetry.prometheus.endpoint", TypeString, "", "Path of the Prometheus metrics."},
+	{"telemetry.prometheus.serviceDiscovery", TypeBool, "false", "Annotate pods for Prometheus service discovery."},
+	{"telemetry.stackdriverMetrics.enable", TypeBool, "false", "Export metrics to Stackdriver."},
+	{"telemetry.stackdriverMetrics.gcpProjectId", TypeString, "", "GCP project of the Stackdriver metrics."},
+	{"telemetry.stackdriverMetrics.prefix", TypeString, "", "Prefix of the Stackdriver metrics."},
+	{"telemetry.opencensusAgent.enable", TypeBool, "false", "Export to the OpenCensus agent."},
+	{"telemetry.opencensusAgent.agentEndpoint", TypeString, "", "OpenCensus agent endpoint."},
+	{"telemetry.otlp.enable", TypeBool, "false", "Export traces and metrics to an OpenTelemetry collector."},
+	{"telemetry.otlp.endpoint", TypeString, "http://localhost:4318", "Base URL of the OTLP/HTTP receiver of the OpenTelemetry collector."},
+
+	{"registrationInterval", TypeDuration, "1s", "Time FetchMatches calls may join a synchronization cycle."},
+	{"proposalCollectionInterval", TypeDuration, "10s", "Time MMFs may run before they are cancelled."},
+	{"pendingReleaseTimeout", TypeDuration, "", "Time after which tickets returned by FetchMatches become active again."},
+	{"assignedDeleteTimeout", TypeDuration, "", "Time after which assigned tickets are deleted."},
+	{"ticketLifecycleTimeout", TypeDuration, "24h", "Time the lifecycle timestamps of tickets are kept after their latest event."},
+	{"matchRecordTimeout", TypeDuration, "", "Time match records are kept, defaulting to pendingReleaseTimeout."},
+	{"queryPageSize", TypeInt, "1000", "Maximum tickets of each QueryTickets response."},
+	{"queryWaitTimePriority", TypeFloat, "1", "Priority tickets gain for every second they wait."},
+	{"evaluatorChain", TypeStringSlice, "[evaluator]", "Names of the evaluators proposals pass through, in order."},
+
+	{"backend.shardSeparator", TypeString, "", "Separator of the synchronizer shard in the names of profiles."},
+	{"backend.readyCheckPollInterval", TypeDuration, "50ms", "How often the backend reads the matches awaiting their ready check."},
+	{"synchronizer.leaderElection.enabled", TypeBool, "false", "Elect a leader among synchronizer replicas."},
+	{"synchronizer.leaderElection.leaseDuration", TypeDuration, "10s", "Duration of the leader lease."},
+	{"synchronizer.leaderElection.advertiseAddress", TypeString, "", "Address of this synchronizer advertised to backends."},
+	{"synchronizer.cycleHistorySize", TypeInt, "20", "Number of latest synchronization cycles shown, and the tickets each consumed.  Negative values show none."},
+	{"synchronizer.earlyExit", TypeBool, "false", "Close the registration window once every call has sent its proposals."},
+	{"synchronizer.adaptiveWindows.enabled", TypeBool, "false", "Size the synchronization windows from MMF latencies."},
+	{"synchronizer.adaptiveWindows.minRegistrationInterval", TypeDuration, "100ms", "Minimum adaptive registration window."},
+	{"synchronizer.adaptiveWindows.minProposalCollectionInterval", TypeDuration, "100ms", "Minimum adaptive proposal window."},
+	{"synchronizer.adaptiveWindows.maxProposalCollectionInterval", TypeDuration, "", "Maximum adaptive proposal window, defaulting to 4 times proposalCollectionInterval."},
+
+	{"defaultEvaluator.strategy", TypeString, "greedy", "Strategy of the default evaluator."},
+	{"defaultEvaluator.maxExactSearchSize", TypeInt, "20", "Maximum matches searched exactly by the optimal strategy."},
+	{"defaultEvaluator.waitTimeBoost", TypeFloat, "0", "Score matches gain for every second their tickets waited."},
+
+	{"wasmMatchFunction.maxMemoryBytes", TypeInt, "67108864", "Maximum memory of WebAssembly match functions."},
+	{"wasmMatchFunction.maxExecutionTime", TypeDuration, "10s", "Maximum run time of WebAssembly match functions."},
+
+	{"tenants.*.maxActiveTickets", TypeInt, "", "Maximum tickets in the pool of the tenant, or 0 for no limit.  Defaults to tenantDefaults.maxActiveTickets."},
+	{"tenantDefaults.maxActiveTickets", TypeInt, "10000", "Maximum tickets in the pool of tenants without their own limit, or 0 for no limit.  The default tenant has no limit."},
+}
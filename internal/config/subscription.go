@@ -0,0 +1,130 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"context"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+var (
+	logger = logrus.WithFields(logrus.Fields{
+		"app":       "openmatch",
+		"component": "config",
+	})
+
+	subscriptionTag = tag.MustNewKey("subscription")
+	changesApplied  = stats.Int64("open-match.dev/config/changes_applied", "Configuration changes applied", stats.UnitDimensionless)
+
+	// ChangesAppliedView counts the configuration changes applied by each
+	// subscription.
+	ChangesAppliedView = &view.View{
+		Measure:     changesApplied,
+		Name:        "open-match.dev/config/changes_applied",
+		Description: "Configuration changes applied",
+		Aggregation: view.Count(),
+		TagKeys:     []tag.Key{subscriptionTag},
+	}
+)
+
+// Subscriber is a View which can change, and applies its changes to
+// subscriptions.
+type Subscriber interface {
+	View
+	Subscribe(name string, apply func(View)) (unsubscribe func())
+}
+
+// Subscribe calls apply with the configuration, and again each time any of
+// the values read by apply change.  apply MUST read the configuration from the
+// provided View.  The returned function cancels the subscription.
+func Subscribe(cfg View, name string, apply func(View)) (unsubscribe func()) {
+	if s, ok := cfg.(Subscriber); ok {
+		return s.Subscribe(name, apply)
+	}
+	// The configuration never changes.
+	apply(cfg)
+	return func() {}
+}
+
+// Watched is a configuration whose changes, as the configuration file is
+// re-read, are applied to its subscriptions.
+type Watched struct {
+	*viper.Viper
+
+	m             sync.Mutex
+	subscriptions map[*subscription]struct{}
+}
+
+type subscription struct {
+	name  string
+	apply func(View)
+	r     *viewChangeDetector
+}
+
+// NewWatched returns a Watched configuration of v.  Changes made to v are
+// applied when Reload is called.
+func NewWatched(v *viper.Viper) *Watched {
+	return &Watched{
+		Viper:         v,
+		subscriptions: make(map[*subscription]struct{}),
+	}
+}
+
+// Subscribe implements Subscriber.
+func (w *Watched) Subscribe(name string, apply func(View)) func() {
+	s := &subscription{
+		name:  name,
+		apply: apply,
+		r:     newViewChangeDetector(w.Viper),
+	}
+
+	w.m.Lock()
+	defer w.m.Unlock()
+	s.apply(s.r)
+	w.subscriptions[s] = struct{}{}
+
+	return func() {
+		w.m.Lock()
+		defer w.m.Unlock()
+		delete(w.subscriptions, s)
+	}
+}
+
+// Reload applies the changed configuration to the subscriptions which read
+// any of the changed values.
+func (w *Watched) Reload() {
+	w.m.Lock()
+	defer w.m.Unlock()
+
+	for s := range w.subscriptions {
+		if !s.r.hasChanges() {
+			continue
+		}
+		s.r = newViewChangeDetector(w.Viper)
+		s.apply(s.r)
+
+		logger.WithField("subscription", s.name).Info("applied configuration change")
+		ctx, err := tag.New(context.Background(), tag.Insert(subscriptionTag, s.name))
+		if err == nil {
+			stats.Record(ctx, changesApplied.M(1))
+		}
+	}
+}
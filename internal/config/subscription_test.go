@@ -0,0 +1,85 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestSubscribeUnwatched(t *testing.T) {
+	v := viper.New()
+	v.Set("foo", "bar")
+
+	var got []string
+	unsubscribe := Subscribe(v, "test", func(cfg View) {
+		got = append(got, cfg.GetString("foo"))
+	})
+	defer unsubscribe()
+
+	if len(got) != 1 || got[0] != "bar" {
+		t.Errorf("applied %v, expected [bar]", got)
+	}
+}
+
+func TestSubscribeWatched(t *testing.T) {
+	v := viper.New()
+	v.Set("foo", "bar")
+	v.Set("other", "a")
+	w := NewWatched(v)
+
+	var got []string
+	unsubscribe := Subscribe(w, "test", func(cfg View) {
+		got = append(got, cfg.GetString("foo"))
+	})
+
+	w.Reload()
+	if len(got) != 1 {
+		t.Fatalf("applied %v without changes, expected [bar]", got)
+	}
+
+	// Changes to values the subscription didn't read are ignored.
+	v.Set("other", "b")
+	w.Reload()
+	if len(got) != 1 {
+		t.Fatalf("applied %v after an unrelated change, expected [bar]", got)
+	}
+
+	v.Set("foo", "baz")
+	w.Reload()
+	if len(got) != 2 || got[1] != "baz" {
+		t.Fatalf("applied %v, expected [bar baz]", got)
+	}
+
+	unsubscribe()
+	v.Set("foo", "qux")
+	w.Reload()
+	if len(got) != 2 {
+		t.Errorf("applied %v after unsubscribing, expected [bar baz]", got)
+	}
+}
+
+func TestSubFromWatched(t *testing.T) {
+	v := viper.New()
+	v.Set("a.b", "a.b")
+	av := Sub(NewWatched(v), "a")
+	if av == nil {
+		t.Fatalf("Sub(%v, 'a') => %v", v, av)
+	}
+	if av.GetString("b") != "a.b" {
+		t.Errorf("av.GetString('b') = %s, expected 'a.b'", av.GetString("b"))
+	}
+}
@@ -0,0 +1,208 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cast"
+)
+
+// maxSuggestionDistance is the largest edit distance between an unknown key
+// and a known key suggested in its place.
+const maxSuggestionDistance = 3
+
+// Settings is a configuration whose keys can be listed, such as *viper.Viper.
+type Settings interface {
+	AllKeys() []string
+	Get(string) interface{}
+}
+
+// Problem is an unknown or mistyped key of a configuration.
+type Problem struct {
+	Key     string
+	Message string
+}
+
+func (p Problem) String() string {
+	return p.Key + ": " + p.Message
+}
+
+// Setting is the effective value of a configuration key.
+type Setting struct {
+	Key   string
+	Value interface{}
+	// Default is true if the key isn't set, and Value is its default.
+	Default bool
+}
+
+// Validate returns the problems of the keys set in the configuration, sorted
+// by key.
+func Validate(cfg Settings) []Problem {
+	var problems []Problem
+	for _, name := range sortedKeys(cfg) {
+		key := lookupKey(name)
+		if key == nil {
+			message := "unknown key"
+			if suggestion := suggestKey(name); suggestion != "" {
+				message += fmt.Sprintf(", did you mean %s?", suggestion)
+			}
+			problems = append(problems, Problem{Key: name, Message: message})
+			continue
+		}
+		if err := checkType(key.Type, cfg.Get(name)); err != nil {
+			problems = append(problems, Problem{Key: name, Message: fmt.Sprintf("expected type %s: %v", key.Type, err)})
+		}
+	}
+	return problems
+}
+
+// Effective returns the keys set in the configuration, and the default of
+// every other known key which has one, sorted by key.
+func Effective(cfg Settings) []Setting {
+	set := make(map[string]bool)
+	var settings []Setting
+	for _, name := range cfg.AllKeys() {
+		set[name] = true
+		settings = append(settings, Setting{Key: name, Value: cfg.Get(name)})
+	}
+	for _, key := range Schema {
+		name := strings.ToLower(key.Name)
+		if key.Default == "" || strings.Contains(name, "*") || set[name] {
+			continue
+		}
+		settings = append(settings, Setting{Key: name, Value: key.Default, Default: true})
+	}
+	sort.Slice(settings, func(i, j int) bool {
+		return settings[i].Key < settings[j].Key
+	})
+	return settings
+}
+
+func sortedKeys(cfg Settings) []string {
+	keys := cfg.AllKeys()
+	sort.Strings(keys)
+	return keys
+}
+
+// lookupKey returns the schema of the key, whose name is lowercased as by
+// viper, or nil if it isn't known.
+func lookupKey(name string) *Key {
+	segments := strings.Split(name, ".")
+	for i := range Schema {
+		pattern := strings.Split(strings.ToLower(Schema[i].Name), ".")
+		if Schema[i].Type == TypeMap {
+			// Maps accept any nested key.
+			if len(segments) >= len(pattern) && matchSegments(pattern, segments[:len(pattern)]) {
+				return &Schema[i]
+			}
+			continue
+		}
+		if len(segments) == len(pattern) && matchSegments(pattern, segments) {
+			return &Schema[i]
+		}
+	}
+	return nil
+}
+
+func matchSegments(pattern, segments []string) bool {
+	for i := range pattern {
+		if pattern[i] != "*" && pattern[i] != segments[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// suggestKey returns the known key closest to the unknown key, or "" if none
+// is close enough.
+func suggestKey(name string) string {
+	segments := strings.Split(name, ".")
+	best, bestDistance := "", maxSuggestionDistance+1
+	for _, key := range Schema {
+		pattern := strings.Split(strings.ToLower(key.Name), ".")
+		if len(pattern) > len(segments) || (len(pattern) < len(segments) && key.Type != TypeMap) {
+			continue
+		}
+		// Fill in the wildcards from the unknown key.
+		candidate := make([]string, len(pattern))
+		for i := range pattern {
+			candidate[i] = pattern[i]
+			if pattern[i] == "*" {
+				candidate[i] = segments[i]
+			}
+		}
+		known := strings.Join(candidate, ".")
+		if d := editDistance(strings.Join(segments[:len(pattern)], "."), known); d < bestDistance {
+			best, bestDistance = key.Name, d
+		}
+	}
+	return best
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
+
+func min(values ...int) int {
+	m := values[0]
+	for _, v := range values[1:] {
+		if v < m {
+			m = v
+		}
+	}
+	return m
+}
+
+func checkType(t Type, value interface{}) error {
+	if value == nil {
+		return nil
+	}
+	var err error
+	switch t {
+	case TypeString:
+		_, err = cast.ToStringE(value)
+	case TypeInt:
+		_, err = cast.ToIntE(value)
+	case TypeFloat:
+		_, err = cast.ToFloat64E(value)
+	case TypeBool:
+		_, err = cast.ToBoolE(value)
+	case TypeDuration:
+		_, err = cast.ToDurationE(value)
+	case TypeStringSlice:
+		_, err = cast.ToStringSliceE(value)
+	}
+	return err
+}
@@ -0,0 +1,104 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestValidate(t *testing.T) {
+	v := viper.New()
+	v.Set("api.frontend.grpcport", "50504")
+	v.Set("api.frontend.auth.apiKeys", map[string][]string{"lobby": {"secret"}})
+	v.Set("api.frontend.rateLimit.methods.CreateTicket.burst", 1)
+	v.Set("tenants.a.maxActiveTickets", 10)
+	v.Set("telemetry.traceSamplingFraction", "0.01")
+	v.Set("redis.pool.maxActiv", 10)
+	v.Set("queryPageSize", "many")
+	v.Set("unknown.key", true)
+
+	got := Validate(v)
+	want := []string{
+		"querypagesize: expected type int",
+		"redis.pool.maxactiv: unknown key, did you mean redis.pool.maxActive?",
+		"unknown.key: unknown key",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Validate() = %v, expected %v", got, want)
+	}
+	for i := range want {
+		if !strings.HasPrefix(got[i].String(), want[i]) {
+			t.Errorf("Validate()[%d] = %s, expected %s", i, got[i], want[i])
+		}
+	}
+}
+
+func TestEffective(t *testing.T) {
+	v := viper.New()
+	v.Set("queryPageSize", 10)
+
+	settings := make(map[string]Setting)
+	for _, s := range Effective(v) {
+		settings[s.Key] = s
+	}
+
+	if s := settings["querypagesize"]; s.Default || s.Value != 10 {
+		t.Errorf("querypagesize = %+v, expected the set value 10", s)
+	}
+	if s := settings["registrationinterval"]; !s.Default || s.Value != "1s" {
+		t.Errorf("registrationinterval = %+v, expected the default 1s", s)
+	}
+	if _, ok := settings["tenants.*.maxactivetickets"]; ok {
+		t.Error("keys of any name shouldn't have effective defaults")
+	}
+}
+
+func TestReadFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	defaultFile := filepath.Join(dir, "default.yaml")
+	overrideFile := filepath.Join(dir, "override.yaml")
+	if err := ioutil.WriteFile(defaultFile, []byte("queryPageSize: 10\nlogging:\n  level: info\n"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(overrideFile, []byte("logging:\n  level: debug\n"), 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := ReadFiles(defaultFile, overrideFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.GetInt("queryPageSize") != 10 {
+		t.Errorf("cfg.GetInt('queryPageSize') = %d, expected 10", cfg.GetInt("queryPageSize"))
+	}
+	if cfg.GetString("logging.level") != "debug" {
+		t.Errorf("cfg.GetString('logging.level') = %s, expected 'debug'", cfg.GetString("logging.level"))
+	}
+
+	if _, err := ReadFiles("", filepath.Join(dir, "missing.yaml")); err == nil {
+		t.Error("ReadFiles() of a missing file succeeded")
+	}
+}
@@ -29,6 +29,7 @@ type View interface {
 	GetInt64(string) int64
 	GetFloat64(string) float64
 	GetStringSlice(string) []string
+	GetStringMapStringSlice(string) map[string][]string
 	GetBool(string) bool
 	GetDuration(string) time.Duration
 }
@@ -45,5 +46,8 @@ func Sub(v View, key string) View {
 	if ok {
 		return vcfg.Sub(key)
 	}
+	if w, ok := v.(*Watched); ok {
+		return w.Viper.Sub(key)
+	}
 	return nil
 }
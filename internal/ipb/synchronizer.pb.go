@@ -27,10 +27,14 @@ const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
 type SynchronizeRequest struct {
 	// A match returned by an mmf.
-	Proposal             *pb.Match `protobuf:"bytes,1,opt,name=proposal,proto3" json:"proposal,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
-	XXX_unrecognized     []byte    `json:"-"`
-	XXX_sizecache        int32     `json:"-"`
+	Proposal *pb.Match `protobuf:"bytes,1,opt,name=proposal,proto3" json:"proposal,omitempty"`
+	// The shard whose cycle the call joins.  Calls in different shards run
+	// independent cycles.  Only read from the first request of a stream, which
+	// is sent before waiting for start_mmfs.
+	Shard                string   `protobuf:"bytes,2,opt,name=shard,proto3" json:"shard,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *SynchronizeRequest) Reset()         { *m = SynchronizeRequest{} }
@@ -65,6 +69,13 @@ func (m *SynchronizeRequest) GetProposal() *pb.Match {
 	return nil
 }
 
+func (m *SynchronizeRequest) GetShard() string {
+	if m != nil {
+		return m.Shard
+	}
+	return ""
+}
+
 type SynchronizeResponse struct {
 	// Instructs the backend call that it can start running the mmfs.
 	StartMmfs bool `protobuf:"varint,1,opt,name=start_mmfs,json=startMmfs,proto3" json:"start_mmfs,omitempty"`
@@ -73,10 +84,13 @@ type SynchronizeResponse struct {
 	CancelMmfs bool `protobuf:"varint,2,opt,name=cancel_mmfs,json=cancelMmfs,proto3" json:"cancel_mmfs,omitempty"`
 	// A match ID returned by the evaluator and should be returned to the FetchMatches
 	// caller.
-	MatchId              string   `protobuf:"bytes,4,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	MatchId string `protobuf:"bytes,4,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	// A match rejected by the evaluator, which is reported to the FetchMatches
+	// caller if requested.
+	RejectedMatch        *pb.RejectedMatch `protobuf:"bytes,5,opt,name=rejected_match,json=rejectedMatch,proto3" json:"rejected_match,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *SynchronizeResponse) Reset()         { *m = SynchronizeResponse{} }
@@ -125,6 +139,13 @@ func (m *SynchronizeResponse) GetMatchId() string {
 	return ""
 }
 
+func (m *SynchronizeResponse) GetRejectedMatch() *pb.RejectedMatch {
+	if m != nil {
+		return m.RejectedMatch
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*SynchronizeRequest)(nil), "openmatch.internal.SynchronizeRequest")
 	proto.RegisterType((*SynchronizeResponse)(nil), "openmatch.internal.SynchronizeResponse")
@@ -133,24 +154,26 @@ func init() {
 func init() { proto.RegisterFile("internal/api/synchronizer.proto", fileDescriptor_35ff6b85fea1c4b7) }
 
 var fileDescriptor_35ff6b85fea1c4b7 = []byte{
-	// 263 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x91, 0x4f, 0x4b, 0xc3, 0x40,
-	0x10, 0xc5, 0x89, 0x16, 0x4d, 0x27, 0x1e, 0xca, 0x7a, 0xa9, 0x05, 0x69, 0xe9, 0xa1, 0xe6, 0xa0,
-	0x1b, 0xa9, 0xdf, 0xa0, 0x37, 0x85, 0x5e, 0xe2, 0xcd, 0x4b, 0xd9, 0x24, 0x53, 0xbb, 0x90, 0xfd,
-	0xe3, 0xce, 0x5a, 0xd0, 0x4f, 0x2f, 0xd9, 0xc5, 0xa6, 0xd2, 0x83, 0x97, 0x85, 0x37, 0xf3, 0xdb,
-	0x37, 0xcc, 0x1b, 0x98, 0x4a, 0xed, 0xd1, 0x69, 0xd1, 0x16, 0xc2, 0xca, 0x82, 0xbe, 0x74, 0xbd,
-	0x73, 0x46, 0xcb, 0x6f, 0x74, 0xdc, 0x3a, 0xe3, 0x0d, 0x63, 0xc6, 0xa2, 0x56, 0xc2, 0xd7, 0x3b,
-	0xfe, 0x8b, 0x4e, 0x58, 0xc7, 0x2a, 0x24, 0x12, 0xef, 0x48, 0x91, 0x9b, 0xaf, 0x80, 0xbd, 0xf6,
-	0xbf, 0x4b, 0xfc, 0xf8, 0x44, 0xf2, 0xec, 0x1e, 0x52, 0xeb, 0x8c, 0x35, 0x24, 0xda, 0x71, 0x32,
-	0x4b, 0xf2, 0x6c, 0x39, 0xe2, 0xbd, 0xe1, 0xba, 0x7b, 0xcb, 0x03, 0x31, 0xdf, 0xc3, 0xf5, 0x1f,
-	0x0f, 0xb2, 0x46, 0x13, 0xb2, 0x5b, 0x00, 0xf2, 0xc2, 0xf9, 0x8d, 0x52, 0x5b, 0x0a, 0x36, 0x69,
-	0x39, 0x0c, 0x95, 0xb5, 0xda, 0x12, 0x9b, 0x42, 0x56, 0x0b, 0x5d, 0x63, 0x1b, 0xfb, 0x67, 0xa1,
-	0x0f, 0xb1, 0x14, 0x80, 0x1b, 0x48, 0xc3, 0xbc, 0x8d, 0x6c, 0xc6, 0x83, 0x59, 0x92, 0x0f, 0xcb,
-	0xcb, 0xa0, 0x9f, 0x9b, 0x97, 0x41, 0x7a, 0x3e, 0x1a, 0x2c, 0x1d, 0x5c, 0x1d, 0xcd, 0x75, 0xac,
-	0x82, 0xec, 0x48, 0xb3, 0x05, 0x3f, 0xcd, 0x80, 0x9f, 0x2e, 0x3b, 0xb9, 0xfb, 0x97, 0x8b, 0x0b,
-	0xe5, 0xc9, 0x63, 0xb2, 0xca, 0xdf, 0x16, 0x1d, 0xfd, 0x10, 0xf1, 0x06, 0xf7, 0x45, 0x2f, 0x8b,
-	0xc3, 0x51, 0xa4, 0xad, 0xaa, 0x8b, 0x10, 0xf0, 0xd3, 0x4f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x84,
-	0xba, 0xf6, 0x41, 0xab, 0x01, 0x00, 0x00,
+	// 303 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x91, 0xb1, 0x4e, 0xfb, 0x30,
+	0x10, 0xc6, 0xe5, 0xfe, 0xdb, 0x3f, 0xe9, 0x15, 0x50, 0x65, 0x18, 0x42, 0x25, 0xd4, 0xaa, 0x43,
+	0xc9, 0x00, 0x09, 0x2a, 0x0f, 0x80, 0xc4, 0x06, 0x52, 0x97, 0xb0, 0x20, 0x96, 0xca, 0x4d, 0xae,
+	0x34, 0x28, 0xb1, 0x8d, 0xcf, 0x20, 0xc1, 0x8b, 0xf1, 0x7a, 0x28, 0x36, 0x34, 0x41, 0x1d, 0x58,
+	0x2c, 0x7d, 0x77, 0x3f, 0x9f, 0x3f, 0x7f, 0x07, 0xe3, 0x42, 0x5a, 0x34, 0x52, 0x94, 0x89, 0xd0,
+	0x45, 0x42, 0xef, 0x32, 0xdb, 0x18, 0x25, 0x8b, 0x0f, 0x34, 0xb1, 0x36, 0xca, 0x2a, 0xce, 0x95,
+	0x46, 0x59, 0x09, 0x9b, 0x6d, 0xe2, 0x1f, 0x74, 0xc4, 0x6b, 0xb6, 0x42, 0x22, 0xf1, 0x84, 0xe4,
+	0xb9, 0xe9, 0x03, 0xf0, 0xfb, 0xe6, 0x76, 0x8a, 0x2f, 0xaf, 0x48, 0x96, 0x9f, 0x43, 0xa0, 0x8d,
+	0xd2, 0x8a, 0x44, 0x19, 0xb2, 0x09, 0x8b, 0x06, 0xf3, 0x61, 0xdc, 0x0c, 0x5c, 0xd4, 0x67, 0xba,
+	0x25, 0xf8, 0x31, 0xf4, 0x68, 0x23, 0x4c, 0x1e, 0x76, 0x26, 0x2c, 0xea, 0xa7, 0x5e, 0x4c, 0x3f,
+	0x19, 0x1c, 0xfd, 0x1a, 0x4d, 0x5a, 0x49, 0x42, 0x7e, 0x0a, 0x40, 0x56, 0x18, 0xbb, 0xac, 0xaa,
+	0x35, 0xb9, 0xe9, 0x41, 0xda, 0x77, 0x95, 0x45, 0xb5, 0x26, 0x3e, 0x86, 0x41, 0x26, 0x64, 0x86,
+	0xa5, 0xef, 0x77, 0x5c, 0x1f, 0x7c, 0xc9, 0x01, 0x27, 0x10, 0x38, 0x1b, 0xcb, 0x22, 0x0f, 0xbb,
+	0xee, 0xc1, 0x3d, 0xa7, 0x6f, 0x73, 0x7e, 0x0d, 0x87, 0x06, 0x9f, 0x31, 0xb3, 0x98, 0x2f, 0x5d,
+	0x2d, 0xec, 0x39, 0xf3, 0x61, 0xcb, 0x7c, 0xfa, 0x0d, 0xf8, 0x4f, 0x1c, 0x98, 0xb6, 0xbc, 0xeb,
+	0x06, 0xff, 0x86, 0xdd, 0xb9, 0x81, 0xfd, 0x96, 0x71, 0xc3, 0x57, 0x30, 0x68, 0x69, 0x3e, 0x8b,
+	0x77, 0xb3, 0x8d, 0x77, 0x43, 0x1c, 0x9d, 0xfd, 0xc9, 0xf9, 0x44, 0x22, 0x76, 0xc9, 0x6e, 0xa2,
+	0xc7, 0x59, 0x4d, 0x5f, 0x78, 0x3c, 0xc7, 0xb7, 0xa4, 0x91, 0xc9, 0x76, 0xd9, 0x85, 0x5e, 0xad,
+	0xfe, 0xbb, 0xc5, 0x5d, 0x7d, 0x0d, 0x00, 0x25, 0xb1, 0x7e, 0x8a, 0x03, 0x02, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -0,0 +1,192 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package readycheck moves matches through their ready check, where every
+// player must accept the match before it can be assigned.  Players accept and
+// decline through the frontend, while the backend times out the ready check.
+package readycheck
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"open-match.dev/open-match/internal/statestore"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+var (
+	logger = logrus.WithFields(logrus.Fields{
+		"app":       "openmatch",
+		"component": "readycheck",
+	})
+)
+
+// Accept records the ticket's player accepting its match, returning the match
+// id.  The match becomes UNASSIGNED once every player accepted.
+func Accept(ctx context.Context, store statestore.Service, ticketID string) (string, error) {
+	matchID, err := store.GetReadyCheckMatchID(ctx, ticketID)
+	if err != nil {
+		return "", err
+	}
+
+	_, err = store.UpdateMatch(ctx, matchID, func(record *pb.MatchRecord) error {
+		err := checkPending(record, ticketID)
+		if err != nil {
+			return err
+		}
+		if !contains(record.GetAcceptedTicketIds(), ticketID) {
+			record.AcceptedTicketIds = append(record.AcceptedTicketIds, ticketID)
+		}
+		if len(record.GetAcceptedTicketIds()) == len(record.GetMatch().GetTickets()) {
+			record.State = pb.MatchRecord_UNASSIGNED
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return matchID, nil
+}
+
+// Decline records the ticket's player declining its match, returning the
+// match id.  The tickets of the other players are released back to the pool.
+func Decline(ctx context.Context, store statestore.Service, ticketID string) (string, error) {
+	matchID, err := store.GetReadyCheckMatchID(ctx, ticketID)
+	if err != nil {
+		return "", err
+	}
+
+	record, err := store.UpdateMatch(ctx, matchID, func(record *pb.MatchRecord) error {
+		err := checkPending(record, ticketID)
+		if err != nil {
+			return err
+		}
+		record.State = pb.MatchRecord_DECLINED
+		record.DeclinedTicketIds = []string{ticketID}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	release(ctx, store, record)
+	return matchID, nil
+}
+
+// Expire declines the match on behalf of the players who haven't accepted it,
+// if its ready check is past the deadline.  Returns the updated record, and
+// whether it timed out now rather than still being pending or already over.
+func Expire(ctx context.Context, store statestore.Service, matchID string) (*pb.MatchRecord, bool, error) {
+	expired := false
+	record, err := store.UpdateMatch(ctx, matchID, func(record *pb.MatchRecord) error {
+		expired = false
+		if record.GetState() != pb.MatchRecord_AWAITING_READY || !pastDeadline(record) {
+			return nil
+		}
+		expired = true
+		record.State = pb.MatchRecord_DECLINED
+		record.DeclinedTicketIds = nil
+		for _, t := range record.GetMatch().GetTickets() {
+			if !contains(record.GetAcceptedTicketIds(), t.GetId()) {
+				record.DeclinedTicketIds = append(record.DeclinedTicketIds, t.GetId())
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	if expired {
+		release(ctx, store, record)
+	}
+	return record, expired, nil
+}
+
+func checkPending(record *pb.MatchRecord, ticketID string) error {
+	if record.GetState() != pb.MatchRecord_AWAITING_READY {
+		return status.Errorf(codes.FailedPrecondition, "ready check of match id:%s is over, match is %s", record.GetMatch().GetMatchId(), record.GetState())
+	}
+	if pastDeadline(record) {
+		return status.Errorf(codes.FailedPrecondition, "ready check of match id:%s timed out", record.GetMatch().GetMatchId())
+	}
+	if contains(record.GetDeclinedTicketIds(), ticketID) {
+		return status.Errorf(codes.FailedPrecondition, "Ticket id:%s already declined match id:%s", ticketID, record.GetMatch().GetMatchId())
+	}
+	return nil
+}
+
+func pastDeadline(record *pb.MatchRecord) bool {
+	deadline, err := ptypes.Timestamp(record.GetReadyDeadline())
+	if err != nil {
+		// Without a valid deadline, the ready check can never complete.
+		return true
+	}
+	return time.Now().After(deadline)
+}
+
+// release returns the tickets of the players who accepted the declined match
+// to the pool, and deletes the tickets of those who didn't if configured to.
+// Failures are only logged, as the tickets are released anyway once
+// pendingReleaseTimeout passes.
+func release(ctx context.Context, store statestore.Service, record *pb.MatchRecord) {
+	var released []string
+	for _, t := range record.GetMatch().GetTickets() {
+		if !contains(record.GetDeclinedTicketIds(), t.GetId()) {
+			released = append(released, t.GetId())
+		}
+	}
+
+	if record.GetReadyCheck().GetDeleteDeclinedTickets() {
+		for _, id := range record.GetDeclinedTicketIds() {
+			err := store.DeindexTicket(ctx, id)
+			if err != nil {
+				logger.WithFields(logrus.Fields{
+					"error": err.Error(),
+					"id":    id,
+				}).Error("failed to deindex the declined ticket")
+				continue
+			}
+			err = store.DeleteTicket(ctx, id)
+			if err != nil {
+				logger.WithFields(logrus.Fields{
+					"error": err.Error(),
+					"id":    id,
+				}).Error("failed to delete the declined ticket")
+			}
+		}
+		released = append(released, record.GetDeclinedTicketIds()...)
+	}
+
+	err := store.DeleteTicketsFromIgnoreList(ctx, released)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"error":      err.Error(),
+			"ticket_ids": released,
+		}).Error("failed to release the tickets of the declined match")
+	}
+}
+
+func contains(ids []string, id string) bool {
+	for _, x := range ids {
+		if x == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,195 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rpc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"crypto/x509"
+	"encoding/hex"
+	"net"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// clientIdentityHeader carries the identities of the client certificate of
+	// an HTTPS request to the gRPC service it is proxied to.
+	clientIdentityHeader = "x-open-match-client-identity"
+	// proxyTokenKey carries the secret of the HTTPS proxy of a server, which
+	// only the calls of the proxy are sent with.
+	proxyTokenKey = "x-open-match-proxy-token"
+	// anyMethod allows an identity to call every method of the service.
+	anyMethod = "*"
+)
+
+// authorizer allows callers to call the methods configured for the
+// identities of their client certificate.
+type authorizer struct {
+	// rules maps each lowercased identity to the methods it may call.
+	rules map[string][]string
+	// proxyToken is the secret the HTTPS proxy of this server calls gRPC
+	// with, whose calls are authorized as the identity they forward.  The
+	// certificate of the proxy isn't enough, as it is the certificate of the
+	// server, which other services may share.
+	proxyToken string
+}
+
+// newAuthorizer returns an authorizer of the rules, or nil if there are none,
+// in which case every verified client may call every method.
+func newAuthorizer(rules map[string][]string, proxyToken string) *authorizer {
+	if len(rules) == 0 {
+		return nil
+	}
+	lowered := make(map[string][]string, len(rules))
+	for identity, methods := range rules {
+		lowered[strings.ToLower(identity)] = methods
+	}
+	return &authorizer{rules: lowered, proxyToken: proxyToken}
+}
+
+// newProxyToken returns a random secret for the proxy of a server.
+func newProxyToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// certificateIdentities returns the subject alternative names and common
+// name of the certificate.
+func certificateIdentities(cert *x509.Certificate) []string {
+	var identities []string
+	identities = append(identities, cert.DNSNames...)
+	identities = append(identities, cert.EmailAddresses...)
+	for _, ip := range cert.IPAddresses {
+		identities = append(identities, ip.String())
+	}
+	for _, uri := range cert.URIs {
+		identities = append(identities, uri.String())
+	}
+	if cert.Subject.CommonName != "" {
+		identities = append(identities, cert.Subject.CommonName)
+	}
+	return identities
+}
+
+// callerIdentities returns the identities of the verified client certificate
+// of the call.
+func (a *authorizer) callerIdentities(ctx context.Context) []string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
+		return nil
+	}
+	cert := tlsInfo.State.VerifiedChains[0][0]
+
+	if a.fromProxy(ctx, p) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		if forwarded := md.Get(clientIdentityHeader); len(forwarded) > 0 {
+			return forwarded
+		}
+	}
+	return certificateIdentities(cert)
+}
+
+// fromProxy returns true if the call is from the HTTPS proxy of this server,
+// running in the same process.
+func (a *authorizer) fromProxy(ctx context.Context, p *peer.Peer) bool {
+	if a.proxyToken == "" {
+		return false
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return false
+	}
+	if ip := net.ParseIP(host); ip == nil || !ip.IsLoopback() {
+		return false
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	tokens := md.Get(proxyTokenKey)
+	return len(tokens) == 1 && subtle.ConstantTimeCompare([]byte(tokens[0]), []byte(a.proxyToken)) == 1
+}
+
+func (a *authorizer) authorize(ctx context.Context, fullMethod string) error {
+	identities := a.callerIdentities(ctx)
+	if len(identities) == 0 {
+		return status.Errorf(codes.Unauthenticated, "%s requires a verified client certificate", fullMethod)
+	}
+
+	for _, identity := range identities {
+		for _, method := range a.rules[strings.ToLower(identity)] {
+			if method == anyMethod || method == fullMethod || method == path.Base(fullMethod) {
+				return nil
+			}
+		}
+	}
+
+	serverLogger.WithFields(logrus.Fields{
+		"method":     fullMethod,
+		"identities": identities,
+	}).Warning("denied unauthorized call")
+	return status.Errorf(codes.PermissionDenied, "%v may not call %s", identities, fullMethod)
+}
+
+func (a *authorizer) unaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := a.authorize(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func (a *authorizer) streamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := a.authorize(stream.Context(), info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, stream)
+	}
+}
+
+// forwardClientIdentity rejects HTTPS requests without a verified client
+// certificate, and replaces their client identity header with the identities
+// of the certificate, for the proxied gRPC call to be authorized as the
+// original caller.
+func forwardClientIdentity(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.TLS == nil || len(req.TLS.VerifiedChains) == 0 || len(req.TLS.VerifiedChains[0]) == 0 {
+			http.Error(w, "a verified client certificate is required", http.StatusUnauthorized)
+			return
+		}
+		req.Header.Del(clientIdentityHeader)
+		for _, identity := range certificateIdentities(req.TLS.VerifiedChains[0][0]) {
+			req.Header.Add(clientIdentityHeader, identity)
+		}
+		handler.ServeHTTP(w, req)
+	})
+}
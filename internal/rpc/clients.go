@@ -30,7 +30,6 @@ import (
 	"go.opencensus.io/plugin/ocgrpc"
 
 	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
-	grpc_tracing "github.com/grpc-ecosystem/go-grpc-middleware/tracing/opentracing"
 
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
@@ -42,6 +41,7 @@ import (
 	"open-match.dev/open-match/internal/config"
 	"open-match.dev/open-match/internal/logging"
 	"open-match.dev/open-match/internal/telemetry"
+	"open-match.dev/open-match/internal/tenant"
 )
 
 const (
@@ -49,6 +49,20 @@ const (
 	ConfigNameEnableRPCLogging = "logging.rpc"
 	// configNameClientTrustedCertificatePath is the same as the root CA cert that the server trusts.
 	configNameClientTrustedCertificatePath = configNameServerRootCertificatePath
+	// configNameClientCertificateFile and configNameClientPrivateKeyFile are
+	// the certificate clients present to servers requiring mutual TLS.  The
+	// certificate of the server isn't presented instead, as it would give
+	// every service the identities of the server.
+	configNameClientCertificateFile = "api.tls.clientCertificateFile"
+	configNameClientPrivateKeyFile  = "api.tls.clientPrivateKey"
+	// configNameClientHealthCheck enables client-side health checking of gRPC
+	// connections, which stops sending calls to servers not serving.
+	configNameClientHealthCheck = "api.clientHealthCheck"
+
+	// healthCheckServiceConfig round robins calls between the servers which
+	// report serving through the gRPC health checking protocol.  Servers not
+	// implementing the protocol are assumed to be serving.
+	healthCheckServiceConfig = `{"loadBalancingPolicy":"round_robin","healthCheckConfig":{"serviceName":""}}`
 )
 
 var (
@@ -60,11 +74,18 @@ var (
 
 // ClientParams contains the connection parameters to connect to an Open Match service.
 type ClientParams struct {
-	Address                 string
-	TrustedCertificate      []byte
+	Address            string
+	TrustedCertificate []byte
+	// Certificate and PrivateKey, in PEM format, are presented to servers
+	// requiring mutual TLS.
+	Certificate             []byte
+	PrivateKey              []byte
 	EnableRPCLogging        bool
 	EnableRPCPayloadLogging bool
 	EnableMetrics           bool
+	// EnableHealthCheck only sends calls to the servers of the address
+	// reporting serving through the gRPC health checking protocol.
+	EnableHealthCheck bool
 }
 
 // nolint:gochecknoinits
@@ -80,28 +101,74 @@ func (p *ClientParams) usingTLS() bool {
 	return len(p.TrustedCertificate) > 0
 }
 
+// tlsConfig returns the TLS configuration trusting the server certificate, and
+// presenting the client certificate if any.
+func (p *ClientParams) tlsConfig() (*tls.Config, error) {
+	pool, err := trustedCertificateFromFileData(p.TrustedCertificate)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig := &tls.Config{RootCAs: pool}
+	if len(p.Certificate) > 0 {
+		cert, err := certificateFromFileData(p.Certificate, p.PrivateKey)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.Certificates = []tls.Certificate{*cert}
+	}
+	return tlsConfig, nil
+}
+
+// readTLSFromConfig fills in the trusted certificate for decrypting the server
+// certificate, and the client certificate to present, if TLS support is
+// enabled in the config.
+func readTLSFromConfig(cfg config.View, params *ClientParams) error {
+	if cfg.GetString(configNameClientTrustedCertificatePath) == "" {
+		return nil
+	}
+
+	_, err := os.Stat(cfg.GetString(configNameClientTrustedCertificatePath))
+	if err != nil {
+		clientLogger.WithError(err).Error("trusted certificate file may not exists.")
+		return err
+	}
+
+	params.TrustedCertificate, err = ioutil.ReadFile(cfg.GetString(configNameClientTrustedCertificatePath))
+	if err != nil {
+		clientLogger.WithError(err).Error("failed to read tls trusted certificate to establish a secure grpc client.")
+		return err
+	}
+
+	certFile, privateKeyFile := cfg.GetString(configNameClientCertificateFile), cfg.GetString(configNameClientPrivateKeyFile)
+	if certFile == "" || privateKeyFile == "" {
+		return nil
+	}
+
+	params.Certificate, err = ioutil.ReadFile(certFile)
+	if err != nil {
+		clientLogger.WithError(err).Error("failed to read tls client certificate.")
+		return err
+	}
+	params.PrivateKey, err = ioutil.ReadFile(privateKeyFile)
+	if err != nil {
+		clientLogger.WithError(err).Error("failed to read tls client private key.")
+		return err
+	}
+	return nil
+}
+
 // GRPCClientFromConfig creates a gRPC client connection from a configuration.
 func GRPCClientFromConfig(cfg config.View, prefix string) (*grpc.ClientConn, error) {
 	clientParams := &ClientParams{
 		Address:                 toAddress(cfg.GetString(prefix+".hostname"), cfg.GetInt(prefix+".grpcport")),
 		EnableRPCLogging:        cfg.GetBool(ConfigNameEnableRPCLogging),
 		EnableRPCPayloadLogging: logging.IsDebugEnabled(cfg),
-		EnableMetrics:           cfg.GetBool(telemetry.ConfigNameEnableMetrics),
+		EnableMetrics:           telemetry.InstrumentationEnabled(cfg),
+		EnableHealthCheck:       cfg.GetBool(configNameClientHealthCheck),
 	}
 
-	// If TLS support is enabled in the config, fill in the trusted certificates for decrpting server certificate.
-	if cfg.GetString(configNameClientTrustedCertificatePath) != "" {
-		_, err := os.Stat(cfg.GetString(configNameClientTrustedCertificatePath))
-		if err != nil {
-			clientLogger.WithError(err).Error("trusted certificate file may not exists.")
-			return nil, err
-		}
-
-		clientParams.TrustedCertificate, err = ioutil.ReadFile(cfg.GetString(configNameClientTrustedCertificatePath))
-		if err != nil {
-			clientLogger.WithError(err).Error("failed to read tls trusted certificate to establish a secure grpc client.")
-			return nil, err
-		}
+	if err := readTLSFromConfig(cfg, clientParams); err != nil {
+		return nil, err
 	}
 
 	return GRPCClientFromParams(clientParams)
@@ -110,34 +177,19 @@ func GRPCClientFromConfig(cfg config.View, prefix string) (*grpc.ClientConn, err
 // GRPCClientFromEndpoint creates a gRPC client connection from endpoint.
 func GRPCClientFromEndpoint(cfg config.View, address string) (*grpc.ClientConn, error) {
 	// TODO: investigate if it is possible to keep a cache of the certpool and transport credentials
-	grpcOptions := newGRPCDialOptions(cfg.GetBool(telemetry.ConfigNameEnableMetrics), cfg.GetBool(ConfigNameEnableRPCLogging), logging.IsDebugEnabled(cfg))
-
-	if cfg.GetString(configNameClientTrustedCertificatePath) != "" {
-		_, err := os.Stat(cfg.GetString(configNameClientTrustedCertificatePath))
-		if err != nil {
-			clientLogger.WithError(err).Error("trusted certificate file may not exists.")
-			return nil, err
-		}
-
-		trustedCertificate, err := ioutil.ReadFile(cfg.GetString(configNameClientTrustedCertificatePath))
-		if err != nil {
-			clientLogger.WithError(err).Error("failed to read tls trusted certificate to establish a secure grpc client.")
-			return nil, err
-		}
-
-		pool, err := trustedCertificateFromFileData(trustedCertificate)
-		if err != nil {
-			clientLogger.WithError(err).Error("failed to get transport credentials from file.")
-			return nil, errors.WithStack(err)
-		}
-		tc := credentials.NewClientTLSFromCert(pool, "")
+	clientParams := &ClientParams{
+		Address:                 address,
+		EnableRPCLogging:        cfg.GetBool(ConfigNameEnableRPCLogging),
+		EnableRPCPayloadLogging: logging.IsDebugEnabled(cfg),
+		EnableMetrics:           telemetry.InstrumentationEnabled(cfg),
+		EnableHealthCheck:       cfg.GetBool(configNameClientHealthCheck),
+	}
 
-		grpcOptions = append(grpcOptions, grpc.WithTransportCredentials(tc))
-	} else {
-		grpcOptions = append(grpcOptions, grpc.WithInsecure())
+	if err := readTLSFromConfig(cfg, clientParams); err != nil {
+		return nil, err
 	}
 
-	return grpc.Dial(address, grpcOptions...)
+	return GRPCClientFromParams(clientParams)
 }
 
 // GRPCClientFromParams creates a gRPC client connection from the parameters.
@@ -145,16 +197,20 @@ func GRPCClientFromParams(params *ClientParams) (*grpc.ClientConn, error) {
 	grpcOptions := newGRPCDialOptions(params.EnableMetrics, params.EnableRPCLogging, params.EnableRPCPayloadLogging)
 
 	if params.usingTLS() {
-		trustedCertPool, err := trustedCertificateFromFileData(params.TrustedCertificate)
+		tlsConfig, err := params.tlsConfig()
 		if err != nil {
 			clientLogger.WithError(err).Error("failed to get transport credentials from file.")
 			return nil, errors.WithStack(err)
 		}
-		grpcOptions = append(grpcOptions, grpc.WithTransportCredentials(credentials.NewClientTLSFromCert(trustedCertPool, "")))
+		grpcOptions = append(grpcOptions, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
 	} else {
 		grpcOptions = append(grpcOptions, grpc.WithInsecure())
 	}
 
+	if params.EnableHealthCheck {
+		grpcOptions = append(grpcOptions, grpc.WithDefaultServiceConfig(healthCheckServiceConfig))
+	}
+
 	return grpc.Dial(params.Address, grpcOptions...)
 }
 
@@ -164,22 +220,11 @@ func HTTPClientFromConfig(cfg config.View, prefix string) (*http.Client, string,
 		Address:                 toAddress(cfg.GetString(prefix+".hostname"), cfg.GetInt(prefix+".httpport")),
 		EnableRPCLogging:        cfg.GetBool(ConfigNameEnableRPCLogging),
 		EnableRPCPayloadLogging: logging.IsDebugEnabled(cfg),
-		EnableMetrics:           cfg.GetBool(telemetry.ConfigNameEnableMetrics),
+		EnableMetrics:           telemetry.InstrumentationEnabled(cfg),
 	}
 
-	// If TLS support is enabled in the config, fill in the trusted certificates for decrpting server certificate.
-	if cfg.GetString(configNameClientTrustedCertificatePath) != "" {
-		_, err := os.Stat(cfg.GetString(configNameClientTrustedCertificatePath))
-		if err != nil {
-			clientLogger.WithError(err).Error("trusted certificate file may not exists.")
-			return nil, "", err
-		}
-
-		clientParams.TrustedCertificate, err = ioutil.ReadFile(cfg.GetString(configNameClientTrustedCertificatePath))
-		if err != nil {
-			clientLogger.WithError(err).Error("failed to read tls trusted certificate to establish a secure grpc client.")
-			return nil, "", err
-		}
+	if err := readTLSFromConfig(cfg, clientParams); err != nil {
+		return nil, "", err
 	}
 
 	return HTTPClientFromParams(clientParams)
@@ -209,21 +254,10 @@ func HTTPClientFromEndpoint(cfg config.View, address string) (*http.Client, stri
 		Address:                 address,
 		EnableRPCLogging:        cfg.GetBool(ConfigNameEnableRPCLogging),
 		EnableRPCPayloadLogging: logging.IsDebugEnabled(cfg),
-		EnableMetrics:           cfg.GetBool(telemetry.ConfigNameEnableMetrics),
+		EnableMetrics:           telemetry.InstrumentationEnabled(cfg),
 	}
-	if cfg.GetString(configNameClientTrustedCertificatePath) != "" {
-		_, err := os.Stat(cfg.GetString(configNameClientTrustedCertificatePath))
-		if err != nil {
-			clientLogger.WithError(err).Error("trusted certificate file may not exists.")
-			return nil, "", err
-		}
-
-		trustedCertificate, err := ioutil.ReadFile(cfg.GetString(configNameClientTrustedCertificatePath))
-		if err != nil {
-			clientLogger.WithError(err).Error("failed to read tls trusted certificate to establish a secure grpc client.")
-			return nil, "", err
-		}
-		params.TrustedCertificate = trustedCertificate
+	if err := readTLSFromConfig(cfg, params); err != nil {
+		return nil, "", err
 	}
 	return HTTPClientFromParams(params)
 }
@@ -242,17 +276,15 @@ func HTTPClientFromParams(params *ClientParams) (*http.Client, string, error) {
 			return nil, "", err
 		}
 
-		pool, err := trustedCertificateFromFileData(params.TrustedCertificate)
+		tlsConfig, err := params.tlsConfig()
 		if err != nil {
 			clientLogger.WithError(err).Error("failed to get cert pool from file.")
 			return nil, "", err
 		}
+		tlsConfig.ServerName = params.Address
 
 		httpClient.Transport = &http.Transport{
-			TLSClientConfig: &tls.Config{
-				ServerName: params.Address,
-				RootCAs:    pool,
-			},
+			TLSClientConfig: tlsConfig,
 		}
 	} else {
 		var err error
@@ -275,7 +307,8 @@ func HTTPClientFromParams(params *ClientParams) (*http.Client, string, error) {
 	if params.EnableMetrics {
 		attachTransport(httpClient, func(transport http.RoundTripper) http.RoundTripper {
 			return &ochttp.Transport{
-				Base: transport,
+				Base:        transport,
+				Propagation: &httpFormat{},
 			}
 		})
 	}
@@ -285,10 +318,10 @@ func HTTPClientFromParams(params *ClientParams) (*http.Client, string, error) {
 
 func newGRPCDialOptions(enableMetrics bool, enableRPCLogging bool, enableRPCPayloadLogging bool) []grpc.DialOption {
 	si := []grpc.StreamClientInterceptor{
-		grpc_tracing.StreamClientInterceptor(),
+		tenant.StreamClientInterceptor(),
 	}
 	ui := []grpc.UnaryClientInterceptor{
-		grpc_tracing.UnaryClientInterceptor(),
+		tenant.UnaryClientInterceptor(),
 	}
 	if enableRPCLogging {
 		grpcLogger := logrus.WithFields(logrus.Fields{
@@ -318,7 +351,7 @@ func newGRPCDialOptions(enableMetrics bool, enableRPCLogging bool, enableRPCPayl
 		}),
 	}
 	if enableMetrics {
-		opts = append(opts, grpc.WithStatsHandler(new(ocgrpc.ClientHandler)))
+		opts = append(opts, grpc.WithStatsHandler(clientStatsHandler{new(ocgrpc.ClientHandler)}))
 	}
 	return opts
 }
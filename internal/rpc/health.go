@@ -0,0 +1,169 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rpc
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const (
+	// healthServicePrefix prefixes the methods of the gRPC health checking
+	// protocol, which are exempt from authorization, authentication and rate
+	// limiting, for load balancers and clients to check any server.
+	healthServicePrefix = "/grpc.health.v1.Health/"
+	// defaultHealthCheckInterval is how often the health checks of a server
+	// are run, to update the status served by the gRPC health service.
+	defaultHealthCheckInterval = 5 * time.Second
+	// healthCheckTimeout bounds each run of the health checks.
+	healthCheckTimeout = 5 * time.Second
+)
+
+// grpcHealth serves the gRPC health checking protocol, with the status of
+// the server and of each of its services fed from its health checks.
+type grpcHealth struct {
+	server   *health.Server
+	probes   []func(context.Context) error
+	interval time.Duration
+	ctx      context.Context
+	cancel   context.CancelFunc
+}
+
+func newGRPCHealth(params *ServerParams) *grpcHealth {
+	interval := params.healthCheckInterval
+	if interval <= 0 {
+		interval = defaultHealthCheckInterval
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &grpcHealth{
+		server:   health.NewServer(),
+		probes:   params.handlersForHealthCheck,
+		interval: interval,
+		ctx:      ctx,
+		cancel:   cancel,
+	}
+}
+
+// Check implements grpc.health.v1.Health.
+func (h *grpcHealth) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	return h.server.Check(ctx, req)
+}
+
+// Watch implements grpc.health.v1.Health, ending the stream once stopped, so
+// that watching clients don't keep the gRPC server from stopping gracefully.
+func (h *grpcHealth) Watch(req *healthpb.HealthCheckRequest, stream healthpb.Health_WatchServer) error {
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+	go func() {
+		select {
+		case <-h.ctx.Done():
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	return h.server.Watch(req, &watchServer{Health_WatchServer: stream, ctx: ctx})
+}
+
+type watchServer struct {
+	healthpb.Health_WatchServer
+	ctx context.Context
+}
+
+func (s *watchServer) Context() context.Context {
+	return s.ctx
+}
+
+// start registers the health service on the gRPC server, which must be called
+// after every other service is registered, and runs the health checks until stopped.
+func (h *grpcHealth) start(s *grpc.Server) {
+	var services []string
+	for name := range s.GetServiceInfo() {
+		services = append(services, name)
+	}
+	healthpb.RegisterHealthServer(s, h)
+
+	h.update(services)
+	go func() {
+		ticker := time.NewTicker(h.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-h.ctx.Done():
+				return
+			case <-ticker.C:
+				h.update(services)
+			}
+		}
+	}()
+}
+
+// update sets the status of the server, the empty service name, and of each
+// service to whether all of the health checks pass.
+func (h *grpcHealth) update(services []string) {
+	ctx, cancel := context.WithTimeout(h.ctx, healthCheckTimeout)
+	defer cancel()
+
+	status := healthpb.HealthCheckResponse_SERVING
+	for _, probe := range h.probes {
+		if err := probe(ctx); err != nil {
+			serverLogger.WithError(err).Debug("gRPC health check failed")
+			status = healthpb.HealthCheckResponse_NOT_SERVING
+			break
+		}
+	}
+
+	h.server.SetServingStatus("", status)
+	for _, service := range services {
+		h.server.SetServingStatus(service, status)
+	}
+}
+
+// stop stops running the health checks, and ends the streams of watching
+// clients after telling them every service is not serving.
+func (h *grpcHealth) stop() {
+	h.server.Shutdown()
+	h.cancel()
+}
+
+func isHealthMethod(fullMethod string) bool {
+	return strings.HasPrefix(fullMethod, healthServicePrefix)
+}
+
+// exemptHealthUnary skips the interceptor for the methods of the health
+// service.
+func exemptHealthUnary(interceptor grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if isHealthMethod(info.FullMethod) {
+			return handler(ctx, req)
+		}
+		return interceptor(ctx, req, info, handler)
+	}
+}
+
+// exemptHealthStream skips the interceptor for the methods of the health
+// service.
+func exemptHealthStream(interceptor grpc.StreamServerInterceptor) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if isHealthMethod(info.FullMethod) {
+			return handler(srv, stream)
+		}
+		return interceptor(srv, stream, info, handler)
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"open-match.dev/open-match/internal/auth"
+	shellTesting "open-match.dev/open-match/internal/testing"
+	utilTesting "open-match.dev/open-match/internal/util/testing"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+func TestGRPCHealth(t *testing.T) {
+	assert := assert.New(t)
+	grpcL := MustListen()
+	httpL := MustListen()
+
+	var healthy int32 = 1
+	params := NewServerParamsFromListeners(grpcL, httpL)
+	params.AddHandleFunc(func(s *grpc.Server) {
+		pb.RegisterFrontendServiceServer(s, &shellTesting.FakeFrontend{})
+	}, pb.RegisterFrontendServiceHandlerFromEndpoint)
+	params.AddHealthCheckFunc(func(context.Context) error {
+		if atomic.LoadInt32(&healthy) == 0 {
+			return errors.New("unhealthy")
+		}
+		return nil
+	})
+	params.healthCheckInterval = 10 * time.Millisecond
+	// Health checks don't need a bearer token.
+	cfg := viper.New()
+	cfg.Set("api.frontend.auth.apiKeys", map[string][]string{"lobby": {"secret"}})
+	authenticator, err := auth.FromConfig(cfg, "api.frontend")
+	assert.Nil(err)
+	params.SetAuthenticator(authenticator)
+
+	s := newInsecureServer(grpcL, httpL)
+	defer s.stop()
+	assert.Nil(s.start(params))
+
+	conn, err := grpc.Dial(fmt.Sprintf(":%s", MustGetPortNumber(grpcL)), grpc.WithInsecure())
+	assert.Nil(err)
+	defer conn.Close()
+	hc := healthpb.NewHealthClient(conn)
+	ctx := utilTesting.NewContext(t)
+
+	check := func(service string) (healthpb.HealthCheckResponse_ServingStatus, codes.Code) {
+		resp, err := hc.Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+		return resp.GetStatus(), status.Code(err)
+	}
+
+	for _, service := range []string{"", "openmatch.FrontendService"} {
+		got, code := check(service)
+		assert.Equal(codes.OK, code, service)
+		assert.Equal(healthpb.HealthCheckResponse_SERVING, got, service)
+	}
+	_, code := check("openmatch.Unknown")
+	assert.Equal(codes.NotFound, code)
+
+	atomic.StoreInt32(&healthy, 0)
+	assert.Eventually(func() bool {
+		got, _ := check("openmatch.FrontendService")
+		return got == healthpb.HealthCheckResponse_NOT_SERVING
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestClientHealthCheck(t *testing.T) {
+	assert := assert.New(t)
+	grpcL := MustListen()
+	httpL := MustListen()
+
+	var healthy int32
+	params := NewServerParamsFromListeners(grpcL, httpL)
+	params.AddHandleFunc(func(s *grpc.Server) {
+		pb.RegisterFrontendServiceServer(s, &shellTesting.FakeFrontend{})
+	}, pb.RegisterFrontendServiceHandlerFromEndpoint)
+	params.AddHealthCheckFunc(func(context.Context) error {
+		if atomic.LoadInt32(&healthy) == 0 {
+			return errors.New("unhealthy")
+		}
+		return nil
+	})
+	params.healthCheckInterval = 10 * time.Millisecond
+
+	s := newInsecureServer(grpcL, httpL)
+	defer s.stop()
+	assert.Nil(s.start(params))
+
+	conn, err := GRPCClientFromParams(&ClientParams{
+		Address:           fmt.Sprintf("localhost:%s", MustGetPortNumber(grpcL)),
+		EnableHealthCheck: true,
+	})
+	assert.Nil(err)
+	defer conn.Close()
+	fe := pb.NewFrontendServiceClient(conn)
+
+	// Calls fail fast while the only server isn't serving.
+	ctx, cancel := context.WithTimeout(utilTesting.NewContext(t), 100*time.Millisecond)
+	defer cancel()
+	_, err = fe.CreateTicket(ctx, &pb.CreateTicketRequest{})
+	assert.NotNil(err)
+
+	atomic.StoreInt32(&healthy, 1)
+	_, err = fe.CreateTicket(utilTesting.NewContext(t), &pb.CreateTicketRequest{}, grpc.WaitForReady(true))
+	assert.Nil(err)
+}
@@ -28,6 +28,7 @@ import (
 type insecureServer struct {
 	grpcListener net.Listener
 	grpcServer   *grpc.Server
+	grpcHealth   *grpcHealth
 
 	httpListener net.Listener
 	httpMux      *http.ServeMux
@@ -37,14 +38,16 @@ type insecureServer struct {
 
 func (s *insecureServer) start(params *ServerParams) error {
 	s.httpMux = params.ServeMux
-	s.proxyMux = runtime.NewServeMux()
+	s.proxyMux = runtime.NewServeMux(runtime.WithIncomingHeaderMatcher(matchIncomingHeader))
 
 	// Configure the gRPC server.
-	s.grpcServer = grpc.NewServer(newGRPCServerOptions(params)...)
+	s.grpcServer = grpc.NewServer(newGRPCServerOptions(params, nil)...)
 	// Bind gRPC handlers
 	for _, handlerFunc := range params.handlersForGrpc {
 		handlerFunc(s.grpcServer)
 	}
+	s.grpcHealth = newGRPCHealth(params)
+	s.grpcHealth.start(s.grpcServer)
 
 	go func() {
 		serverLogger.Infof("Serving gRPC: %s", s.grpcListener.Addr().String())
@@ -58,8 +61,9 @@ func (s *insecureServer) start(params *ServerParams) error {
 	// Bind gRPC handlers
 	ctx, cancel := context.WithCancel(context.Background())
 
+	rpcLogging, rpcPayloadLogging := params.rpcLogging.get()
 	for _, handlerFunc := range params.handlersForGrpcProxy {
-		dialOpts := newGRPCDialOptions(params.enableMetrics, params.enableRPCLogging, params.enableRPCPayloadLogging)
+		dialOpts := newGRPCDialOptions(params.enableMetrics, rpcLogging, rpcPayloadLogging)
 		dialOpts = append(dialOpts, grpc.WithInsecure())
 		if err := handlerFunc(ctx, s.proxyMux, s.grpcListener.Addr().String(), dialOpts); err != nil {
 			cancel()
@@ -88,6 +92,7 @@ func (s *insecureServer) start(params *ServerParams) error {
 func (s *insecureServer) stop() error {
 	// the servers also close their respective listeners.
 	err := s.httpServer.Shutdown(context.Background())
+	s.grpcHealth.stop()
 	s.grpcServer.GracefulStop()
 	return err
 }
@@ -17,14 +17,21 @@ package rpc
 import (
 	"fmt"
 	"net/http"
+	"strings"
 	"testing"
 	"time"
 
 	"open-match.dev/open-match/pkg/pb"
 
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"open-match.dev/open-match/internal/auth"
 	shellTesting "open-match.dev/open-match/internal/testing"
+	utilTesting "open-match.dev/open-match/internal/util/testing"
 )
 
 func TestInsecureStartStop(t *testing.T) {
@@ -52,3 +59,50 @@ func TestInsecureStartStop(t *testing.T) {
 	}
 	runGrpcWithProxyTests(t, assert, s, conn, httpClient, endpoint)
 }
+
+func TestInsecureAuthenticator(t *testing.T) {
+	assert := assert.New(t)
+	grpcL := MustListen()
+	httpL := MustListen()
+
+	params := NewServerParamsFromListeners(grpcL, httpL)
+	params.AddHandleFunc(func(s *grpc.Server) {
+		pb.RegisterFrontendServiceServer(s, &shellTesting.FakeFrontend{})
+	}, pb.RegisterFrontendServiceHandlerFromEndpoint)
+	cfg := viper.New()
+	cfg.Set("api.frontend.auth.apiKeys", map[string][]string{"lobby": {"secret"}})
+	authenticator, err := auth.FromConfig(cfg, "api.frontend")
+	assert.Nil(err)
+	params.SetAuthenticator(authenticator)
+
+	s := newInsecureServer(grpcL, httpL)
+	defer s.stop()
+	assert.Nil(s.start(params))
+
+	conn, err := grpc.Dial(fmt.Sprintf(":%s", MustGetPortNumber(grpcL)), grpc.WithInsecure())
+	assert.Nil(err)
+	defer conn.Close()
+	fe := pb.NewFrontendServiceClient(conn)
+
+	ctx := utilTesting.NewContext(t)
+	_, err = fe.CreateTicket(ctx, &pb.CreateTicketRequest{})
+	assert.Equal(codes.Unauthenticated, status.Code(err))
+	_, err = fe.CreateTicket(metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer secret"), &pb.CreateTicketRequest{})
+	assert.Nil(err)
+
+	for authorization, want := range map[string]int{
+		"":              http.StatusUnauthorized,
+		"Bearer wrong":  http.StatusUnauthorized,
+		"Bearer secret": http.StatusOK,
+	} {
+		req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("http://localhost:%s/v1/frontendservice/tickets", MustGetPortNumber(httpL)), strings.NewReader("{}"))
+		assert.Nil(err)
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		resp, err := (&http.Client{Timeout: time.Second}).Do(req)
+		assert.Nil(err)
+		resp.Body.Close()
+		assert.Equal(want, resp.StatusCode, authorization)
+	}
+}
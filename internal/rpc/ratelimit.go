@@ -0,0 +1,233 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"golang.org/x/time/rate"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"open-match.dev/open-match/internal/auth"
+	"open-match.dev/open-match/internal/config"
+)
+
+const (
+	// Config names under the prefix of a service.  Limits of a single method
+	// are configured under rateLimit.methods.<method name>, and default to
+	// the limits of the service.
+	configNameRateLimitPerSecond = "rateLimit.requestsPerSecond"
+	configNameRateLimitBurst     = "rateLimit.burst"
+	configNameRateLimitMethods   = "rateLimit.methods."
+
+	// rateLimiterIdleTimeout is how long the bucket of a caller is kept
+	// without calls.  Idle buckets are full, so dropping them changes nothing.
+	rateLimiterIdleTimeout = 10 * time.Minute
+)
+
+// ResourceExhaustedError returns a ResourceExhausted error, telling the caller
+// to retry after the delay.
+func ResourceExhaustedError(retryDelay time.Duration, format string, a ...interface{}) error {
+	st := status.New(codes.ResourceExhausted, fmt.Sprintf(format, a...))
+	detailed, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: ptypes.DurationProto(retryDelay)})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+type rateLimit struct {
+	perSecond rate.Limit
+	burst     int
+}
+
+// rateLimiter limits the calls of each caller to each method with a token
+// bucket.  Callers are identified by their authenticated identity, or else by
+// their address.  Changes to the limits are applied until close is called,
+// but rate limiting is only enabled or disabled on restart.
+type rateLimiter struct {
+	prefix      string
+	unsubscribe func()
+
+	m       sync.Mutex
+	service rateLimit
+	// methods are the limits of the methods configured with their own, by
+	// lowercase name as listed by the configuration.
+	methods map[string]rateLimit
+	buckets map[rateLimitKey]*bucket
+	pruned  time.Time
+}
+
+type rateLimitKey struct {
+	caller string
+	method string
+}
+
+type bucket struct {
+	limiter  *rate.Limiter
+	lastUsed time.Time
+}
+
+// newRateLimiterFromConfig returns the rate limiter configured for the service
+// of the prefix, or nil if its calls aren't limited.
+func newRateLimiterFromConfig(cfg config.View, prefix string) *rateLimiter {
+	if !cfg.IsSet(prefix + "." + configNameRateLimitPerSecond) {
+		return nil
+	}
+	r := &rateLimiter{
+		prefix: prefix,
+		pruned: time.Now(),
+	}
+	r.unsubscribe = config.Subscribe(cfg, prefix+".rateLimit", r.apply)
+	return r
+}
+
+// apply reads the limits of the service and of its methods.  Callers start
+// over with full buckets, as their buckets may have the previous limits.
+func (r *rateLimiter) apply(cfg config.View) {
+	service := readRateLimit(cfg, r.prefix+"."+configNameRateLimitPerSecond, r.prefix+"."+configNameRateLimitBurst, rateLimit{})
+	methods := make(map[string]rateLimit)
+	// Listing the methods applies the limits of methods configured later.
+	for name := range cfg.GetStringMapStringSlice(r.prefix + "." + strings.TrimSuffix(configNameRateLimitMethods, ".")) {
+		methodPrefix := r.prefix + "." + configNameRateLimitMethods + name + "."
+		methods[name] = readRateLimit(cfg, methodPrefix+"requestsPerSecond", methodPrefix+"burst", service)
+	}
+
+	r.m.Lock()
+	defer r.m.Unlock()
+	r.service = service
+	r.methods = methods
+	r.buckets = make(map[rateLimitKey]*bucket)
+}
+
+// close stops applying changes to the limits.
+func (r *rateLimiter) close() {
+	r.unsubscribe()
+}
+
+func readRateLimit(cfg config.View, perSecondKey, burstKey string, defaults rateLimit) rateLimit {
+	limit := defaults
+	if cfg.IsSet(perSecondKey) {
+		limit.perSecond = rate.Limit(cfg.GetFloat64(perSecondKey))
+		// Without a burst, a second worth of calls may be made at once.
+		limit.burst = int(limit.perSecond)
+	}
+	if cfg.IsSet(burstKey) {
+		limit.burst = cfg.GetInt(burstKey)
+	}
+	if limit.burst < 1 {
+		limit.burst = 1
+	}
+	return limit
+}
+
+// CallerOf returns the authenticated identity of the caller, or else its IP
+// address.  Calls proxied from HTTP are from the address of the original
+// caller, as forwarded by the proxy.
+func CallerOf(ctx context.Context) string {
+	if identity := auth.FromContext(ctx); identity != "" {
+		return "identity:" + identity
+	}
+
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		host = p.Addr.String()
+	}
+
+	// Only the proxy, running in the same process, is trusted to forward
+	// the address of the caller.  It appends the address it received the
+	// request from to the header.
+	if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() {
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if forwarded := md.Get("x-forwarded-for"); len(forwarded) > 0 {
+				addresses := strings.Split(forwarded[len(forwarded)-1], ",")
+				host = strings.TrimSpace(addresses[len(addresses)-1])
+			}
+		}
+	}
+	return "address:" + host
+}
+
+func (r *rateLimiter) allow(ctx context.Context, fullMethod string) error {
+	now := time.Now()
+	key := rateLimitKey{caller: CallerOf(ctx), method: fullMethod}
+
+	r.m.Lock()
+	limit, ok := r.methods[strings.ToLower(path.Base(fullMethod))]
+	if !ok {
+		limit = r.service
+	}
+
+	if now.Sub(r.pruned) > rateLimiterIdleTimeout {
+		for k, b := range r.buckets {
+			if now.Sub(b.lastUsed) > rateLimiterIdleTimeout {
+				delete(r.buckets, k)
+			}
+		}
+		r.pruned = now
+	}
+
+	b, ok := r.buckets[key]
+	if !ok {
+		b = &bucket{limiter: rate.NewLimiter(limit.perSecond, limit.burst)}
+		r.buckets[key] = b
+	}
+	b.lastUsed = now
+	reservation := b.limiter.ReserveN(now, 1)
+	r.m.Unlock()
+
+	if !reservation.OK() {
+		return status.Errorf(codes.ResourceExhausted, "%s may not be called", fullMethod)
+	}
+	delay := reservation.DelayFrom(now)
+	if delay > 0 {
+		reservation.CancelAt(now)
+		return ResourceExhaustedError(delay, "too many calls to %s, retry in %s", fullMethod, delay)
+	}
+	return nil
+}
+
+func (r *rateLimiter) unaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := r.allow(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func (r *rateLimiter) streamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := r.allow(stream.Context(), info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, stream)
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rpc
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"open-match.dev/open-match/internal/auth"
+	"open-match.dev/open-match/internal/config"
+)
+
+const (
+	createTicketMethod = "/openmatch.FrontendService/CreateTicket"
+	getTicketMethod    = "/openmatch.FrontendService/GetTicket"
+)
+
+func TestRateLimiterNotConfigured(t *testing.T) {
+	assert.Nil(t, newRateLimiterFromConfig(viper.New(), "api.frontend"))
+}
+
+func TestRateLimiter(t *testing.T) {
+	assert := assert.New(t)
+	cfg := viper.New()
+	cfg.Set("api.frontend.rateLimit.requestsPerSecond", 0.001)
+	cfg.Set("api.frontend.rateLimit.burst", 2)
+	cfg.Set("api.frontend.rateLimit.methods.GetTicket.burst", 1)
+	r := newRateLimiterFromConfig(cfg, "api.frontend")
+	assert.NotNil(r)
+
+	player1 := auth.NewContext(context.Background(), "player-1")
+	player2 := auth.NewContext(context.Background(), "player-2")
+
+	assert.Nil(r.allow(player1, createTicketMethod))
+	assert.Nil(r.allow(player1, createTicketMethod))
+	err := r.allow(player1, createTicketMethod)
+	assert.Equal(codes.ResourceExhausted, status.Code(err))
+
+	details := status.Convert(err).Details()
+	if assert.Len(details, 1) {
+		retryInfo, ok := details[0].(*errdetails.RetryInfo)
+		assert.True(ok)
+		delay, err := ptypes.Duration(retryInfo.GetRetryDelay())
+		assert.Nil(err)
+		assert.True(delay > 0)
+	}
+
+	// Each caller and method has its own bucket.
+	assert.Nil(r.allow(player2, createTicketMethod))
+	assert.Nil(r.allow(player1, getTicketMethod))
+	assert.Equal(codes.ResourceExhausted, status.Code(r.allow(player1, getTicketMethod)))
+}
+
+func TestRateLimiterConfigChange(t *testing.T) {
+	assert := assert.New(t)
+	v := viper.New()
+	v.Set("api.frontend.rateLimit.requestsPerSecond", 0.001)
+	v.Set("api.frontend.rateLimit.burst", 1)
+	cfg := config.NewWatched(v)
+	r := newRateLimiterFromConfig(cfg, "api.frontend")
+	assert.NotNil(r)
+	defer r.close()
+
+	player := auth.NewContext(context.Background(), "player-1")
+	assert.Nil(r.allow(player, createTicketMethod))
+	assert.Equal(codes.ResourceExhausted, status.Code(r.allow(player, createTicketMethod)))
+
+	// Limits of a method configured later are applied.
+	v.Set("api.frontend.rateLimit.methods.CreateTicket.burst", 2)
+	cfg.Reload()
+	assert.Nil(r.allow(player, createTicketMethod))
+	assert.Nil(r.allow(player, createTicketMethod))
+	assert.Equal(codes.ResourceExhausted, status.Code(r.allow(player, createTicketMethod)))
+
+	v.Set("api.frontend.rateLimit.methods.CreateTicket.burst", 3)
+	cfg.Reload()
+	for i := 0; i < 3; i++ {
+		assert.Nil(r.allow(player, createTicketMethod))
+	}
+	assert.Equal(codes.ResourceExhausted, status.Code(r.allow(player, createTicketMethod)))
+}
+
+func TestCallerOf(t *testing.T) {
+	remote := &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("203.0.113.1"), Port: 1234}}
+	local := &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 1234}}
+	forwarded := metadata.Pairs("x-forwarded-for", "198.51.100.1, 203.0.113.2")
+
+	testCases := []struct {
+		name string
+		ctx  context.Context
+		want string
+	}{
+		{
+			"authenticated",
+			auth.NewContext(peer.NewContext(context.Background(), remote), "player-1"),
+			"identity:player-1",
+		},
+		{
+			"remote",
+			peer.NewContext(metadata.NewIncomingContext(context.Background(), forwarded), remote),
+			"address:203.0.113.1",
+		},
+		{
+			"proxied",
+			peer.NewContext(metadata.NewIncomingContext(context.Background(), forwarded), local),
+			"address:203.0.113.2",
+		},
+		{
+			"local",
+			peer.NewContext(context.Background(), local),
+			"address:127.0.0.1",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, CallerOf(tc.ctx))
+		})
+	}
+}
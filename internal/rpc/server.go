@@ -21,30 +21,38 @@ import (
 	"net"
 	"net/http"
 	"net/http/httputil"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	grpc_logrus "github.com/grpc-ecosystem/go-grpc-middleware/logging/logrus"
 	grpc_recovery "github.com/grpc-ecosystem/go-grpc-middleware/recovery"
-	grpc_tracing "github.com/grpc-ecosystem/go-grpc-middleware/tracing/opentracing"
 	grpc_validator "github.com/grpc-ecosystem/go-grpc-middleware/validator"
 	"github.com/grpc-ecosystem/grpc-gateway/runtime"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"go.opencensus.io/plugin/ocgrpc"
 	"go.opencensus.io/plugin/ochttp"
-	"go.opencensus.io/plugin/ochttp/propagation/b3"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/keepalive"
+	"open-match.dev/open-match/internal/auth"
 	"open-match.dev/open-match/internal/config"
 	"open-match.dev/open-match/internal/logging"
 	"open-match.dev/open-match/internal/telemetry"
+	"open-match.dev/open-match/internal/tenant"
 )
 
 const (
 	configNameServerPublicCertificateFile = "api.tls.certificateFile"
 	configNameServerPrivateKeyFile        = "api.tls.privateKey"
 	configNameServerRootCertificatePath   = "api.tls.rootCertificateFile"
+	// configNameRequireClientCertificate enables mutual TLS, where servers
+	// only accept clients presenting a certificate signed by the root CA.
+	configNameRequireClientCertificate = "api.tls.requireClientCertificate"
+	// configNameAuthorization, under the prefix of a service, maps client
+	// certificate identities to the methods of the service they may call.
+	configNameAuthorization = "authorization"
 )
 
 var (
@@ -68,6 +76,9 @@ type ServerParams struct {
 	handlersForGrpc        []GrpcHandler
 	handlersForGrpcProxy   []GrpcProxyHandler
 	handlersForHealthCheck []func(context.Context) error
+	// healthCheckInterval is how often the health checks are run for the
+	// gRPC health service, or 0 for the default.
+	healthCheckInterval time.Duration
 
 	grpcListener      net.Listener
 	grpcProxyListener net.Listener
@@ -80,9 +91,20 @@ type ServerParams struct {
 	// Private key in PEM format.
 	privateKeyFileData []byte
 
-	enableRPCLogging        bool
-	enableRPCPayloadLogging bool
-	enableMetrics           bool
+	requireClientCertificate bool
+	// authorizedClients maps client certificate identities to the methods
+	// they may call.  Every verified client may call every method if empty.
+	authorizedClients map[string][]string
+	// authenticator authenticates the bearer token of every call, if set.
+	authenticator auth.Authenticator
+	// rateLimiter limits the calls of each caller to each method, if set.
+	rateLimiter *rateLimiter
+
+	// rpcLogging follows the configuration, which stops following it once
+	// unsubscribeRPCLogging is called.
+	rpcLogging            rpcLogging
+	unsubscribeRPCLogging func()
+	enableMetrics         bool
 }
 
 // NewServerParamsFromConfig returns server Params initialized from the configuration file.
@@ -129,9 +151,26 @@ func NewServerParamsFromConfig(cfg config.View, prefix string, listen func(netwo
 		p.SetTLSConfiguration(rootPublicCertData, publicCertData, privateKeyData)
 	}
 
-	p.enableMetrics = cfg.GetBool(telemetry.ConfigNameEnableMetrics)
-	p.enableRPCLogging = cfg.GetBool(ConfigNameEnableRPCLogging)
-	p.enableRPCPayloadLogging = logging.IsDebugEnabled(cfg)
+	if cfg.GetBool(configNameRequireClientCertificate) {
+		if !p.usingTLS() {
+			p.invalidate()
+			return nil, errors.Errorf("%s requires %s and %s to be set", configNameRequireClientCertificate, configNameServerPublicCertificateFile, configNameServerPrivateKeyFile)
+		}
+		p.SetMutualTLS(cfg.GetStringMapStringSlice(prefix + "." + configNameAuthorization))
+	}
+
+	p.authenticator, err = auth.FromConfig(cfg, prefix)
+	if err != nil {
+		p.invalidate()
+		return nil, err
+	}
+
+	p.rateLimiter = newRateLimiterFromConfig(cfg, prefix)
+
+	p.enableMetrics = telemetry.InstrumentationEnabled(cfg)
+	p.unsubscribeRPCLogging = config.Subscribe(cfg, prefix+".rpcLogging", func(cfg config.View) {
+		p.rpcLogging.set(cfg.GetBool(ConfigNameEnableRPCLogging), logging.IsDebugEnabled(cfg))
+	})
 
 	return p, nil
 }
@@ -158,6 +197,24 @@ func (p *ServerParams) SetTLSConfiguration(rootCaPublicCertificateFileData []byt
 	return p
 }
 
+// SetMutualTLS configures the TLS server to require clients to present a
+// certificate signed by the root CA, and to only let them call the methods
+// authorized for the identities of their certificate.  Methods are either
+// the full gRPC method name, the method name alone, or "*" for any method.
+// If authorizedClients is empty, every verified client may call any method.
+func (p *ServerParams) SetMutualTLS(authorizedClients map[string][]string) *ServerParams {
+	p.requireClientCertificate = true
+	p.authorizedClients = authorizedClients
+	return p
+}
+
+// SetAuthenticator requires every call to present a bearer token accepted by
+// the authenticator, which identifies the caller.
+func (p *ServerParams) SetAuthenticator(a auth.Authenticator) *ServerParams {
+	p.authenticator = a
+	return p
+}
+
 // usingTLS returns true if a certificate is set.
 func (p *ServerParams) usingTLS() bool {
 	return len(p.publicCertificateFileData) > 0
@@ -174,6 +231,7 @@ func (p *ServerParams) AddHandleFunc(handlerFunc GrpcHandler, grpcProxyHandler G
 }
 
 // AddHealthCheckFunc adds a readiness probe to tell Kubernetes the service is able to handle traffic.
+// The probes also set the status served by the gRPC health service.
 func (p *ServerParams) AddHealthCheckFunc(handlerFunc func(context.Context) error) {
 	if handlerFunc != nil {
 		p.handlersForHealthCheck = append(p.handlersForHealthCheck, handlerFunc)
@@ -194,6 +252,7 @@ func (p *ServerParams) invalidate() {
 // All HTTP traffic is served from a common http.ServeMux.
 type Server struct {
 	serverWithProxy grpcServerWithProxy
+	params          *ServerParams
 }
 
 // grpcServerWithProxy this will go away when insecure.go and tls.go are merged into the same server.
@@ -204,6 +263,7 @@ type grpcServerWithProxy interface {
 
 // Start the gRPC+HTTP(s) REST server.
 func (s *Server) Start(p *ServerParams) error {
+	s.params = p
 	if p.usingTLS() {
 		s.serverWithProxy = newTLSServer(p.grpcListener, p.grpcProxyListener)
 	} else {
@@ -214,16 +274,97 @@ func (s *Server) Start(p *ServerParams) error {
 
 // Stop the gRPC+HTTP(s) REST server.
 func (s *Server) Stop() error {
+	if s.params != nil && s.params.unsubscribeRPCLogging != nil {
+		s.params.unsubscribeRPCLogging()
+	}
+	if s.params != nil && s.params.rateLimiter != nil {
+		s.params.rateLimiter.close()
+	}
 	return s.serverWithProxy.stop()
 }
 
+// rpcLogging is whether calls to the server are logged, and with their
+// payloads.  It is read on every call, for changes to the configuration to
+// apply to the running server.
+type rpcLogging struct {
+	enabled  int32
+	payloads int32
+}
+
+func (l *rpcLogging) set(enabled, payloads bool) {
+	atomic.StoreInt32(&l.enabled, boolToInt32(enabled))
+	atomic.StoreInt32(&l.payloads, boolToInt32(payloads))
+}
+
+func (l *rpcLogging) get() (enabled, payloads bool) {
+	return atomic.LoadInt32(&l.enabled) == 1, atomic.LoadInt32(&l.payloads) == 1
+}
+
+func boolToInt32(b bool) int32 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func (l *rpcLogging) unaryServerInterceptor() grpc.UnaryServerInterceptor {
+	plain := grpc_logrus.UnaryServerInterceptor(grpcServerLogger())
+	payload := grpc_logrus.PayloadUnaryServerInterceptor(grpcServerLogger(), logEverythingFromServer)
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		enabled, payloads := l.get()
+		switch {
+		case !enabled:
+			return handler(ctx, req)
+		case payloads:
+			return payload(ctx, req, info, handler)
+		default:
+			return plain(ctx, req, info, handler)
+		}
+	}
+}
+
+func (l *rpcLogging) streamServerInterceptor() grpc.StreamServerInterceptor {
+	plain := grpc_logrus.StreamServerInterceptor(grpcServerLogger())
+	payload := grpc_logrus.PayloadStreamServerInterceptor(grpcServerLogger(), logEverythingFromServer)
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		enabled, payloads := l.get()
+		switch {
+		case !enabled:
+			return handler(srv, stream)
+		case payloads:
+			return payload(srv, stream, info, handler)
+		default:
+			return plain(srv, stream, info, handler)
+		}
+	}
+}
+
+func grpcServerLogger() *logrus.Entry {
+	grpcLogger := logrus.WithFields(logrus.Fields{
+		"app":       "openmatch",
+		"component": "grpc.server",
+	})
+	grpcLogger.Level = logrus.DebugLevel
+	return grpcLogger
+}
+
+func logEverythingFromServer(_ context.Context, _ string, _ interface{}) bool {
+	return true
+}
+
 type loggingHTTPHandler struct {
-	handler     http.Handler
-	logPayloads bool
+	handler http.Handler
+	logging *rpcLogging
 }
 
 func (l *loggingHTTPHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
-	dumpReqLog, dumpReqErr := httputil.DumpRequest(req, l.logPayloads)
+	enabled, logPayloads := l.logging.get()
+	if !enabled {
+		l.handler.ServeHTTP(w, req)
+		return
+	}
+
+	dumpReqLog, dumpReqErr := httputil.DumpRequest(req, logPayloads)
 	fields := logrus.Fields{
 		"method": req.Method,
 		"url":    req.URL,
@@ -241,50 +382,60 @@ func instrumentHTTPHandler(handler http.Handler, params *ServerParams) http.Hand
 	if params.enableMetrics {
 		handler = &ochttp.Handler{
 			Handler:     handler,
-			Propagation: &b3.HTTPFormat{},
+			Propagation: &httpFormat{},
 		}
 	}
-	if params.enableRPCLogging {
-		handler = &loggingHTTPHandler{
-			handler:     handler,
-			logPayloads: params.enableRPCPayloadLogging,
-		}
+	return &loggingHTTPHandler{
+		handler: handler,
+		logging: &params.rpcLogging,
 	}
-	return handler
 }
 
-func newGRPCServerOptions(params *ServerParams) []grpc.ServerOption {
+// matchIncomingHeader forwards the tenant and client identity HTTP headers to
+// the gRPC service, in addition to the headers forwarded by default.
+func matchIncomingHeader(key string) (string, bool) {
+	if strings.EqualFold(key, tenant.MetadataKey) {
+		return tenant.MetadataKey, true
+	}
+	if strings.EqualFold(key, clientIdentityHeader) {
+		return clientIdentityHeader, true
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+func newGRPCServerOptions(params *ServerParams, authz *authorizer) []grpc.ServerOption {
 	opts := []grpc.ServerOption{}
 	si := []grpc.StreamServerInterceptor{
 		grpc_recovery.StreamServerInterceptor(),
-		grpc_validator.StreamServerInterceptor(),
-		grpc_tracing.StreamServerInterceptor(),
 	}
 	ui := []grpc.UnaryServerInterceptor{
 		grpc_recovery.UnaryServerInterceptor(),
-		grpc_validator.UnaryServerInterceptor(),
-		grpc_tracing.UnaryServerInterceptor(),
 	}
-	if params.enableRPCLogging {
-		grpcLogger := logrus.WithFields(logrus.Fields{
-			"app":       "openmatch",
-			"component": "grpc.server",
-		})
-		grpcLogger.Level = logrus.DebugLevel
-		if params.enableRPCPayloadLogging {
-			logEverythingFromServer := func(_ context.Context, _ string, _ interface{}) bool {
-				return true
-			}
-			si = append(si, grpc_logrus.PayloadStreamServerInterceptor(grpcLogger, logEverythingFromServer))
-			ui = append(ui, grpc_logrus.PayloadUnaryServerInterceptor(grpcLogger, logEverythingFromServer))
-		} else {
-			si = append(si, grpc_logrus.StreamServerInterceptor(grpcLogger))
-			ui = append(ui, grpc_logrus.UnaryServerInterceptor(grpcLogger))
-		}
+	if authz != nil {
+		si = append(si, exemptHealthStream(authz.streamServerInterceptor()))
+		ui = append(ui, exemptHealthUnary(authz.unaryServerInterceptor()))
+	}
+	if params.authenticator != nil {
+		si = append(si, exemptHealthStream(auth.StreamServerInterceptor(params.authenticator)))
+		ui = append(ui, exemptHealthUnary(auth.UnaryServerInterceptor(params.authenticator)))
 	}
+	if params.rateLimiter != nil {
+		si = append(si, exemptHealthStream(params.rateLimiter.streamServerInterceptor()))
+		ui = append(ui, exemptHealthUnary(params.rateLimiter.unaryServerInterceptor()))
+	}
+	si = append(si,
+		grpc_validator.StreamServerInterceptor(),
+		tenant.StreamServerInterceptor(),
+	)
+	ui = append(ui,
+		grpc_validator.UnaryServerInterceptor(),
+		tenant.UnaryServerInterceptor(),
+	)
+	si = append(si, params.rpcLogging.streamServerInterceptor())
+	ui = append(ui, params.rpcLogging.unaryServerInterceptor())
 
 	if params.enableMetrics {
-		opts = append(opts, grpc.StatsHandler(&ocgrpc.ServerHandler{}))
+		opts = append(opts, grpc.StatsHandler(serverStatsHandler{&ocgrpc.ServerHandler{}}))
 	}
 
 	return append(opts,
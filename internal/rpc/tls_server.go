@@ -25,6 +25,7 @@ import (
 	"github.com/pkg/errors"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
 	"open-match.dev/open-match/internal/telemetry"
 )
 
@@ -36,6 +37,7 @@ const (
 type tlsServer struct {
 	grpcListener net.Listener
 	grpcServer   *grpc.Server
+	grpcHealth   *grpcHealth
 
 	httpListener net.Listener
 	httpMux      *http.ServeMux
@@ -44,8 +46,17 @@ type tlsServer struct {
 }
 
 func (s *tlsServer) start(params *ServerParams) error {
+	proxyToken, err := newProxyToken()
+	if err != nil {
+		return errors.WithStack(err)
+	}
 	s.httpMux = params.ServeMux
-	s.proxyMux = runtime.NewServeMux()
+	s.proxyMux = runtime.NewServeMux(
+		runtime.WithIncomingHeaderMatcher(matchIncomingHeader),
+		runtime.WithMetadata(func(context.Context, *http.Request) metadata.MD {
+			return metadata.Pairs(proxyTokenKey, proxyToken)
+		}),
+	)
 
 	_, grpcPort, err := net.SplitHostPort(s.grpcListener.Addr().String())
 	if err != nil {
@@ -66,8 +77,17 @@ func (s *tlsServer) start(params *ServerParams) error {
 	if err != nil {
 		return errors.WithStack(err)
 	}
-	creds := credentials.NewServerTLSFromCert(grpcTLSCertificate)
-	serverOpts := newGRPCServerOptions(params)
+	clientAuth, httpClientAuth := tls.NoClientCert, tls.NoClientCert
+	if params.requireClientCertificate {
+		clientAuth, httpClientAuth = tls.RequireAndVerifyClientCert, tls.VerifyClientCertIfGiven
+	}
+	creds := credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{*grpcTLSCertificate},
+		ClientCAs:    rootCaCert,
+		ClientAuth:   clientAuth,
+	})
+	authz := newAuthorizer(params.authorizedClients, proxyToken)
+	serverOpts := newGRPCServerOptions(params, authz)
 	serverOpts = append(serverOpts, grpc.Creds(creds))
 	s.grpcServer = grpc.NewServer(serverOpts...)
 
@@ -75,6 +95,8 @@ func (s *tlsServer) start(params *ServerParams) error {
 	for _, handlerFunc := range params.handlersForGrpc {
 		handlerFunc(s.grpcServer)
 	}
+	s.grpcHealth = newGRPCHealth(params)
+	s.grpcHealth.start(s.grpcServer)
 
 	go func() {
 		serverLogger.Infof("Serving gRPC-TLS: %s", s.grpcListener.Addr().String())
@@ -88,8 +110,14 @@ func (s *tlsServer) start(params *ServerParams) error {
 	// Bind gRPC handlers
 	ctx, cancel := context.WithCancel(context.Background())
 
-	httpsToGrpcProxyOptions := newGRPCDialOptions(params.enableMetrics, params.enableRPCLogging, params.enableRPCPayloadLogging)
-	httpsToGrpcProxyOptions = append(httpsToGrpcProxyOptions, grpc.WithTransportCredentials(credentials.NewClientTLSFromCert(certPoolForGrpcEndpoint, "")))
+	rpcLogging, rpcPayloadLogging := params.rpcLogging.get()
+	httpsToGrpcProxyOptions := newGRPCDialOptions(params.enableMetrics, rpcLogging, rpcPayloadLogging)
+	// The proxy presents the server's own certificate, in case the gRPC
+	// server requires client certificates.
+	httpsToGrpcProxyOptions = append(httpsToGrpcProxyOptions, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{
+		RootCAs:      certPoolForGrpcEndpoint,
+		Certificates: []tls.Certificate{*grpcTLSCertificate},
+	})))
 
 	for _, handlerFunc := range params.handlersForGrpcProxy {
 		if err = handlerFunc(ctx, s.proxyMux, grpcAddress, httpsToGrpcProxyOptions); err != nil {
@@ -100,15 +128,20 @@ func (s *tlsServer) start(params *ServerParams) error {
 
 	// Bind HTTPS handlers
 	s.httpMux.Handle(telemetry.HealthCheckEndpoint, telemetry.NewHealthCheck(params.handlersForHealthCheck))
-	s.httpMux.Handle("/", s.proxyMux)
+	if params.requireClientCertificate {
+		s.httpMux.Handle("/", forwardClientIdentity(s.proxyMux))
+	} else {
+		s.httpMux.Handle("/", s.proxyMux)
+	}
 	s.httpServer = &http.Server{
 		Addr:    s.httpListener.Addr().String(),
 		Handler: instrumentHTTPHandler(s.httpMux, params),
 		TLSConfig: &tls.Config{
 			Certificates: []tls.Certificate{*grpcTLSCertificate},
 			ClientCAs:    rootCaCert,
-			// Commented as open-match does not support mutual authentication yet
-			// ClientAuth:   tls.RequireAndVerifyClientCert,
+			// Client certificates are verified if given, while only the proxied
+			// API requires one, so that health checks keep working.
+			ClientAuth: httpClientAuth,
 			NextProtos: []string{http2WithTLSVersionID}, // https://github.com/grpc-ecosystem/grpc-gateway/issues/220
 		},
 	}
@@ -128,6 +161,7 @@ func (s *tlsServer) start(params *ServerParams) error {
 func (s *tlsServer) stop() error {
 	// the servers also close their respective listeners.
 	err := s.httpServer.Shutdown(context.Background())
+	s.grpcHealth.stop()
 	s.grpcServer.GracefulStop()
 	return err
 }
@@ -19,13 +19,20 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"open-match.dev/open-match/internal/telemetry"
 	shellTesting "open-match.dev/open-match/internal/testing"
+	utilTesting "open-match.dev/open-match/internal/util/testing"
 	"open-match.dev/open-match/pkg/pb"
 	certgenTesting "open-match.dev/open-match/tools/certgen/testing"
 )
@@ -128,3 +135,101 @@ func runTestStartStopTLSServer(t *testing.T, tp *tlsServerTestParams) {
 	}
 	runGrpcWithProxyTests(t, assert, s, conn, httpClient, httpsEndpoint)
 }
+
+func TestMutualTLSAuthorization(t *testing.T) {
+	assert := assert.New(t)
+	grpcL := MustListen()
+	proxyL := MustListen()
+	grpcAddress := fmt.Sprintf("localhost:%s", MustGetPortNumber(grpcL))
+	proxyAddress := fmt.Sprintf("localhost:%s", MustGetPortNumber(proxyL))
+	allHostnames := []string{grpcAddress, proxyAddress}
+	rootPub, rootPriv, err := certgenTesting.CreateRootCertificateAndPrivateKeyForTesting(allHostnames)
+	assert.Nil(err)
+	pub, priv, err := certgenTesting.CreateDerivedCertificateAndPrivateKeyForTesting(rootPub, rootPriv, allHostnames)
+	assert.Nil(err)
+
+	serverParams := NewServerParamsFromListeners(grpcL, proxyL)
+	serverParams.AddHandleFunc(func(s *grpc.Server) {
+		pb.RegisterFrontendServiceServer(s, &shellTesting.FakeFrontend{})
+	}, pb.RegisterFrontendServiceHandlerFromEndpoint)
+	serverParams.SetTLSConfiguration(rootPub, pub, priv)
+	serverParams.SetMutualTLS(map[string][]string{
+		"allowed.example": {"CreateTicket"},
+	})
+	s := newTLSServer(serverParams.grpcListener, serverParams.grpcProxyListener)
+	defer s.stop()
+	assert.Nil(s.start(serverParams))
+
+	clientParams := func(hostname string) *ClientParams {
+		params := &ClientParams{TrustedCertificate: rootPub}
+		if hostname != "" {
+			params.Certificate, params.PrivateKey, err = certgenTesting.CreateDerivedCertificateAndPrivateKeyForTesting(rootPub, rootPriv, []string{hostname})
+			assert.Nil(err)
+		}
+		return params
+	}
+
+	ctx := utilTesting.NewContext(t)
+	for hostname, wantCreate := range map[string]codes.Code{
+		"":                codes.Unavailable,
+		"allowed.example": codes.OK,
+		"denied.example":  codes.PermissionDenied,
+	} {
+		params := clientParams(hostname)
+		params.Address = grpcAddress
+		conn, err := GRPCClientFromParams(params)
+		assert.Nil(err)
+		defer conn.Close()
+
+		fe := pb.NewFrontendServiceClient(conn)
+		_, err = fe.CreateTicket(ctx, &pb.CreateTicketRequest{})
+		assert.Equal(wantCreate, status.Code(err), hostname)
+		if hostname == "allowed.example" {
+			_, err = fe.GetTicket(ctx, &pb.GetTicketRequest{TicketId: "1"})
+			assert.Equal(codes.PermissionDenied, status.Code(err))
+		}
+		if hostname == "denied.example" {
+			// Every verified client may check the health of the server.
+			_, err = healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+			assert.Nil(err)
+		}
+	}
+
+	// Clients sharing the certificate of the server can't forge the identity
+	// forwarded by its proxy.
+	conn, err := GRPCClientFromParams(&ClientParams{
+		Address:            grpcAddress,
+		TrustedCertificate: rootPub,
+		Certificate:        pub,
+		PrivateKey:         priv,
+	})
+	assert.Nil(err)
+	defer conn.Close()
+	forgedCtx := metadata.AppendToOutgoingContext(ctx, clientIdentityHeader, "allowed.example", proxyTokenKey, "guessed")
+	_, err = pb.NewFrontendServiceClient(conn).CreateTicket(forgedCtx, &pb.CreateTicketRequest{})
+	assert.Equal(codes.PermissionDenied, status.Code(err))
+
+	httpStatus := func(hostname, path, forgedIdentity string) int {
+		params := clientParams(hostname)
+		params.Address = proxyAddress
+		client, baseURL, err := HTTPClientFromParams(params)
+		assert.Nil(err)
+
+		req, err := http.NewRequest(http.MethodPost, baseURL+path, strings.NewReader("{}"))
+		assert.Nil(err)
+		if forgedIdentity != "" {
+			req.Header.Set(clientIdentityHeader, forgedIdentity)
+		}
+		resp, err := client.Do(req)
+		assert.Nil(err)
+		defer resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	assert.Equal(http.StatusOK, httpStatus("allowed.example", "/v1/frontendservice/tickets", ""))
+	assert.Equal(http.StatusUnauthorized, httpStatus("", "/v1/frontendservice/tickets", ""))
+	assert.Equal(http.StatusForbidden, httpStatus("denied.example", "/v1/frontendservice/tickets", ""))
+	assert.Equal(http.StatusForbidden, httpStatus("denied.example", "/v1/frontendservice/tickets", "allowed.example"))
+	// Health checks don't require a client certificate.
+	assert.Equal(http.StatusOK, httpStatus("", telemetry.HealthCheckEndpoint, ""))
+}
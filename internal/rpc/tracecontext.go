@@ -0,0 +1,107 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rpc
+
+import (
+	"context"
+	"net/http"
+
+	"go.opencensus.io/plugin/ocgrpc"
+	"go.opencensus.io/plugin/ochttp/propagation/b3"
+	"go.opencensus.io/plugin/ochttp/propagation/tracecontext"
+	"go.opencensus.io/trace"
+	"go.opencensus.io/trace/propagation"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/stats"
+)
+
+// Trace context is propagated in the W3C traceparent and tracestate headers,
+// https://www.w3.org/TR/trace-context/, so that traces of Open Match join the
+// traces of callers instrumented with OpenTelemetry.  The OpenCensus headers,
+// grpc-trace-bin and B3, are still propagated for existing callers.
+const (
+	traceparentKey   = "traceparent"
+	tracestateKey    = "tracestate"
+	grpcTraceContext = "grpc-trace-bin"
+)
+
+var w3cFormat = &tracecontext.HTTPFormat{}
+
+// httpFormat propagates the trace context of HTTP requests in both W3C and B3
+// headers, preferring W3C.
+type httpFormat struct {
+	b3 b3.HTTPFormat
+}
+
+func (f *httpFormat) SpanContextFromRequest(req *http.Request) (trace.SpanContext, bool) {
+	if sc, ok := w3cFormat.SpanContextFromRequest(req); ok {
+		return sc, true
+	}
+	return f.b3.SpanContextFromRequest(req)
+}
+
+func (f *httpFormat) SpanContextToRequest(sc trace.SpanContext, req *http.Request) {
+	w3cFormat.SpanContextToRequest(sc, req)
+	f.b3.SpanContextToRequest(sc, req)
+}
+
+// serverStatsHandler is an ocgrpc.ServerHandler which also continues the
+// traces of calls only propagating the W3C trace context.
+type serverStatsHandler struct {
+	*ocgrpc.ServerHandler
+}
+
+func (h serverStatsHandler) TagRPC(ctx context.Context, rti *stats.RPCTagInfo) context.Context {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if ok && len(md.Get(grpcTraceContext)) == 0 {
+		if sc, ok := spanContextFromMetadata(md); ok {
+			md = md.Copy()
+			md.Set(grpcTraceContext, string(propagation.Binary(sc)))
+			ctx = metadata.NewIncomingContext(ctx, md)
+		}
+	}
+	return h.ServerHandler.TagRPC(ctx, rti)
+}
+
+// clientStatsHandler is an ocgrpc.ClientHandler which also propagates the W3C
+// trace context of calls.
+type clientStatsHandler struct {
+	*ocgrpc.ClientHandler
+}
+
+func (h clientStatsHandler) TagRPC(ctx context.Context, rti *stats.RPCTagInfo) context.Context {
+	ctx = h.ClientHandler.TagRPC(ctx, rti)
+	span := trace.FromContext(ctx)
+	if span == nil {
+		return ctx
+	}
+	req := &http.Request{Header: make(http.Header)}
+	w3cFormat.SpanContextToRequest(span.SpanContext(), req)
+	kv := []string{traceparentKey, req.Header.Get(traceparentKey)}
+	if ts := req.Header.Get(tracestateKey); ts != "" {
+		kv = append(kv, tracestateKey, ts)
+	}
+	return metadata.AppendToOutgoingContext(ctx, kv...)
+}
+
+func spanContextFromMetadata(md metadata.MD) (trace.SpanContext, bool) {
+	req := &http.Request{Header: make(http.Header)}
+	for _, key := range []string{traceparentKey, tracestateKey} {
+		for _, v := range md.Get(key) {
+			req.Header.Add(key, v)
+		}
+	}
+	return w3cFormat.SpanContextFromRequest(req)
+}
@@ -0,0 +1,94 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rpc
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/plugin/ocgrpc"
+	"go.opencensus.io/trace"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/stats"
+)
+
+const testTraceparent = "00-0102030405060708090a0b0c0d0e0f10-0102030405060708-01"
+
+var testSpanContext = trace.SpanContext{
+	TraceID:      trace.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+	SpanID:       trace.SpanID{1, 2, 3, 4, 5, 6, 7, 8},
+	TraceOptions: 1,
+}
+
+func TestServerStatsHandlerW3C(t *testing.T) {
+	require := require.New(t)
+	h := serverStatsHandler{&ocgrpc.ServerHandler{}}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(traceparentKey, testTraceparent))
+	ctx = h.TagRPC(ctx, &stats.RPCTagInfo{FullMethodName: createTicketMethod})
+
+	span := trace.FromContext(ctx)
+	require.NotNil(span)
+	defer span.End()
+	assert.Equal(t, testSpanContext.TraceID, span.SpanContext().TraceID)
+	assert.NotEqual(t, testSpanContext.SpanID, span.SpanContext().SpanID)
+}
+
+func TestClientStatsHandlerW3C(t *testing.T) {
+	require := require.New(t)
+	h := clientStatsHandler{&ocgrpc.ClientHandler{}}
+
+	ctx, parent := trace.StartSpanWithRemoteParent(context.Background(), "parent", testSpanContext)
+	defer parent.End()
+	ctx = h.TagRPC(ctx, &stats.RPCTagInfo{FullMethodName: createTicketMethod})
+
+	span := trace.FromContext(ctx)
+	require.NotNil(span)
+	defer span.End()
+	md, ok := metadata.FromOutgoingContext(ctx)
+	require.True(ok)
+	require.Len(md.Get(traceparentKey), 1)
+	assert.Len(t, md.Get(grpcTraceContext), 1)
+
+	sc, ok := spanContextFromMetadata(md)
+	require.True(ok)
+	assert.Equal(t, span.SpanContext().TraceID, sc.TraceID)
+	assert.Equal(t, span.SpanContext().SpanID, sc.SpanID)
+	assert.Equal(t, testSpanContext.TraceID, sc.TraceID)
+}
+
+func TestHTTPFormat(t *testing.T) {
+	f := &httpFormat{}
+
+	req := &http.Request{Header: make(http.Header)}
+	f.SpanContextToRequest(testSpanContext, req)
+	assert.Equal(t, testTraceparent, req.Header.Get(traceparentKey))
+	assert.NotEmpty(t, req.Header.Get("X-B3-TraceId"))
+
+	// B3 headers are read from callers not propagating W3C trace context.
+	req.Header.Del(traceparentKey)
+	sc, ok := f.SpanContextFromRequest(req)
+	assert.True(t, ok)
+	assert.Equal(t, testSpanContext.TraceID, sc.TraceID)
+
+	// W3C trace context is preferred.
+	req.Header.Set(traceparentKey, "00-1102030405060708090a0b0c0d0e0f10-0102030405060708-01")
+	sc, ok = f.SpanContextFromRequest(req)
+	assert.True(t, ok)
+	assert.Equal(t, byte(0x11), sc.TraceID[0])
+}
@@ -16,6 +16,7 @@ package statestore
 
 import (
 	"context"
+	"time"
 
 	"go.opencensus.io/trace"
 	"open-match.dev/open-match/pkg/pb"
@@ -53,12 +54,42 @@ func (is *instrumentedService) DeleteTicket(ctx context.Context, id string) erro
 	return is.s.DeleteTicket(ctx, id)
 }
 
+func (is *instrumentedService) SetTicketOwner(ctx context.Context, id string, owner string, maxTickets int) (bool, error) {
+	ctx, span := trace.StartSpan(ctx, "statestore/instrumented.SetTicketOwner")
+	defer span.End()
+	return is.s.SetTicketOwner(ctx, id, owner, maxTickets)
+}
+
+func (is *instrumentedService) GetTicketOwner(ctx context.Context, id string) (string, error) {
+	ctx, span := trace.StartSpan(ctx, "statestore/instrumented.GetTicketOwner")
+	defer span.End()
+	return is.s.GetTicketOwner(ctx, id)
+}
+
+func (is *instrumentedService) RecordTicketEvents(ctx context.Context, event TicketEvent, times map[string]time.Time) error {
+	ctx, span := trace.StartSpan(ctx, "statestore/instrumented.RecordTicketEvents")
+	defer span.End()
+	return is.s.RecordTicketEvents(ctx, event, times)
+}
+
+func (is *instrumentedService) GetTicketLifecycles(ctx context.Context, ids []string) (map[string]*TicketLifecycle, error) {
+	ctx, span := trace.StartSpan(ctx, "statestore/instrumented.GetTicketLifecycles")
+	defer span.End()
+	return is.s.GetTicketLifecycles(ctx, ids)
+}
+
 func (is *instrumentedService) IndexTicket(ctx context.Context, ticket *pb.Ticket) error {
 	ctx, span := trace.StartSpan(ctx, "statestore/instrumented.IndexTicket")
 	defer span.End()
 	return is.s.IndexTicket(ctx, ticket)
 }
 
+func (is *instrumentedService) IndexTicketWithinLimit(ctx context.Context, ticket *pb.Ticket, maxTickets int) (bool, error) {
+	ctx, span := trace.StartSpan(ctx, "statestore/instrumented.IndexTicketWithinLimit")
+	defer span.End()
+	return is.s.IndexTicketWithinLimit(ctx, ticket, maxTickets)
+}
+
 func (is *instrumentedService) DeindexTicket(ctx context.Context, id string) error {
 	ctx, span := trace.StartSpan(ctx, "statestore/instrumented.DeindexTicket")
 	defer span.End()
@@ -77,6 +108,12 @@ func (is *instrumentedService) GetIndexedIDSet(ctx context.Context) (map[string]
 	return is.s.GetIndexedIDSet(ctx)
 }
 
+func (is *instrumentedService) CountIndexedTickets(ctx context.Context) (int, error) {
+	ctx, span := trace.StartSpan(ctx, "statestore/instrumented.CountIndexedTickets")
+	defer span.End()
+	return is.s.CountIndexedTickets(ctx)
+}
+
 func (is *instrumentedService) UpdateAssignments(ctx context.Context, req *pb.AssignTicketsRequest) (*pb.AssignTicketsResponse, error) {
 	ctx, span := trace.StartSpan(ctx, "statestore/instrumented.UpdateAssignments")
 	defer span.End()
@@ -106,3 +143,93 @@ func (is *instrumentedService) ReleaseAllTickets(ctx context.Context) error {
 	defer span.End()
 	return is.s.ReleaseAllTickets(ctx)
 }
+
+func (is *instrumentedService) SetMatchFunctionModule(ctx context.Context, name string, module []byte) error {
+	ctx, span := trace.StartSpan(ctx, "statestore/instrumented.SetMatchFunctionModule")
+	defer span.End()
+	return is.s.SetMatchFunctionModule(ctx, name, module)
+}
+
+func (is *instrumentedService) GetMatchFunctionModule(ctx context.Context, name string) ([]byte, error) {
+	ctx, span := trace.StartSpan(ctx, "statestore/instrumented.GetMatchFunctionModule")
+	defer span.End()
+	return is.s.GetMatchFunctionModule(ctx, name)
+}
+
+func (is *instrumentedService) CreateMatch(ctx context.Context, record *pb.MatchRecord) error {
+	ctx, span := trace.StartSpan(ctx, "statestore/instrumented.CreateMatch")
+	defer span.End()
+	return is.s.CreateMatch(ctx, record)
+}
+
+func (is *instrumentedService) GetMatch(ctx context.Context, id string) (*pb.MatchRecord, error) {
+	ctx, span := trace.StartSpan(ctx, "statestore/instrumented.GetMatch")
+	defer span.End()
+	return is.s.GetMatch(ctx, id)
+}
+
+func (is *instrumentedService) GetMatches(ctx context.Context, ids []string) (map[string]*pb.MatchRecord, error) {
+	ctx, span := trace.StartSpan(ctx, "statestore/instrumented.GetMatches")
+	defer span.End()
+	return is.s.GetMatches(ctx, ids)
+}
+
+func (is *instrumentedService) GetUnassignedMatches(ctx context.Context) ([]*pb.MatchRecord, error) {
+	ctx, span := trace.StartSpan(ctx, "statestore/instrumented.GetUnassignedMatches")
+	defer span.End()
+	return is.s.GetUnassignedMatches(ctx)
+}
+
+func (is *instrumentedService) UpdateMatchState(ctx context.Context, id string, from, to pb.MatchRecord_State) (*pb.MatchRecord, error) {
+	ctx, span := trace.StartSpan(ctx, "statestore/instrumented.UpdateMatchState")
+	defer span.End()
+	return is.s.UpdateMatchState(ctx, id, from, to)
+}
+
+func (is *instrumentedService) UpdateMatch(ctx context.Context, id string, update func(*pb.MatchRecord) error) (*pb.MatchRecord, error) {
+	ctx, span := trace.StartSpan(ctx, "statestore/instrumented.UpdateMatch")
+	defer span.End()
+	return is.s.UpdateMatch(ctx, id, update)
+}
+
+func (is *instrumentedService) AssignMatch(ctx context.Context, id string, assignment *pb.Assignment) (*pb.MatchRecord, []*pb.AssignmentFailure, error) {
+	ctx, span := trace.StartSpan(ctx, "statestore/instrumented.AssignMatch")
+	defer span.End()
+	return is.s.AssignMatch(ctx, id, assignment)
+}
+
+func (is *instrumentedService) ReleaseMatch(ctx context.Context, id string) (*pb.MatchRecord, error) {
+	ctx, span := trace.StartSpan(ctx, "statestore/instrumented.ReleaseMatch")
+	defer span.End()
+	return is.s.ReleaseMatch(ctx, id)
+}
+
+func (is *instrumentedService) GetReadyCheckMatchID(ctx context.Context, ticketID string) (string, error) {
+	ctx, span := trace.StartSpan(ctx, "statestore/instrumented.GetReadyCheckMatchID")
+	defer span.End()
+	return is.s.GetReadyCheckMatchID(ctx, ticketID)
+}
+
+func (is *instrumentedService) GetTicketMatchIDs(ctx context.Context, ticketIDs []string) ([]string, error) {
+	ctx, span := trace.StartSpan(ctx, "statestore/instrumented.GetTicketMatchIDs")
+	defer span.End()
+	return is.s.GetTicketMatchIDs(ctx, ticketIDs)
+}
+
+func (is *instrumentedService) AcquireLease(ctx context.Context, name, holder string, ttl time.Duration) (bool, error) {
+	ctx, span := trace.StartSpan(ctx, "statestore/instrumented.AcquireLease")
+	defer span.End()
+	return is.s.AcquireLease(ctx, name, holder, ttl)
+}
+
+func (is *instrumentedService) GetLeaseHolder(ctx context.Context, name string) (string, error) {
+	ctx, span := trace.StartSpan(ctx, "statestore/instrumented.GetLeaseHolder")
+	defer span.End()
+	return is.s.GetLeaseHolder(ctx, name)
+}
+
+func (is *instrumentedService) ReleaseLease(ctx context.Context, name, holder string) error {
+	ctx, span := trace.StartSpan(ctx, "statestore/instrumented.ReleaseLease")
+	defer span.End()
+	return is.s.ReleaseLease(ctx, name, holder)
+}
@@ -16,12 +16,38 @@ package statestore
 
 import (
 	"context"
+	"time"
 
 	"open-match.dev/open-match/internal/config"
 	"open-match.dev/open-match/internal/telemetry"
 	"open-match.dev/open-match/pkg/pb"
 )
 
+// SynchronizerLease is the name of the lease held by the synchronizer replica
+// which is currently running cycles, when leader election is enabled.
+const SynchronizerLease = "synchronizer"
+
+// TicketEvent is a step of the lifecycle of a Ticket.
+type TicketEvent string
+
+// Events of the lifecycle of a Ticket.
+const (
+	TicketCreated  TicketEvent = "created"
+	TicketProposed TicketEvent = "proposed"
+	TicketMatched  TicketEvent = "matched"
+	TicketAssigned TicketEvent = "assigned"
+	TicketDeleted  TicketEvent = "deleted"
+)
+
+// TicketLifecycle is the time a Ticket first reached each event of its
+// lifecycle, and the match which consumed it.
+type TicketLifecycle struct {
+	Times    map[TicketEvent]time.Time
+	MatchID  string
+	Profile  string
+	Function string
+}
+
 // Service is a generic interface for talking to a storage backend.
 type Service interface {
 	// HealthCheck indicates if the database is reachable.
@@ -36,15 +62,34 @@ type Service interface {
 	// DeleteTicket removes the Ticket with the specified id from state storage. This method succeeds if the Ticket does not exist.
 	DeleteTicket(ctx context.Context, id string) error
 
+	// SetTicketOwner records the identity which created the Ticket, unless the owner already has maxTickets Tickets, which is unlimited if 0.  Returns false, without recording the owner, if the owner has too many Tickets.  Tickets which were deleted or expired no longer count.
+	SetTicketOwner(ctx context.Context, id string, owner string, maxTickets int) (bool, error)
+
+	// GetTicketOwner returns the identity which created the Ticket, or "" if
+	// it has no owner.
+	GetTicketOwner(ctx context.Context, id string) (string, error)
+
+	// RecordTicketEvents records the time each of the Tickets reached the event, unless it already did.  The lifecycles expire after ticketLifecycleTimeout without events.
+	RecordTicketEvents(ctx context.Context, event TicketEvent, times map[string]time.Time) error
+
+	// GetTicketLifecycles returns the lifecycles of the Tickets by id.  Tickets without events are silently ignored.
+	GetTicketLifecycles(ctx context.Context, ids []string) (map[string]*TicketLifecycle, error)
+
 	// IndexTicket adds the ticket to the index.
 	IndexTicket(ctx context.Context, ticket *pb.Ticket) error
 
+	// IndexTicketWithinLimit adds the ticket to the index, unless maxTickets are already indexed, or regardless if maxTickets is 0.  Returns true if the ticket was indexed.
+	IndexTicketWithinLimit(ctx context.Context, ticket *pb.Ticket, maxTickets int) (bool, error)
+
 	// DeindexTicket removes specified ticket from the index. The Ticket continues to exist.
 	DeindexTicket(ctx context.Context, id string) error
 
 	// GetIndexedIDSet returns the ids of all tickets currently indexed.
 	GetIndexedIDSet(ctx context.Context) (map[string]struct{}, error)
 
+	// CountIndexedTickets returns the number of tickets currently indexed.
+	CountIndexedTickets(ctx context.Context) (int, error)
+
 	// GetTickets returns multiple tickets from storage.  Missing tickets are
 	// silently ignored.
 	GetTickets(ctx context.Context, ids []string) ([]*pb.Ticket, error)
@@ -64,6 +109,51 @@ type Service interface {
 	// ReleaseAllTickets releases all pending tickets back to active
 	ReleaseAllTickets(ctx context.Context) error
 
+	// SetMatchFunctionModule stores a WebAssembly match function module under the name, replacing any existing module.
+	SetMatchFunctionModule(ctx context.Context, name string, module []byte) error
+
+	// GetMatchFunctionModule gets the WebAssembly match function module with the specified name. This method fails if the module does not exist.
+	GetMatchFunctionModule(ctx context.Context, name string) ([]byte, error)
+
+	// CreateMatch stores the record of a match returned by FetchMatches, replacing any existing record, and records its Tickets as matched at the create time of the record in their lifecycles.  The record expires after matchRecordTimeout.
+	CreateMatch(ctx context.Context, record *pb.MatchRecord) error
+
+	// GetMatch gets the record of the match with the specified id. This method fails if the record does not exist or has expired.
+	GetMatch(ctx context.Context, id string) (*pb.MatchRecord, error)
+
+	// GetMatches gets the records of the matches with the specified ids.  Matches without a record, or whose record expired, are silently ignored.
+	GetMatches(ctx context.Context, ids []string) (map[string]*pb.MatchRecord, error)
+
+	// GetUnassignedMatches returns the records of all unassigned matches which have not expired.
+	GetUnassignedMatches(ctx context.Context) ([]*pb.MatchRecord, error)
+
+	// UpdateMatchState moves the record of the match with the specified id from one state to another, returning the updated record. This method fails if the record does not exist, or is not in the from state.
+	UpdateMatchState(ctx context.Context, id string, from, to pb.MatchRecord_State) (*pb.MatchRecord, error)
+
+	// UpdateMatch atomically applies the update to the record of the match with the specified id, returning the updated record. The update may be called multiple times if the record is concurrently updated. This method fails if the record does not exist, or the update fails.
+	UpdateMatch(ctx context.Context, id string, update func(*pb.MatchRecord) error) (*pb.MatchRecord, error)
+
+	// AssignMatch assigns the Tickets of the unassigned match with the specified id, and moves its record to assigned, in one transaction.  The assigned Tickets are deindexed and removed from the ignore list.  Tickets which can't be assigned are returned as failures.  This method fails if the record does not exist, or is not unassigned.
+	AssignMatch(ctx context.Context, id string, assignment *pb.Assignment) (*pb.MatchRecord, []*pb.AssignmentFailure, error)
+
+	// ReleaseMatch moves the record of the unassigned match with the specified id to released, and removes its Tickets from the ignore list, in one transaction.  This method fails if the record does not exist, or is not unassigned.
+	ReleaseMatch(ctx context.Context, id string) (*pb.MatchRecord, error)
+
+	// GetTicketMatchIDs returns the ids of the latest matches recorded for the Tickets, without duplicates.  Tickets without a recorded match are silently ignored.
+	GetTicketMatchIDs(ctx context.Context, ticketIDs []string) ([]string, error)
+
+	// GetReadyCheckMatchID returns the id of the match awaiting the ready check of the ticket. This method fails if the ticket has no pending ready check.
+	GetReadyCheckMatchID(ctx context.Context, ticketID string) (string, error)
+
+	// AcquireLease takes the named lease for the holder, or renews it if the holder already has it. Returns true if the holder has the lease for the next ttl.
+	AcquireLease(ctx context.Context, name, holder string, ttl time.Duration) (bool, error)
+
+	// GetLeaseHolder returns the current holder of the named lease, or an empty string if it is not held.
+	GetLeaseHolder(ctx context.Context, name string) (string, error)
+
+	// ReleaseLease gives up the named lease if it is held by the holder.
+	ReleaseLease(ctx context.Context, name, holder string) error
+
 	// Closes the connection to the underlying storage.
 	Close() error
 }
@@ -18,22 +18,115 @@ import (
 	"context"
 	"fmt"
 	"io/ioutil"
+	"strconv"
 	"time"
 
 	"github.com/cenkalti/backoff"
 	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/gomodule/redigo/redis"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"open-match.dev/open-match/internal/config"
+	"open-match.dev/open-match/internal/tenant"
 	"open-match.dev/open-match/pkg/pb"
 )
 
-const allTickets = "allTickets"
+const (
+	allTickets                = "allTickets"
+	matchFunctionModulePrefix = "matchFunctionModule:"
+	leasePrefix               = "lease:"
+	matchPrefix               = "match:"
+	unassignedMatches         = "unassignedMatches"
+	readyCheckPrefix          = "readyCheck:"
+	ticketMatchPrefix         = "ticketMatch:"
+	ignoreList                = "proposed_ticket_ids"
+	tenantPrefix              = "tenant:"
+	ticketOwnerPrefix         = "ticketOwner:"
+	ownedTicketsPrefix        = "ownedTickets:"
+	ticketLifecyclePrefix     = "ticketLifecycle:"
+
+	// Fields of the lifecycle hash of a Ticket, in addition to the events.
+	lifecycleMatchID  = "matchId"
+	lifecycleProfile  = "profile"
+	lifecycleFunction = "function"
+
+	defaultTicketLifecycleTimeout = 24 * time.Hour
+)
 
 var (
+	// acquireLeaseScript sets the lease to the holder if it is not held by
+	// anyone else, and resets its expiration.
+	acquireLeaseScript = redis.NewScript(1, `
+local holder = redis.call('GET', KEYS[1])
+if holder == false or holder == ARGV[1] then
+	redis.call('SET', KEYS[1], ARGV[1], 'PX', ARGV[2])
+	return 1
+end
+return 0`)
+
+	// releaseLeaseScript deletes the lease only if it is held by the holder.
+	releaseLeaseScript = redis.NewScript(1, `
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('DEL', KEYS[1])
+end
+return 0`)
+
+	// setTicketOwnerScript records the owner of the ticket, unless the owner
+	// already has as many tickets as allowed.  Tickets of the owner which were
+	// deleted or expired, such as assigned tickets, are pruned before
+	// counting.  Without a limit, the tickets of owners aren't counted.  Every
+	// key is declared, so the tickets of the owner are read beforehand, and
+	// the script returns -1 if the owner gained tickets since.
+	//
+	// KEYS: the owned tickets of the owner, the owner of the ticket, then the
+	// key and the owner key of each ticket of the owner.
+	// ARGV: the ticket id, the owner, the maximum number of tickets, then the
+	// id of each ticket of the owner.
+	setTicketOwnerScript = redis.NewScript(-1, `
+local max = tonumber(ARGV[3])
+if max > 0 then
+	local known = {}
+	for i = 4, #ARGV do
+		known[ARGV[i]] = i - 3
+	end
+	local count = 0
+	for _, id in ipairs(redis.call('SMEMBERS', KEYS[1])) do
+		local i = known[id]
+		if i == nil then
+			return -1
+		end
+		if redis.call('EXISTS', KEYS[1 + 2 * i]) == 1 then
+			count = count + 1
+		else
+			redis.call('SREM', KEYS[1], id)
+			redis.call('DEL', KEYS[2 + 2 * i])
+		end
+	end
+	if count >= max then
+		return 0
+	end
+	redis.call('SADD', KEYS[1], ARGV[1])
+end
+redis.call('SET', KEYS[2], ARGV[2])
+return 1`)
+
+	// indexTicketWithinLimitScript indexes the ticket, unless as many tickets
+	// as allowed are already indexed.  Without a limit, the ticket is always
+	// indexed.
+	//
+	// KEYS: the indexed tickets.
+	// ARGV: the ticket id, and the maximum number of tickets.
+	indexTicketWithinLimitScript = redis.NewScript(1, `
+local max = tonumber(ARGV[2])
+if max > 0 and redis.call('SCARD', KEYS[1]) >= max then
+	return 0
+end
+redis.call('SADD', KEYS[1], ARGV[1])
+return 1`)
+
 	redisLogger = logrus.WithFields(logrus.Fields{
 		"app":       "openmatch",
 		"component": "statestore.redis",
@@ -248,7 +341,7 @@ func (rb *redisBackend) CreateTicket(ctx context.Context, ticket *pb.Ticket) err
 		return status.Errorf(codes.Internal, "%v", err)
 	}
 
-	_, err = redisConn.Do("SET", ticket.GetId(), value)
+	_, err = redisConn.Do("SET", tenantKey(ctx, ticket.GetId()), value)
 	if err != nil {
 		redisLogger.WithFields(logrus.Fields{
 			"cmd":   "SET",
@@ -269,7 +362,7 @@ func (rb *redisBackend) GetTicket(ctx context.Context, id string) (*pb.Ticket, e
 	}
 	defer handleConnectionClose(&redisConn)
 
-	value, err := redis.Bytes(redisConn.Do("GET", id))
+	value, err := redis.Bytes(redisConn.Do("GET", tenantKey(ctx, id)))
 	if err != nil {
 		redisLogger.WithFields(logrus.Fields{
 			"cmd":   "GET",
@@ -320,7 +413,28 @@ func (rb *redisBackend) DeleteTicket(ctx context.Context, id string) error {
 	}
 	defer handleConnectionClose(&redisConn)
 
-	_, err = redisConn.Do("DEL", id)
+	owner, err := redis.String(redisConn.Do("GET", tenantKey(ctx, ticketOwnerPrefix+id)))
+	if err != nil && err != redis.ErrNil {
+		redisLogger.WithFields(logrus.Fields{
+			"cmd":   "GET",
+			"key":   ticketOwnerPrefix + id,
+			"error": err.Error(),
+		}).Error("failed to get the owner of the ticket")
+		return status.Errorf(codes.Internal, "%v", err)
+	}
+	if owner != "" {
+		_, err = redisConn.Do("SREM", tenantKey(ctx, ownedTicketsPrefix+owner), id)
+		if err != nil {
+			redisLogger.WithFields(logrus.Fields{
+				"cmd":   "SREM",
+				"key":   ownedTicketsPrefix + owner,
+				"error": err.Error(),
+			}).Error("failed to remove the ticket from the tickets of its owner")
+			return status.Errorf(codes.Internal, "%v", err)
+		}
+	}
+
+	_, err = redisConn.Do("DEL", tenantKey(ctx, id), tenantKey(ctx, ticketOwnerPrefix+id))
 	if err != nil {
 		redisLogger.WithFields(logrus.Fields{
 			"cmd":   "DEL",
@@ -333,6 +447,225 @@ func (rb *redisBackend) DeleteTicket(ctx context.Context, id string) error {
 	return nil
 }
 
+// SetTicketOwner records the identity which created the Ticket, unless the
+// owner already has maxTickets Tickets.  The number of Tickets of owners is
+// unlimited if maxTickets is 0.  Returns false, without recording the owner,
+// if the owner has too many Tickets.  Tickets which were deleted or expired
+// no longer count.
+func (rb *redisBackend) SetTicketOwner(ctx context.Context, id string, owner string, maxTickets int) (bool, error) {
+	redisConn, err := rb.connect(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer handleConnectionClose(&redisConn)
+
+	const maxAttempts = 5
+	ownedKey := tenantKey(ctx, ownedTicketsPrefix+owner)
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		var owned []string
+		if maxTickets > 0 {
+			owned, err = redis.Strings(redisConn.Do("SMEMBERS", ownedKey))
+			if err != nil {
+				redisLogger.WithFields(logrus.Fields{
+					"cmd":   "SMEMBERS",
+					"key":   ownedTicketsPrefix + owner,
+					"error": err.Error(),
+				}).Error("failed to get the tickets of the owner")
+				return false, status.Errorf(codes.Internal, "%v", err)
+			}
+		}
+
+		keys := []interface{}{ownedKey, tenantKey(ctx, ticketOwnerPrefix+id)}
+		args := []interface{}{id, owner, maxTickets}
+		for _, ownedID := range owned {
+			keys = append(keys, tenantKey(ctx, ownedID), tenantKey(ctx, ticketOwnerPrefix+ownedID))
+			args = append(args, ownedID)
+		}
+
+		var result int
+		result, err = redis.Int(setTicketOwnerScript.Do(redisConn, append(append([]interface{}{len(keys)}, keys...), args...)...))
+		if err != nil {
+			redisLogger.WithFields(logrus.Fields{
+				"cmd":   "EVALSHA",
+				"key":   ownedTicketsPrefix + owner,
+				"error": err.Error(),
+			}).Error("failed to set the owner of the ticket")
+			return false, status.Errorf(codes.Internal, "%v", err)
+		}
+		if result >= 0 {
+			return result == 1, nil
+		}
+	}
+	return false, status.Errorf(codes.Aborted, "the tickets of %q were concurrently updated", owner)
+}
+
+// GetTicketOwner returns the identity which created the Ticket, or "" if it
+// has no owner.
+func (rb *redisBackend) GetTicketOwner(ctx context.Context, id string) (string, error) {
+	redisConn, err := rb.connect(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer handleConnectionClose(&redisConn)
+
+	owner, err := redis.String(redisConn.Do("GET", tenantKey(ctx, ticketOwnerPrefix+id)))
+	if err == redis.ErrNil {
+		return "", nil
+	}
+	if err != nil {
+		redisLogger.WithFields(logrus.Fields{
+			"cmd":   "GET",
+			"key":   ticketOwnerPrefix + id,
+			"error": err.Error(),
+		}).Error("failed to get the owner of the ticket")
+		return "", status.Errorf(codes.Internal, "%v", err)
+	}
+
+	return owner, nil
+}
+
+// RecordTicketEvents records the time each of the Tickets reached the event, unless it already did.  The lifecycles expire after ticketLifecycleTimeout without events.
+func (rb *redisBackend) RecordTicketEvents(ctx context.Context, event TicketEvent, times map[string]time.Time) error {
+	if len(times) == 0 {
+		return nil
+	}
+
+	redisConn, err := rb.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer handleConnectionClose(&redisConn)
+
+	err = redisConn.Send("MULTI")
+	if err != nil {
+		return errors.Wrap(err, "error starting redis multi")
+	}
+	for id, t := range times {
+		err = rb.sendLifecycleFields(ctx, redisConn, id, string(event), t.UnixNano())
+		if err != nil {
+			return err
+		}
+	}
+	_, err = redisConn.Do("EXEC")
+	if err != nil {
+		redisLogger.WithFields(logrus.Fields{
+			"cmd":   "EXEC",
+			"event": event,
+			"error": err.Error(),
+		}).Error("failed to record the ticket event")
+		return status.Errorf(codes.Internal, "%v", err)
+	}
+	return nil
+}
+
+// sendTicketsMatched sends the commands recording the time the Tickets of the
+// match were matched, and the match, unless they already were.
+func (rb *redisBackend) sendTicketsMatched(ctx context.Context, redisConn redis.Conn, match *pb.Match, t time.Time) error {
+	for _, ticket := range match.GetTickets() {
+		err := rb.sendLifecycleFields(ctx, redisConn, ticket.GetId(),
+			string(TicketMatched), t.UnixNano(),
+			lifecycleMatchID, match.GetMatchId(),
+			lifecycleProfile, match.GetMatchProfile(),
+			lifecycleFunction, match.GetMatchFunction(),
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sendLifecycleFields sends the commands setting the fields of the lifecycle
+// of the Ticket which aren't set yet, as pairs of field and value, and
+// resetting its expiration.
+func (rb *redisBackend) sendLifecycleFields(ctx context.Context, redisConn redis.Conn, id string, fieldValues ...interface{}) error {
+	key := tenantKey(ctx, ticketLifecyclePrefix+id)
+	for i := 0; i+1 < len(fieldValues); i += 2 {
+		err := redisConn.Send("HSETNX", key, fieldValues[i], fieldValues[i+1])
+		if err != nil {
+			return errors.Wrap(err, "error sending ticket lifecycle set")
+		}
+	}
+	err := redisConn.Send("PEXPIRE", key, rb.ticketLifecycleTimeout().Milliseconds())
+	if err != nil {
+		return errors.Wrap(err, "error sending ticket lifecycle expire")
+	}
+	return nil
+}
+
+// GetTicketLifecycles returns the lifecycles of the Tickets by id.  Tickets without events are silently ignored.
+func (rb *redisBackend) GetTicketLifecycles(ctx context.Context, ids []string) (map[string]*TicketLifecycle, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	redisConn, err := rb.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer handleConnectionClose(&redisConn)
+
+	err = redisConn.Send("MULTI")
+	if err != nil {
+		return nil, errors.Wrap(err, "error starting redis multi")
+	}
+	for _, id := range ids {
+		err = redisConn.Send("HGETALL", tenantKey(ctx, ticketLifecyclePrefix+id))
+		if err != nil {
+			return nil, errors.Wrap(err, "error sending ticket lifecycle get")
+		}
+	}
+	values, err := redis.Values(redisConn.Do("EXEC"))
+	if err != nil {
+		redisLogger.WithFields(logrus.Fields{
+			"cmd":   "EXEC",
+			"error": err.Error(),
+		}).Error("failed to get the ticket lifecycles")
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	lifecycles := make(map[string]*TicketLifecycle, len(ids))
+	for i, v := range values {
+		fields, err := redis.StringMap(v, nil)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "%v", err)
+		}
+		if len(fields) == 0 {
+			continue
+		}
+		l := &TicketLifecycle{Times: make(map[TicketEvent]time.Time)}
+		for field, value := range fields {
+			switch field {
+			case lifecycleMatchID:
+				l.MatchID = value
+			case lifecycleProfile:
+				l.Profile = value
+			case lifecycleFunction:
+				l.Function = value
+			default:
+				nanos, err := strconv.ParseInt(value, 10, 64)
+				if err != nil {
+					redisLogger.WithFields(logrus.Fields{
+						"key":   ticketLifecyclePrefix + ids[i],
+						"field": field,
+					}).WithError(err).Error("failed to parse the time of the ticket event")
+					continue
+				}
+				l.Times[TicketEvent(field)] = time.Unix(0, nanos)
+			}
+		}
+		lifecycles[ids[i]] = l
+	}
+	return lifecycles, nil
+}
+
+func (rb *redisBackend) ticketLifecycleTimeout() time.Duration {
+	if rb.cfg.IsSet("ticketLifecycleTimeout") {
+		return rb.cfg.GetDuration("ticketLifecycleTimeout")
+	}
+	return defaultTicketLifecycleTimeout
+}
+
 // IndexTicket indexes the Ticket id for the configured index fields.
 func (rb *redisBackend) IndexTicket(ctx context.Context, ticket *pb.Ticket) error {
 	redisConn, err := rb.connect(ctx)
@@ -341,7 +674,7 @@ func (rb *redisBackend) IndexTicket(ctx context.Context, ticket *pb.Ticket) erro
 	}
 	defer handleConnectionClose(&redisConn)
 
-	err = redisConn.Send("SADD", allTickets, ticket.Id)
+	err = redisConn.Send("SADD", tenantKey(ctx, allTickets), ticket.Id)
 	if err != nil {
 		redisLogger.WithFields(logrus.Fields{
 			"cmd":    "SADD",
@@ -355,6 +688,30 @@ func (rb *redisBackend) IndexTicket(ctx context.Context, ticket *pb.Ticket) erro
 	return nil
 }
 
+// IndexTicketWithinLimit indexes the Ticket id, unless maxTickets tickets are
+// already indexed.  The tickets are counted and the Ticket indexed at once, so
+// concurrent calls can't index more than maxTickets.
+func (rb *redisBackend) IndexTicketWithinLimit(ctx context.Context, ticket *pb.Ticket, maxTickets int) (bool, error) {
+	redisConn, err := rb.connect(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer handleConnectionClose(&redisConn)
+
+	indexed, err := redis.Bool(indexTicketWithinLimitScript.Do(redisConn, tenantKey(ctx, allTickets), ticket.Id, maxTickets))
+	if err != nil {
+		redisLogger.WithFields(logrus.Fields{
+			"cmd":    "EVALSHA",
+			"ticket": ticket.GetId(),
+			"error":  err.Error(),
+			"key":    allTickets,
+		}).Error("failed to add ticket to all tickets")
+		return false, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	return indexed, nil
+}
+
 // DeindexTicket removes the indexing for the specified Ticket. Only the indexes are removed but the Ticket continues to exist.
 func (rb *redisBackend) DeindexTicket(ctx context.Context, id string) error {
 	redisConn, err := rb.connect(ctx)
@@ -363,7 +720,7 @@ func (rb *redisBackend) DeindexTicket(ctx context.Context, id string) error {
 	}
 	defer handleConnectionClose(&redisConn)
 
-	err = redisConn.Send("SREM", allTickets, id)
+	err = redisConn.Send("SREM", tenantKey(ctx, allTickets), id)
 	if err != nil {
 		redisLogger.WithFields(logrus.Fields{
 			"cmd":   "SREM",
@@ -391,13 +748,13 @@ func (rb *redisBackend) GetIndexedIDSet(ctx context.Context) (map[string]struct{
 	startTimeInt := curTime.Add(-ttl).UnixNano()
 
 	// Filter out tickets that are fetched but not assigned within ttl time (ms).
-	idsInIgnoreLists, err := redis.Strings(redisConn.Do("ZRANGEBYSCORE", "proposed_ticket_ids", startTimeInt, endTimeInt))
+	idsInIgnoreLists, err := redis.Strings(redisConn.Do("ZRANGEBYSCORE", tenantKey(ctx, ignoreList), startTimeInt, endTimeInt))
 	if err != nil {
 		redisLogger.WithError(err).Error("failed to get proposed tickets")
 		return nil, status.Errorf(codes.Internal, "error getting ignore list %v", err)
 	}
 
-	idsIndexed, err := redis.Strings(redisConn.Do("SMEMBERS", allTickets))
+	idsIndexed, err := redis.Strings(redisConn.Do("SMEMBERS", tenantKey(ctx, allTickets)))
 	if err != nil {
 		redisLogger.WithFields(logrus.Fields{
 			"Command": "SMEMBER allTickets",
@@ -416,6 +773,27 @@ func (rb *redisBackend) GetIndexedIDSet(ctx context.Context) (map[string]struct{
 	return r, nil
 }
 
+// CountIndexedTickets returns the number of tickets currently indexed, including those in the ignore list.
+func (rb *redisBackend) CountIndexedTickets(ctx context.Context) (int, error) {
+	redisConn, err := rb.connect(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer handleConnectionClose(&redisConn)
+
+	count, err := redis.Int(redisConn.Do("SCARD", tenantKey(ctx, allTickets)))
+	if err != nil {
+		redisLogger.WithFields(logrus.Fields{
+			"cmd":   "SCARD",
+			"key":   tenantKey(ctx, allTickets),
+			"error": err.Error(),
+		}).Error("failed to count all tickets")
+		return 0, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	return count, nil
+}
+
 // GetTickets returns multiple tickets from storage.  Missing tickets are
 // silently ignored.
 func (rb *redisBackend) GetTickets(ctx context.Context, ids []string) ([]*pb.Ticket, error) {
@@ -431,7 +809,7 @@ func (rb *redisBackend) GetTickets(ctx context.Context, ids []string) ([]*pb.Tic
 
 	queryParams := make([]interface{}, len(ids))
 	for i, id := range ids {
-		queryParams[i] = id
+		queryParams[i] = tenantKey(ctx, id)
 	}
 
 	ticketBytes, err := redis.ByteSlices(redisConn.Do("MGET", queryParams...))
@@ -484,7 +862,7 @@ func (rb *redisBackend) UpdateAssignments(ctx context.Context, req *pb.AssignTic
 
 			idToA[id] = a.Assignment
 			ids = append(ids, id)
-			idsI = append(idsI, id)
+			idsI = append(idsI, tenantKey(ctx, id))
 		}
 	}
 
@@ -534,7 +912,7 @@ func (rb *redisBackend) UpdateAssignments(ctx context.Context, req *pb.AssignTic
 			return nil, status.Errorf(codes.Internal, "failed to marshal ticket %s", ticket.GetId())
 		}
 
-		err = redisConn.Send("SET", ticket.Id, ticketByte, "PX", int64(assignmentTimeout), "XX")
+		err = redisConn.Send("SET", tenantKey(ctx, ticket.Id), ticketByte, "PX", int64(assignmentTimeout), "XX")
 		if err != nil {
 			return nil, errors.Wrap(err, "error sending ticket assignment set")
 		}
@@ -614,7 +992,7 @@ func (rb *redisBackend) AddTicketsToIgnoreList(ctx context.Context, ids []string
 
 	currentTime := time.Now().UnixNano()
 	cmds := make([]interface{}, 0, 2*len(ids)+1)
-	cmds = append(cmds, "proposed_ticket_ids")
+	cmds = append(cmds, tenantKey(ctx, ignoreList))
 	for _, id := range ids {
 		cmds = append(cmds, currentTime, id)
 	}
@@ -641,7 +1019,7 @@ func (rb *redisBackend) DeleteTicketsFromIgnoreList(ctx context.Context, ids []s
 	defer handleConnectionClose(&redisConn)
 
 	cmds := make([]interface{}, 0, len(ids)+1)
-	cmds = append(cmds, "proposed_ticket_ids")
+	cmds = append(cmds, tenantKey(ctx, ignoreList))
 	for _, id := range ids {
 		cmds = append(cmds, id)
 	}
@@ -662,10 +1040,637 @@ func (rb *redisBackend) ReleaseAllTickets(ctx context.Context) error {
 	}
 	defer handleConnectionClose(&redisConn)
 
-	_, err = redisConn.Do("DEL", "proposed_ticket_ids")
+	_, err = redisConn.Do("DEL", tenantKey(ctx, ignoreList))
 	return err
 }
 
+// SetMatchFunctionModule stores a WebAssembly match function module under the name, replacing any existing module.
+func (rb *redisBackend) SetMatchFunctionModule(ctx context.Context, name string, module []byte) error {
+	redisConn, err := rb.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer handleConnectionClose(&redisConn)
+
+	_, err = redisConn.Do("SET", tenantKey(ctx, matchFunctionModulePrefix+name), module)
+	if err != nil {
+		redisLogger.WithFields(logrus.Fields{
+			"cmd":   "SET",
+			"key":   tenantKey(ctx, matchFunctionModulePrefix+name),
+			"error": err.Error(),
+		}).Error("failed to set the value for match function module")
+		return status.Errorf(codes.Internal, "%v", err)
+	}
+
+	return nil
+}
+
+// GetMatchFunctionModule gets the WebAssembly match function module with the specified name. This method fails if the module does not exist.
+func (rb *redisBackend) GetMatchFunctionModule(ctx context.Context, name string) ([]byte, error) {
+	redisConn, err := rb.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer handleConnectionClose(&redisConn)
+
+	value, err := redis.Bytes(redisConn.Do("GET", tenantKey(ctx, matchFunctionModulePrefix+name)))
+	if err == redis.ErrNil {
+		return nil, status.Errorf(codes.NotFound, "Match function module %s not found", name)
+	}
+	if err != nil {
+		redisLogger.WithFields(logrus.Fields{
+			"cmd":   "GET",
+			"key":   tenantKey(ctx, matchFunctionModulePrefix+name),
+			"error": err.Error(),
+		}).Error("failed to get the match function module from state storage")
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	return value, nil
+}
+
+// CreateMatch stores the record of a match returned by FetchMatches, replacing any existing record, and records its Tickets as matched at the create time of the record in their lifecycles.  The record expires after matchRecordTimeout.
+func (rb *redisBackend) CreateMatch(ctx context.Context, record *pb.MatchRecord) error {
+	redisConn, err := rb.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer handleConnectionClose(&redisConn)
+
+	id := record.GetMatch().GetMatchId()
+	value, err := proto.Marshal(record)
+	if err != nil {
+		redisLogger.WithFields(logrus.Fields{
+			"key":   tenantKey(ctx, matchPrefix+id),
+			"error": err.Error(),
+		}).Error("failed to marshal the match record proto")
+		return status.Errorf(codes.Internal, "%v", err)
+	}
+
+	err = redisConn.Send("MULTI")
+	if err != nil {
+		return errors.Wrap(err, "error starting redis multi")
+	}
+	err = redisConn.Send("SET", tenantKey(ctx, matchPrefix+id), value, "PX", rb.matchRecordTimeout().Milliseconds())
+	if err != nil {
+		return errors.Wrap(err, "error sending match record set")
+	}
+	if record.GetState() == pb.MatchRecord_UNASSIGNED {
+		err = redisConn.Send("SADD", tenantKey(ctx, unassignedMatches), id)
+		if err != nil {
+			return errors.Wrap(err, "error sending unassigned matches add")
+		}
+	}
+	for _, t := range record.GetMatch().GetTickets() {
+		// Assigning or releasing the ticket by id updates its latest match.
+		err = redisConn.Send("SET", tenantKey(ctx, ticketMatchPrefix+t.GetId()), id, "PX", rb.matchRecordTimeout().Milliseconds())
+		if err != nil {
+			return errors.Wrap(err, "error sending ticket match set")
+		}
+		// Players find their match's ready check by their ticket.
+		if record.GetState() == pb.MatchRecord_AWAITING_READY {
+			err = redisConn.Send("SET", tenantKey(ctx, readyCheckPrefix+t.GetId()), id, "PX", rb.matchRecordTimeout().Milliseconds())
+			if err != nil {
+				return errors.Wrap(err, "error sending ready check set")
+			}
+		}
+	}
+	// Records without a create time leave the lifecycles alone.
+	if matched, err := ptypes.Timestamp(record.GetCreateTime()); err == nil {
+		err = rb.sendTicketsMatched(ctx, redisConn, record.GetMatch(), matched)
+		if err != nil {
+			return err
+		}
+	}
+	_, err = redisConn.Do("EXEC")
+	if err != nil {
+		redisLogger.WithFields(logrus.Fields{
+			"cmd":   "EXEC",
+			"key":   tenantKey(ctx, matchPrefix+id),
+			"error": err.Error(),
+		}).Error("failed to set the value for match record")
+		return status.Errorf(codes.Internal, "%v", err)
+	}
+
+	return nil
+}
+
+// GetMatch gets the record of the match with the specified id. This method fails if the record does not exist or has expired.
+func (rb *redisBackend) GetMatch(ctx context.Context, id string) (*pb.MatchRecord, error) {
+	redisConn, err := rb.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer handleConnectionClose(&redisConn)
+
+	value, err := redis.Bytes(redisConn.Do("GET", tenantKey(ctx, matchPrefix+id)))
+	if err == redis.ErrNil {
+		return nil, status.Errorf(codes.NotFound, "Match id:%s not found", id)
+	}
+	if err != nil {
+		redisLogger.WithFields(logrus.Fields{
+			"cmd":   "GET",
+			"key":   tenantKey(ctx, matchPrefix+id),
+			"error": err.Error(),
+		}).Error("failed to get the match record from state storage")
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	record := &pb.MatchRecord{}
+	err = proto.Unmarshal(value, record)
+	if err != nil {
+		redisLogger.WithFields(logrus.Fields{
+			"key":   tenantKey(ctx, matchPrefix+id),
+			"error": err.Error(),
+		}).Error("failed to unmarshal the match record proto")
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	return record, nil
+}
+
+// GetMatches gets the records of the matches with the specified ids.  Matches without a record, or whose record expired, are silently ignored.
+func (rb *redisBackend) GetMatches(ctx context.Context, ids []string) (map[string]*pb.MatchRecord, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	redisConn, err := rb.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer handleConnectionClose(&redisConn)
+
+	keys := make([]interface{}, len(ids))
+	for i, id := range ids {
+		keys[i] = tenantKey(ctx, matchPrefix+id)
+	}
+	values, err := redis.ByteSlices(redisConn.Do("MGET", keys...))
+	if err != nil {
+		redisLogger.WithFields(logrus.Fields{
+			"cmd":   "MGET",
+			"error": err.Error(),
+		}).Error("failed to get match records")
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	records := make(map[string]*pb.MatchRecord, len(ids))
+	for i, value := range values {
+		if value == nil {
+			continue
+		}
+		record := &pb.MatchRecord{}
+		err = proto.Unmarshal(value, record)
+		if err != nil {
+			redisLogger.WithFields(logrus.Fields{
+				"key":   keys[i],
+				"error": err.Error(),
+			}).Error("failed to unmarshal the match record proto")
+			return nil, status.Errorf(codes.Internal, "%v", err)
+		}
+		records[ids[i]] = record
+	}
+	return records, nil
+}
+
+// GetUnassignedMatches returns the records of all unassigned matches which have not expired.
+func (rb *redisBackend) GetUnassignedMatches(ctx context.Context) ([]*pb.MatchRecord, error) {
+	redisConn, err := rb.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer handleConnectionClose(&redisConn)
+
+	ids, err := redis.Strings(redisConn.Do("SMEMBERS", tenantKey(ctx, unassignedMatches)))
+	if err != nil {
+		redisLogger.WithFields(logrus.Fields{
+			"cmd":   "SMEMBERS",
+			"key":   tenantKey(ctx, unassignedMatches),
+			"error": err.Error(),
+		}).Error("failed to get unassigned match ids")
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	keys := make([]interface{}, len(ids))
+	for i, id := range ids {
+		keys[i] = tenantKey(ctx, matchPrefix+id)
+	}
+	values, err := redis.ByteSlices(redisConn.Do("MGET", keys...))
+	if err != nil {
+		redisLogger.WithFields(logrus.Fields{
+			"cmd":   "MGET",
+			"error": err.Error(),
+		}).Error("failed to get unassigned match records")
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	records := make([]*pb.MatchRecord, 0, len(ids))
+	// Expired and handled matches are lazily removed from the set.
+	stale := []interface{}{tenantKey(ctx, unassignedMatches)}
+	for i, value := range values {
+		if value == nil {
+			stale = append(stale, ids[i])
+			continue
+		}
+		record := &pb.MatchRecord{}
+		err = proto.Unmarshal(value, record)
+		if err != nil {
+			redisLogger.WithFields(logrus.Fields{
+				"key":   keys[i],
+				"error": err.Error(),
+			}).Error("failed to unmarshal the match record proto")
+			return nil, status.Errorf(codes.Internal, "%v", err)
+		}
+		if record.GetState() != pb.MatchRecord_UNASSIGNED {
+			stale = append(stale, ids[i])
+			continue
+		}
+		records = append(records, record)
+	}
+
+	if len(stale) > 1 {
+		_, err = redisConn.Do("SREM", stale...)
+		if err != nil {
+			redisLogger.WithFields(logrus.Fields{
+				"cmd":   "SREM",
+				"key":   tenantKey(ctx, unassignedMatches),
+				"error": err.Error(),
+			}).Warning("failed to remove stale unassigned match ids")
+		}
+	}
+
+	return records, nil
+}
+
+// UpdateMatchState moves the record of the match with the specified id from one state to another, returning the updated record. This method fails if the record does not exist, or is not in the from state.
+func (rb *redisBackend) UpdateMatchState(ctx context.Context, id string, from, to pb.MatchRecord_State) (*pb.MatchRecord, error) {
+	return rb.UpdateMatch(ctx, id, func(record *pb.MatchRecord) error {
+		if record.GetState() != from {
+			return status.Errorf(codes.FailedPrecondition, "Match id:%s is %s, not %s", id, record.GetState(), from)
+		}
+		record.State = to
+		return nil
+	})
+}
+
+// UpdateMatch atomically applies the update to the record of the match with the specified id, returning the updated record. The update may be called multiple times if the record is concurrently updated. This method fails if the record does not exist, or the update fails.
+func (rb *redisBackend) UpdateMatch(ctx context.Context, id string, update func(*pb.MatchRecord) error) (*pb.MatchRecord, error) {
+	return rb.updateMatch(ctx, id, func(_ redis.Conn, record *pb.MatchRecord) (func() error, error) {
+		return nil, update(record)
+	})
+}
+
+// AssignMatch assigns the Tickets of the unassigned match with the specified id, and moves its record to assigned, in one transaction.  The assigned Tickets are deindexed and removed from the ignore list.  Tickets which can't be assigned are returned as failures.  This method fails if the record does not exist, or is not unassigned.
+func (rb *redisBackend) AssignMatch(ctx context.Context, id string, assignment *pb.Assignment) (*pb.MatchRecord, []*pb.AssignmentFailure, error) {
+	var failures []*pb.AssignmentFailure
+	record, err := rb.updateMatch(ctx, id, func(redisConn redis.Conn, record *pb.MatchRecord) (func() error, error) {
+		if record.GetState() != pb.MatchRecord_UNASSIGNED {
+			return nil, status.Errorf(codes.FailedPrecondition, "Match id:%s is %s, not %s", id, record.GetState(), pb.MatchRecord_UNASSIGNED)
+		}
+		record.State = pb.MatchRecord_ASSIGNED
+
+		tickets := record.GetMatch().GetTickets()
+		if len(tickets) == 0 {
+			return nil, nil
+		}
+		ids := make([]interface{}, 0, len(tickets))
+		keys := make([]interface{}, 0, len(tickets))
+		for _, t := range tickets {
+			ids = append(ids, t.GetId())
+			keys = append(keys, tenantKey(ctx, t.GetId()))
+		}
+		// The transaction also fails if the tickets change after being read.
+		_, err := redisConn.Do("WATCH", keys...)
+		if err != nil {
+			return nil, errors.Wrap(err, "error watching tickets")
+		}
+		ticketBytes, err := redis.ByteSlices(redisConn.Do("MGET", keys...))
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "%v", err)
+		}
+
+		failures = nil
+		assigned := make([]*pb.Ticket, 0, len(ticketBytes))
+		for i, ticketByte := range ticketBytes {
+			if ticketByte == nil {
+				failures = append(failures, &pb.AssignmentFailure{
+					TicketId: tickets[i].GetId(),
+					Cause:    pb.AssignmentFailure_TICKET_NOT_FOUND,
+				})
+				continue
+			}
+			t := &pb.Ticket{}
+			err = proto.Unmarshal(ticketByte, t)
+			if err != nil {
+				redisLogger.WithFields(logrus.Fields{
+					"key": tickets[i].GetId(),
+				}).WithError(err).Error("failed to unmarshal ticket from redis.")
+				return nil, status.Errorf(codes.Internal, "%v", err)
+			}
+			t.Assignment = assignment
+			assigned = append(assigned, t)
+		}
+
+		assignmentTimeout := rb.cfg.GetDuration("assignedDeleteTimeout") / time.Millisecond
+		return func() error {
+			for _, ticket := range assigned {
+				ticketByte, err := proto.Marshal(ticket)
+				if err != nil {
+					return status.Errorf(codes.Internal, "failed to marshal ticket %s", ticket.GetId())
+				}
+				err = redisConn.Send("SET", tenantKey(ctx, ticket.Id), ticketByte, "PX", int64(assignmentTimeout), "XX")
+				if err != nil {
+					return errors.Wrap(err, "error sending ticket assignment set")
+				}
+			}
+			err := redisConn.Send("SREM", append([]interface{}{tenantKey(ctx, allTickets)}, ids...)...)
+			if err != nil {
+				return errors.Wrap(err, "error sending tickets deindex")
+			}
+			return redisConn.Send("ZREM", append([]interface{}{tenantKey(ctx, ignoreList)}, ids...)...)
+		}, nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return record, failures, nil
+}
+
+// ReleaseMatch moves the record of the unassigned match with the specified id to released, and removes its Tickets from the ignore list, in one transaction.  This method fails if the record does not exist, or is not unassigned.
+func (rb *redisBackend) ReleaseMatch(ctx context.Context, id string) (*pb.MatchRecord, error) {
+	return rb.updateMatch(ctx, id, func(redisConn redis.Conn, record *pb.MatchRecord) (func() error, error) {
+		if record.GetState() != pb.MatchRecord_UNASSIGNED {
+			return nil, status.Errorf(codes.FailedPrecondition, "Match id:%s is %s, not %s", id, record.GetState(), pb.MatchRecord_UNASSIGNED)
+		}
+		record.State = pb.MatchRecord_RELEASED
+
+		if len(record.GetMatch().GetTickets()) == 0 {
+			return nil, nil
+		}
+		cmds := []interface{}{tenantKey(ctx, ignoreList)}
+		for _, t := range record.GetMatch().GetTickets() {
+			cmds = append(cmds, t.GetId())
+		}
+		return func() error {
+			return redisConn.Send("ZREM", cmds...)
+		}, nil
+	})
+}
+
+// matchTransaction updates the record of a match, read in a transaction.  It
+// may watch and read other keys on the connection, and returns a function
+// sending the other commands of the transaction, or nil if there are none.
+type matchTransaction func(redisConn redis.Conn, record *pb.MatchRecord) (func() error, error)
+
+func (rb *redisBackend) updateMatch(ctx context.Context, id string, update matchTransaction) (*pb.MatchRecord, error) {
+	const maxAttempts = 5
+
+	redisConn, err := rb.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer handleConnectionClose(&redisConn)
+
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		record, err := rb.tryUpdateMatch(ctx, redisConn, id, update)
+		if status.Code(err) == codes.Aborted {
+			continue
+		}
+		return record, err
+	}
+	return nil, status.Errorf(codes.Aborted, "Match id:%s was concurrently updated", id)
+}
+
+func (rb *redisBackend) tryUpdateMatch(ctx context.Context, redisConn redis.Conn, id string, update matchTransaction) (*pb.MatchRecord, error) {
+	key := tenantKey(ctx, matchPrefix+id)
+	// The transaction fails if the record changes after being read.  Closing
+	// the connection unwatches it if the transaction isn't reached.
+	_, err := redisConn.Do("WATCH", key)
+	if err != nil {
+		return nil, errors.Wrap(err, "error watching match record")
+	}
+
+	value, err := redis.Bytes(redisConn.Do("GET", key))
+	if err == redis.ErrNil {
+		return nil, status.Errorf(codes.NotFound, "Match id:%s not found", id)
+	}
+	if err != nil {
+		redisLogger.WithFields(logrus.Fields{
+			"cmd":   "GET",
+			"key":   key,
+			"error": err.Error(),
+		}).Error("failed to get the match record from state storage")
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+	ttl, err := redis.Int64(redisConn.Do("PTTL", key))
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting match record ttl")
+	}
+
+	record := &pb.MatchRecord{}
+	err = proto.Unmarshal(value, record)
+	if err != nil {
+		redisLogger.WithFields(logrus.Fields{
+			"key":   key,
+			"error": err.Error(),
+		}).Error("failed to unmarshal the match record proto")
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	send, err := update(redisConn, record)
+	if err != nil {
+		_, unwatchErr := redisConn.Do("UNWATCH")
+		if unwatchErr != nil {
+			redisLogger.WithError(unwatchErr).Warning("failed to unwatch match record")
+		}
+		return nil, err
+	}
+
+	value, err = proto.Marshal(record)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to marshal match record %s", id)
+	}
+
+	err = redisConn.Send("MULTI")
+	if err != nil {
+		return nil, errors.Wrap(err, "error starting redis multi")
+	}
+	if ttl > 0 {
+		err = redisConn.Send("SET", key, value, "PX", ttl)
+	} else {
+		err = redisConn.Send("SET", key, value)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "error sending match record set")
+	}
+	if record.GetState() == pb.MatchRecord_UNASSIGNED {
+		err = redisConn.Send("SADD", tenantKey(ctx, unassignedMatches), id)
+	} else {
+		err = redisConn.Send("SREM", tenantKey(ctx, unassignedMatches), id)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "error sending unassigned matches update")
+	}
+	if send != nil {
+		err = send()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	_, err = redis.Values(redisConn.Do("EXEC"))
+	if err == redis.ErrNil {
+		return nil, status.Errorf(codes.Aborted, "Match id:%s was concurrently updated", id)
+	}
+	if err != nil {
+		redisLogger.WithFields(logrus.Fields{
+			"cmd":   "EXEC",
+			"key":   key,
+			"error": err.Error(),
+		}).Error("failed to update the match record")
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	return record, nil
+}
+
+// GetReadyCheckMatchID returns the id of the match awaiting the ready check of the ticket. This method fails if the ticket has no pending ready check.
+func (rb *redisBackend) GetReadyCheckMatchID(ctx context.Context, ticketID string) (string, error) {
+	redisConn, err := rb.connect(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer handleConnectionClose(&redisConn)
+
+	id, err := redis.String(redisConn.Do("GET", tenantKey(ctx, readyCheckPrefix+ticketID)))
+	if err == redis.ErrNil {
+		return "", status.Errorf(codes.NotFound, "Ticket id:%s has no ready check", ticketID)
+	}
+	if err != nil {
+		redisLogger.WithFields(logrus.Fields{
+			"cmd":   "GET",
+			"key":   tenantKey(ctx, readyCheckPrefix+ticketID),
+			"error": err.Error(),
+		}).Error("failed to get the ready check of the ticket")
+		return "", status.Errorf(codes.Internal, "%v", err)
+	}
+
+	return id, nil
+}
+
+// GetTicketMatchIDs returns the ids of the latest matches recorded for the Tickets, without duplicates.  Tickets without a recorded match are silently ignored.
+func (rb *redisBackend) GetTicketMatchIDs(ctx context.Context, ticketIDs []string) ([]string, error) {
+	if len(ticketIDs) == 0 {
+		return nil, nil
+	}
+
+	redisConn, err := rb.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer handleConnectionClose(&redisConn)
+
+	keys := make([]interface{}, len(ticketIDs))
+	for i, id := range ticketIDs {
+		keys[i] = tenantKey(ctx, ticketMatchPrefix+id)
+	}
+	values, err := redis.Strings(redisConn.Do("MGET", keys...))
+	if err != nil {
+		redisLogger.WithFields(logrus.Fields{
+			"cmd":   "MGET",
+			"error": err.Error(),
+		}).Error("failed to get the matches of the tickets")
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	seen := map[string]struct{}{}
+	ids := []string{}
+	for _, id := range values {
+		if _, ok := seen[id]; ok || id == "" {
+			continue
+		}
+		seen[id] = struct{}{}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func (rb *redisBackend) matchRecordTimeout() time.Duration {
+	if rb.cfg.IsSet("matchRecordTimeout") {
+		return rb.cfg.GetDuration("matchRecordTimeout")
+	}
+	return rb.cfg.GetDuration("pendingReleaseTimeout")
+}
+
+// AcquireLease takes the named lease for the holder, or renews it if the holder already has it. Returns true if the holder has the lease for the next ttl.
+func (rb *redisBackend) AcquireLease(ctx context.Context, name, holder string, ttl time.Duration) (bool, error) {
+	redisConn, err := rb.connect(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer handleConnectionClose(&redisConn)
+
+	acquired, err := redis.Bool(acquireLeaseScript.Do(redisConn, leasePrefix+name, holder, ttl.Milliseconds()))
+	if err != nil {
+		redisLogger.WithFields(logrus.Fields{
+			"cmd":   "EVALSHA",
+			"key":   leasePrefix + name,
+			"error": err.Error(),
+		}).Error("failed to acquire lease")
+		return false, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	return acquired, nil
+}
+
+// GetLeaseHolder returns the current holder of the named lease, or an empty string if it is not held.
+func (rb *redisBackend) GetLeaseHolder(ctx context.Context, name string) (string, error) {
+	redisConn, err := rb.connect(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer handleConnectionClose(&redisConn)
+
+	holder, err := redis.String(redisConn.Do("GET", leasePrefix+name))
+	if err == redis.ErrNil {
+		return "", nil
+	}
+	if err != nil {
+		redisLogger.WithFields(logrus.Fields{
+			"cmd":   "GET",
+			"key":   leasePrefix + name,
+			"error": err.Error(),
+		}).Error("failed to get the lease holder")
+		return "", status.Errorf(codes.Internal, "%v", err)
+	}
+
+	return holder, nil
+}
+
+// ReleaseLease gives up the named lease if it is held by the holder.
+func (rb *redisBackend) ReleaseLease(ctx context.Context, name, holder string) error {
+	redisConn, err := rb.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer handleConnectionClose(&redisConn)
+
+	_, err = releaseLeaseScript.Do(redisConn, leasePrefix+name, holder)
+	if err != nil {
+		redisLogger.WithFields(logrus.Fields{
+			"cmd":   "EVALSHA",
+			"key":   leasePrefix + name,
+			"error": err.Error(),
+		}).Error("failed to release lease")
+		return status.Errorf(codes.Internal, "%v", err)
+	}
+
+	return nil
+}
+
 func handleConnectionClose(conn *redis.Conn) {
 	err := (*conn).Close()
 	if err != nil {
@@ -691,3 +1696,13 @@ func (rb *redisBackend) newExponentialBackoffStrategy() backoff.BackOff {
 	backoffStrat.MaxElapsedTime = rb.cfg.GetDuration("backoff.maxElapsedTime")
 	return backoff.BackOff(backoffStrat)
 }
+
+// tenantKey namespaces the key by the tenant of the request.  Keys of the
+// default tenant are not namespaced, so that they are compatible with data
+// stored before tenants existed.
+func tenantKey(ctx context.Context, key string) string {
+	if name := tenant.FromContext(ctx); name != "" {
+		return tenantPrefix + name + ":" + key
+	}
+	return key
+}
@@ -24,6 +24,8 @@ import (
 
 	"github.com/Bose/minisentinel"
 	miniredis "github.com/alicebob/miniredis/v2"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/gomodule/redigo/redis"
 	"github.com/rs/xid"
 	"github.com/spf13/viper"
@@ -32,6 +34,7 @@ import (
 	"google.golang.org/grpc/status"
 	"open-match.dev/open-match/internal/config"
 	"open-match.dev/open-match/internal/telemetry"
+	"open-match.dev/open-match/internal/tenant"
 	internalTesting "open-match.dev/open-match/internal/testing"
 	utilTesting "open-match.dev/open-match/internal/util/testing"
 	"open-match.dev/open-match/pkg/pb"
@@ -245,6 +248,338 @@ func TestGetAssignmentNormal(t *testing.T) {
 	assert.Equal(returnedErr, err)
 }
 
+func TestMatchFunctionModuleLifecycle(t *testing.T) {
+	assert := assert.New(t)
+	cfg, closer := createRedis(t, true, "")
+	defer closer()
+	service := New(cfg)
+	assert.NotNil(service)
+	defer service.Close()
+
+	ctx := utilTesting.NewContext(t)
+
+	_, err := service.GetMatchFunctionModule(ctx, "mmf")
+	assert.Equal(codes.NotFound, status.Code(err))
+
+	err = service.SetMatchFunctionModule(ctx, "mmf", []byte("first"))
+	assert.Nil(err)
+
+	module, err := service.GetMatchFunctionModule(ctx, "mmf")
+	assert.Nil(err)
+	assert.Equal([]byte("first"), module)
+
+	err = service.SetMatchFunctionModule(ctx, "mmf", []byte("second"))
+	assert.Nil(err)
+
+	module, err = service.GetMatchFunctionModule(ctx, "mmf")
+	assert.Nil(err)
+	assert.Equal([]byte("second"), module)
+}
+
+func TestMatchRecordLifecycle(t *testing.T) {
+	assert := assert.New(t)
+	cfg, closer := createRedis(t, true, "")
+	defer closer()
+	service := New(cfg)
+	assert.NotNil(service)
+	defer service.Close()
+
+	ctx := utilTesting.NewContext(t)
+
+	_, err := service.GetMatch(ctx, "1")
+	assert.Equal(codes.NotFound, status.Convert(err).Code())
+
+	records, err := service.GetUnassignedMatches(ctx)
+	assert.Nil(err)
+	assert.Empty(records)
+
+	record := &pb.MatchRecord{
+		Match: &pb.Match{
+			MatchId: "1",
+			Tickets: []*pb.Ticket{{Id: "a"}, {Id: "b"}},
+		},
+		Profile: &pb.MatchProfile{Name: "profile"},
+	}
+	assert.Nil(service.CreateMatch(ctx, record))
+
+	got, err := service.GetMatch(ctx, "1")
+	assert.Nil(err)
+	assert.True(proto.Equal(record, got))
+
+	records, err = service.GetUnassignedMatches(ctx)
+	assert.Nil(err)
+	assert.Len(records, 1)
+	assert.True(proto.Equal(record, records[0]))
+
+	_, err = service.UpdateMatchState(ctx, "2", pb.MatchRecord_UNASSIGNED, pb.MatchRecord_ASSIGNED)
+	assert.Equal(codes.NotFound, status.Convert(err).Code())
+
+	got, err = service.UpdateMatchState(ctx, "1", pb.MatchRecord_UNASSIGNED, pb.MatchRecord_ASSIGNED)
+	assert.Nil(err)
+	assert.Equal(pb.MatchRecord_ASSIGNED, got.State)
+
+	// Already handled.
+	_, err = service.UpdateMatchState(ctx, "1", pb.MatchRecord_UNASSIGNED, pb.MatchRecord_RELEASED)
+	assert.Equal(codes.FailedPrecondition, status.Convert(err).Code())
+
+	got, err = service.GetMatch(ctx, "1")
+	assert.Nil(err)
+	assert.Equal(pb.MatchRecord_ASSIGNED, got.State)
+
+	records, err = service.GetUnassignedMatches(ctx)
+	assert.Nil(err)
+	assert.Empty(records)
+}
+
+func TestAssignMatch(t *testing.T) {
+	assert := assert.New(t)
+	cfg, closer := createRedis(t, true, "")
+	defer closer()
+	service := New(cfg)
+	assert.NotNil(service)
+	defer service.Close()
+	ctx := utilTesting.NewContext(t)
+
+	for _, id := range []string{"a", "b"} {
+		ticket := &pb.Ticket{Id: id}
+		assert.Nil(service.CreateTicket(ctx, ticket))
+		assert.Nil(service.IndexTicket(ctx, ticket))
+	}
+	assert.Nil(service.AddTicketsToIgnoreList(ctx, []string{"a", "b"}))
+	record := &pb.MatchRecord{
+		Match: &pb.Match{
+			MatchId: "1",
+			Tickets: []*pb.Ticket{{Id: "a"}, {Id: "b"}, {Id: "deleted"}},
+		},
+	}
+	assert.Nil(service.CreateMatch(ctx, record))
+
+	_, _, err := service.AssignMatch(ctx, "2", &pb.Assignment{Connection: "c"})
+	assert.Equal(codes.NotFound, status.Convert(err).Code())
+
+	got, failures, err := service.AssignMatch(ctx, "1", &pb.Assignment{Connection: "c"})
+	assert.Nil(err)
+	assert.Equal(pb.MatchRecord_ASSIGNED, got.State)
+	assert.Len(failures, 1)
+	assert.Equal("deleted", failures[0].TicketId)
+	assert.Equal(pb.AssignmentFailure_TICKET_NOT_FOUND, failures[0].Cause)
+
+	for _, id := range []string{"a", "b"} {
+		ticket, err := service.GetTicket(ctx, id)
+		assert.Nil(err)
+		assert.Equal("c", ticket.GetAssignment().GetConnection())
+	}
+	ids, err := service.GetIndexedIDSet(ctx)
+	assert.Nil(err)
+	assert.Empty(ids)
+	records, err := service.GetUnassignedMatches(ctx)
+	assert.Nil(err)
+	assert.Empty(records)
+
+	// Already handled, so the tickets keep their assignment.
+	_, _, err = service.AssignMatch(ctx, "1", &pb.Assignment{Connection: "d"})
+	assert.Equal(codes.FailedPrecondition, status.Convert(err).Code())
+	_, err = service.ReleaseMatch(ctx, "1")
+	assert.Equal(codes.FailedPrecondition, status.Convert(err).Code())
+	ticket, err := service.GetTicket(ctx, "a")
+	assert.Nil(err)
+	assert.Equal("c", ticket.GetAssignment().GetConnection())
+}
+
+func TestReleaseMatch(t *testing.T) {
+	assert := assert.New(t)
+	cfg, closer := createRedis(t, true, "")
+	defer closer()
+	service := New(cfg)
+	assert.NotNil(service)
+	defer service.Close()
+	ctx := utilTesting.NewContext(t)
+
+	for _, id := range []string{"a", "b", "c"} {
+		ticket := &pb.Ticket{Id: id}
+		assert.Nil(service.CreateTicket(ctx, ticket))
+		assert.Nil(service.IndexTicket(ctx, ticket))
+	}
+	assert.Nil(service.AddTicketsToIgnoreList(ctx, []string{"a", "b", "c"}))
+	record := &pb.MatchRecord{
+		Match: &pb.Match{
+			MatchId: "1",
+			Tickets: []*pb.Ticket{{Id: "a"}, {Id: "b"}},
+		},
+	}
+	assert.Nil(service.CreateMatch(ctx, record))
+
+	_, err := service.ReleaseMatch(ctx, "2")
+	assert.Equal(codes.NotFound, status.Convert(err).Code())
+
+	got, err := service.ReleaseMatch(ctx, "1")
+	assert.Nil(err)
+	assert.Equal(pb.MatchRecord_RELEASED, got.State)
+
+	// Only the tickets of the match return to the pool.
+	ids, err := service.GetIndexedIDSet(ctx)
+	assert.Nil(err)
+	assert.Equal(map[string]struct{}{"a": {}, "b": {}}, ids)
+
+	_, err = service.ReleaseMatch(ctx, "1")
+	assert.Equal(codes.FailedPrecondition, status.Convert(err).Code())
+}
+
+func TestGetMatches(t *testing.T) {
+	assert := assert.New(t)
+	cfg, closer := createRedis(t, true, "")
+	defer closer()
+	service := New(cfg)
+	assert.NotNil(service)
+	defer service.Close()
+
+	ctx := utilTesting.NewContext(t)
+
+	records, err := service.GetMatches(ctx, nil)
+	assert.Nil(err)
+	assert.Empty(records)
+
+	a := &pb.MatchRecord{Match: &pb.Match{MatchId: "a"}}
+	b := &pb.MatchRecord{Match: &pb.Match{MatchId: "b"}, State: pb.MatchRecord_AWAITING_READY}
+	assert.Nil(service.CreateMatch(ctx, a))
+	assert.Nil(service.CreateMatch(ctx, b))
+
+	// Unknown matches are ignored.
+	records, err = service.GetMatches(ctx, []string{"a", "b", "c"})
+	assert.Nil(err)
+	assert.Len(records, 2)
+	assert.True(proto.Equal(a, records["a"]))
+	assert.True(proto.Equal(b, records["b"]))
+}
+
+func TestReadyCheckMatchUpdate(t *testing.T) {
+	assert := assert.New(t)
+	cfg, closer := createRedis(t, true, "")
+	defer closer()
+	service := New(cfg)
+	assert.NotNil(service)
+	defer service.Close()
+
+	ctx := utilTesting.NewContext(t)
+
+	_, err := service.GetReadyCheckMatchID(ctx, "a")
+	assert.Equal(codes.NotFound, status.Convert(err).Code())
+
+	assert.Nil(service.CreateMatch(ctx, &pb.MatchRecord{
+		Match: &pb.Match{
+			MatchId: "1",
+			Tickets: []*pb.Ticket{{Id: "a"}, {Id: "b"}},
+		},
+		State: pb.MatchRecord_AWAITING_READY,
+	}))
+
+	id, err := service.GetReadyCheckMatchID(ctx, "b")
+	assert.Nil(err)
+	assert.Equal("1", id)
+
+	// Not assignable until ready.
+	records, err := service.GetUnassignedMatches(ctx)
+	assert.Nil(err)
+	assert.Empty(records)
+
+	failed := errors.New("failed")
+	_, err = service.UpdateMatch(ctx, "1", func(record *pb.MatchRecord) error {
+		record.State = pb.MatchRecord_UNASSIGNED
+		return failed
+	})
+	assert.Equal(failed, err)
+
+	got, err := service.UpdateMatch(ctx, "1", func(record *pb.MatchRecord) error {
+		record.AcceptedTicketIds = []string{"a", "b"}
+		record.State = pb.MatchRecord_UNASSIGNED
+		return nil
+	})
+	assert.Nil(err)
+	assert.Equal([]string{"a", "b"}, got.AcceptedTicketIds)
+
+	records, err = service.GetUnassignedMatches(ctx)
+	assert.Nil(err)
+	assert.Len(records, 1)
+	assert.True(proto.Equal(got, records[0]))
+}
+
+func TestGetTicketMatchIDs(t *testing.T) {
+	assert := assert.New(t)
+	cfg, closer := createRedis(t, true, "")
+	defer closer()
+	service := New(cfg)
+	assert.NotNil(service)
+	defer service.Close()
+
+	ctx := utilTesting.NewContext(t)
+
+	ids, err := service.GetTicketMatchIDs(ctx, []string{"a"})
+	assert.Nil(err)
+	assert.Empty(ids)
+
+	for _, m := range []*pb.Match{
+		{MatchId: "1", Tickets: []*pb.Ticket{{Id: "a"}, {Id: "b"}}},
+		{MatchId: "2", Tickets: []*pb.Ticket{{Id: "c"}, {Id: "d"}}},
+		// Matching a ticket again replaces its latest match.
+		{MatchId: "3", Tickets: []*pb.Ticket{{Id: "d"}, {Id: "e"}}},
+	} {
+		assert.Nil(service.CreateMatch(ctx, &pb.MatchRecord{Match: m}))
+	}
+
+	ids, err = service.GetTicketMatchIDs(ctx, []string{"a", "b", "d", "e", "f"})
+	assert.Nil(err)
+	assert.Equal([]string{"1", "3"}, ids)
+}
+
+func TestLeaseLifecycle(t *testing.T) {
+	assert := assert.New(t)
+	cfg, closer := createRedis(t, true, "")
+	defer closer()
+	service := New(cfg)
+	assert.NotNil(service)
+	defer service.Close()
+
+	ctx := utilTesting.NewContext(t)
+
+	holder, err := service.GetLeaseHolder(ctx, "lease")
+	assert.Nil(err)
+	assert.Equal("", holder)
+
+	acquired, err := service.AcquireLease(ctx, "lease", "a", time.Minute)
+	assert.Nil(err)
+	assert.True(acquired)
+
+	acquired, err = service.AcquireLease(ctx, "lease", "b", time.Minute)
+	assert.Nil(err)
+	assert.False(acquired)
+
+	// Renewing a held lease succeeds.
+	acquired, err = service.AcquireLease(ctx, "lease", "a", time.Minute)
+	assert.Nil(err)
+	assert.True(acquired)
+
+	holder, err = service.GetLeaseHolder(ctx, "lease")
+	assert.Nil(err)
+	assert.Equal("a", holder)
+
+	// Only the holder can release the lease.
+	err = service.ReleaseLease(ctx, "lease", "b")
+	assert.Nil(err)
+	holder, err = service.GetLeaseHolder(ctx, "lease")
+	assert.Nil(err)
+	assert.Equal("a", holder)
+
+	err = service.ReleaseLease(ctx, "lease", "a")
+	assert.Nil(err)
+	holder, err = service.GetLeaseHolder(ctx, "lease")
+	assert.Nil(err)
+	assert.Equal("", holder)
+
+	acquired, err = service.AcquireLease(ctx, "lease", "b", time.Minute)
+	assert.Nil(err)
+	assert.True(acquired)
+}
+
 func TestConnect(t *testing.T) {
 	testConnect(t, false, "")
 	testConnect(t, false, "redispassword")
@@ -289,6 +624,7 @@ func createRedis(t *testing.T, withSentinel bool, withPassword string) (config.V
 	cfg.Set("redis.pool.healthCheckTimeout", 100*time.Millisecond)
 	cfg.Set("redis.pool.maxActive", 5)
 	cfg.Set("pendingReleaseTimeout", "200ms")
+	cfg.Set("assignedDeleteTimeout", time.Minute)
 	cfg.Set("backoff.initialInterval", 100*time.Millisecond)
 	cfg.Set("backoff.randFactor", 0.5)
 	cfg.Set("backoff.multiplier", 0.5)
@@ -336,3 +672,207 @@ func createRedis(t *testing.T, withSentinel bool, withPassword string) (config.V
 		}
 	}
 }
+
+func TestTenantIsolation(t *testing.T) {
+	assert := assert.New(t)
+	cfg, closer := createRedis(t, true, "")
+	defer closer()
+	service := New(cfg)
+	assert.NotNil(service)
+	defer service.Close()
+
+	ctx := utilTesting.NewContext(t)
+	ctxA := tenant.NewContext(ctx, "a")
+	ctxB := tenant.NewContext(ctx, "b")
+
+	ticket := &pb.Ticket{Id: "1"}
+	assert.Nil(service.CreateTicket(ctxA, ticket))
+	assert.Nil(service.IndexTicket(ctxA, ticket))
+
+	_, err := service.GetTicket(ctxB, "1")
+	assert.Equal(codes.NotFound, status.Convert(err).Code())
+	_, err = service.GetTicket(ctx, "1")
+	assert.Equal(codes.NotFound, status.Convert(err).Code())
+
+	got, err := service.GetTicket(ctxA, "1")
+	assert.Nil(err)
+	assert.Equal("1", got.GetId())
+
+	for _, tc := range []struct {
+		ctx  context.Context
+		want int
+	}{{ctx, 0}, {ctxA, 1}, {ctxB, 0}} {
+		ids, err := service.GetIndexedIDSet(tc.ctx)
+		assert.Nil(err)
+		assert.Len(ids, tc.want)
+
+		count, err := service.CountIndexedTickets(tc.ctx)
+		assert.Nil(err)
+		assert.Equal(tc.want, count)
+	}
+
+	// Ignoring the ticket in another tenant leaves it in the pool.
+	assert.Nil(service.AddTicketsToIgnoreList(ctxB, []string{"1"}))
+	ids, err := service.GetIndexedIDSet(ctxA)
+	assert.Nil(err)
+	assert.Len(ids, 1)
+}
+
+func TestIndexTicketWithinLimit(t *testing.T) {
+	assert := assert.New(t)
+	cfg, closer := createRedis(t, true, "")
+	defer closer()
+	service := New(cfg)
+	assert.NotNil(service)
+	defer service.Close()
+	ctx := utilTesting.NewContext(t)
+
+	for _, tc := range []struct {
+		id   string
+		max  int
+		want bool
+	}{
+		{"1", 2, true},
+		{"2", 2, true},
+		{"3", 2, false},
+		{"3", 0, true},
+	} {
+		indexed, err := service.IndexTicketWithinLimit(ctx, &pb.Ticket{Id: tc.id}, tc.max)
+		assert.Nil(err)
+		assert.Equal(tc.want, indexed, tc.id)
+	}
+
+	ids, err := service.GetIndexedIDSet(ctx)
+	assert.Nil(err)
+	assert.Len(ids, 3)
+}
+
+func TestTicketLifecycleEvents(t *testing.T) {
+	assert := assert.New(t)
+	cfg, closer := createRedis(t, true, "")
+	defer closer()
+	service := New(cfg)
+	assert.NotNil(service)
+	defer service.Close()
+	ctx := utilTesting.NewContext(t)
+
+	created := time.Unix(100, 0)
+	proposed := time.Unix(110, 0)
+	matched := time.Unix(120, 0)
+
+	assert.Nil(service.RecordTicketEvents(ctx, TicketCreated, map[string]time.Time{"1": created, "2": created}))
+	assert.Nil(service.RecordTicketEvents(ctx, TicketProposed, map[string]time.Time{"1": proposed}))
+	// Only the first time each event is reached is kept.
+	assert.Nil(service.RecordTicketEvents(ctx, TicketProposed, map[string]time.Time{"1": matched}))
+	matchedProto, err := ptypes.TimestampProto(matched)
+	assert.Nil(err)
+	assert.Nil(service.CreateMatch(ctx, &pb.MatchRecord{
+		Match: &pb.Match{
+			MatchId:       "match-1",
+			MatchProfile:  "1v1",
+			MatchFunction: "mmf",
+			Tickets:       []*pb.Ticket{{Id: "1"}},
+		},
+		CreateTime: matchedProto,
+	}))
+
+	lifecycles, err := service.GetTicketLifecycles(ctx, []string{"1", "2", "3"})
+	assert.Nil(err)
+	assert.Equal(map[string]*TicketLifecycle{
+		"1": {
+			Times: map[TicketEvent]time.Time{
+				TicketCreated:  created,
+				TicketProposed: proposed,
+				TicketMatched:  matched,
+			},
+			MatchID:  "match-1",
+			Profile:  "1v1",
+			Function: "mmf",
+		},
+		"2": {
+			Times: map[TicketEvent]time.Time{TicketCreated: created},
+		},
+	}, lifecycles)
+
+	// Lifecycles expire after ticketLifecycleTimeout without events.
+	rb, ok := service.(*instrumentedService).s.(*redisBackend)
+	assert.True(ok)
+	conn, err := rb.connect(ctx)
+	assert.Nil(err)
+	defer conn.Close()
+	ttl, err := redis.Int64(conn.Do("PTTL", tenantKey(ctx, ticketLifecyclePrefix+"1")))
+	assert.Nil(err)
+	assert.True(ttl > 0 && ttl <= defaultTicketLifecycleTimeout.Milliseconds(), "ttl = %d", ttl)
+}
+
+func TestTicketOwnerLifecycle(t *testing.T) {
+	assert := assert.New(t)
+	cfg, closer := createRedis(t, true, "")
+	defer closer()
+	service := New(cfg)
+	assert.NotNil(service)
+	defer service.Close()
+	ctx := utilTesting.NewContext(t)
+
+	for _, id := range []string{"1", "2"} {
+		assert.Nil(service.CreateTicket(ctx, &pb.Ticket{Id: id}))
+		set, err := service.SetTicketOwner(ctx, id, "player-1", 2)
+		assert.Nil(err)
+		assert.True(set)
+	}
+
+	owner, err := service.GetTicketOwner(ctx, "1")
+	assert.Nil(err)
+	assert.Equal("player-1", owner)
+
+	// The owner has as many tickets as allowed.
+	assert.Nil(service.CreateTicket(ctx, &pb.Ticket{Id: "3"}))
+	set, err := service.SetTicketOwner(ctx, "3", "player-1", 2)
+	assert.Nil(err)
+	assert.False(set)
+	owner, err = service.GetTicketOwner(ctx, "3")
+	assert.Nil(err)
+	assert.Equal("", owner)
+
+	assert.Nil(service.DeleteTicket(ctx, "1"))
+	owner, err = service.GetTicketOwner(ctx, "1")
+	assert.Nil(err)
+	assert.Equal("", owner)
+	set, err = service.SetTicketOwner(ctx, "3", "player-1", 2)
+	assert.Nil(err)
+	assert.True(set)
+
+	// Expired tickets, such as assigned tickets, no longer count.
+	rb := service.(*instrumentedService).s.(*redisBackend)
+	conn, err := rb.connect(ctx)
+	assert.Nil(err)
+	defer conn.Close()
+	_, err = conn.Do("DEL", tenantKey(ctx, "2"))
+	assert.Nil(err)
+	assert.Nil(service.CreateTicket(ctx, &pb.Ticket{Id: "4"}))
+	set, err = service.SetTicketOwner(ctx, "4", "player-1", 2)
+	assert.Nil(err)
+	assert.True(set)
+	owner, err = service.GetTicketOwner(ctx, "2")
+	assert.Nil(err)
+	assert.Equal("", owner)
+
+	// The script only uses the keys it is given, so it refuses to count
+	// tickets of the owner which weren't read beforehand.
+	result, err := redis.Int(setTicketOwnerScript.Do(conn, 2,
+		tenantKey(ctx, ownedTicketsPrefix+"player-1"), tenantKey(ctx, ticketOwnerPrefix+"7"),
+		"7", "player-1", 5))
+	assert.Nil(err)
+	assert.Equal(-1, result)
+	owner, err = service.GetTicketOwner(ctx, "7")
+	assert.Nil(err)
+	assert.Equal("", owner)
+
+	// Tickets of other owners, and without a limit, aren't counted.
+	set, err = service.SetTicketOwner(ctx, "5", "player-2", 2)
+	assert.Nil(err)
+	assert.True(set)
+	set, err = service.SetTicketOwner(ctx, "6", "player-1", 0)
+	assert.Nil(err)
+	assert.True(set)
+}
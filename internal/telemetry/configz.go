@@ -23,7 +23,6 @@ import (
 	"sort"
 	"strings"
 
-	"github.com/spf13/viper"
 	"open-match.dev/open-match/internal/config"
 )
 
@@ -60,9 +59,10 @@ type configZValue struct {
 
 // ServeHTTP serves the /configz endpoint that allows a user to view the configuration of the server.
 func (cz *configz) ServeHTTP(w http.ResponseWriter, req *http.Request) {
-	cfg, ok := cz.cfg.(*viper.Viper)
+	cfg, ok := cz.cfg.(interface{ AllSettings() map[string]interface{} })
 	if !ok {
 		http.Error(w, "Configuration is not a *viper.Viper object", http.StatusInternalServerError)
+		return
 	}
 	values := []configZValue{}
 	settings := cfg.AllSettings()
@@ -35,6 +35,7 @@ const (
 * <a href="/debug/pprof/profile">/debug/pprof/profile</a> - PProf
 * <a href="/debug/pprof/symbol">/debug/pprof/symbol</a> - PProf
 * <a href="/debug/pprof/trace">/debug/pprof/trace</a> - Execution Trace
+* <a href="/debug/cycles">/debug/cycles</a> - Latest Synchronizer Cycles, synchronizer only
 * <a href="/metrics">/metrics</a> - Raw Metrics, use prometheus or grafana instead.
 
 <i>For /debug/pprof/ links see, https://golang.org/pkg/net/http/pprof/ for details.</i>
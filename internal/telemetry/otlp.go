@@ -0,0 +1,558 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/trace"
+	"open-match.dev/open-match/internal/config"
+)
+
+const (
+	// ConfigNameEnableOTLP indicates that traces and metrics are exported to
+	// an OpenTelemetry collector.
+	ConfigNameEnableOTLP = "telemetry.otlp.enable"
+	// configNameOTLPEndpoint is the base URL of the OTLP/HTTP receiver of the
+	// collector.
+	configNameOTLPEndpoint = "telemetry.otlp.endpoint"
+	defaultOTLPEndpoint    = "http://localhost:4318"
+
+	otlpFlushInterval = time.Second
+	otlpBatchSize     = 512
+	// otlpMaxQueuedSpans and otlpMaxQueuedMetrics bound the spans and metrics
+	// waiting to be exported, the newest being dropped while the collector is
+	// unreachable.
+	otlpMaxQueuedSpans   = 8192
+	otlpMaxQueuedMetrics = 2048
+	otlpTimeout          = 10 * time.Second
+)
+
+// InstrumentationEnabled returns true if RPCs are instrumented, recording
+// metrics and propagating traces.
+func InstrumentationEnabled(cfg config.View) bool {
+	return cfg.GetBool(ConfigNameEnableMetrics) || cfg.GetBool(ConfigNameEnableOTLP)
+}
+
+func bindOTLP(p Params, b Bindings) error {
+	cfg := p.Config()
+
+	if !cfg.GetBool(ConfigNameEnableOTLP) {
+		logger.Info("OpenTelemetry Export: Disabled")
+		return nil
+	}
+
+	endpoint := cfg.GetString(configNameOTLPEndpoint)
+	if endpoint == "" {
+		endpoint = defaultOTLPEndpoint
+	}
+	serviceName := p.ServiceName()
+
+	logger.WithFields(logrus.Fields{
+		"endpoint":    endpoint,
+		"serviceName": serviceName,
+	}).Info("OpenTelemetry Export: ENABLED")
+
+	e, err := newOTLPExporter(endpoint, serviceName)
+	if err != nil {
+		return errors.Wrap(err, "Failed to create the OTLP exporter")
+	}
+
+	trace.RegisterExporter(e)
+	view.RegisterExporter(e)
+	b.AddCloser(func() {
+		view.UnregisterExporter(e)
+		trace.UnregisterExporter(e)
+		e.stop()
+	})
+
+	return nil
+}
+
+// otlpExporter exports OpenCensus spans and views to an OpenTelemetry
+// collector, using the JSON encoding of OTLP/HTTP.
+type otlpExporter struct {
+	tracesURL   string
+	metricsURL  string
+	resource    otlpResource
+	client      *http.Client
+	flushPeriod time.Duration
+
+	m              sync.Mutex
+	spans          []*trace.SpanData
+	dropped        int
+	metrics        []otlpMetric
+	droppedMetrics int
+
+	flush chan struct{}
+	done  chan struct{}
+	wg    sync.WaitGroup
+}
+
+func newOTLPExporter(endpoint, serviceName string) (*otlpExporter, error) {
+	if !strings.HasPrefix(endpoint, "http://") && !strings.HasPrefix(endpoint, "https://") {
+		return nil, errors.Errorf("OTLP endpoint %s must be an http or https URL", endpoint)
+	}
+	endpoint = strings.TrimSuffix(endpoint, "/")
+
+	e := &otlpExporter{
+		tracesURL:  endpoint + "/v1/traces",
+		metricsURL: endpoint + "/v1/metrics",
+		resource: otlpResource{Attributes: []otlpKeyValue{
+			{Key: "service.name", Value: otlpValueOf(serviceName)},
+			{Key: "service.namespace", Value: otlpValueOf("open-match")},
+		}},
+		client:      &http.Client{Timeout: otlpTimeout},
+		flushPeriod: otlpFlushInterval,
+		flush:       make(chan struct{}, 1),
+		done:        make(chan struct{}),
+	}
+	e.wg.Add(1)
+	go e.run()
+	return e, nil
+}
+
+// ExportSpan implements trace.Exporter.  Spans are queued, and sent in
+// batches.
+func (e *otlpExporter) ExportSpan(s *trace.SpanData) {
+	e.m.Lock()
+	defer e.m.Unlock()
+	if len(e.spans) >= otlpMaxQueuedSpans {
+		e.dropped++
+		return
+	}
+	e.spans = append(e.spans, s)
+	if len(e.spans) >= otlpBatchSize {
+		select {
+		case e.flush <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// ExportView implements view.Exporter.  Views are exported by the goroutine
+// recording every measurement, so they are queued like spans rather than sent
+// right away, which would block recording while the collector is slow.
+func (e *otlpExporter) ExportView(d *view.Data) {
+	m, ok := otlpMetricOf(d)
+	if !ok {
+		return
+	}
+	e.m.Lock()
+	defer e.m.Unlock()
+	if len(e.metrics) >= otlpMaxQueuedMetrics {
+		e.droppedMetrics++
+		return
+	}
+	e.metrics = append(e.metrics, m)
+	if len(e.metrics) >= otlpBatchSize {
+		select {
+		case e.flush <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (e *otlpExporter) run() {
+	defer e.wg.Done()
+	t := time.NewTicker(e.flushPeriod)
+	defer t.Stop()
+	for {
+		select {
+		case <-t.C:
+		case <-e.flush:
+		case <-e.done:
+			e.exportSpans()
+			e.exportMetrics()
+			return
+		}
+		e.exportSpans()
+		e.exportMetrics()
+	}
+}
+
+// stop exports the queued spans and metrics, and stops exporting.
+func (e *otlpExporter) stop() {
+	close(e.done)
+	e.wg.Wait()
+}
+
+func (e *otlpExporter) exportSpans() {
+	e.m.Lock()
+	spans, dropped := e.spans, e.dropped
+	e.spans, e.dropped = nil, 0
+	e.m.Unlock()
+
+	if dropped > 0 {
+		logger.WithField("dropped", dropped).Warning("dropped spans queued for the OpenTelemetry collector")
+	}
+	for len(spans) > 0 {
+		n := len(spans)
+		if n > otlpBatchSize {
+			n = otlpBatchSize
+		}
+		if err := e.post(e.tracesURL, e.tracesRequest(spans[:n])); err != nil {
+			logger.WithError(err).WithField("spans", n).Warning("failed to export spans to the OpenTelemetry collector")
+		}
+		spans = spans[n:]
+	}
+}
+
+func (e *otlpExporter) exportMetrics() {
+	e.m.Lock()
+	metrics, dropped := e.metrics, e.droppedMetrics
+	e.metrics, e.droppedMetrics = nil, 0
+	e.m.Unlock()
+
+	if dropped > 0 {
+		logger.WithField("dropped", dropped).Warning("dropped metrics queued for the OpenTelemetry collector")
+	}
+	for len(metrics) > 0 {
+		n := len(metrics)
+		if n > otlpBatchSize {
+			n = otlpBatchSize
+		}
+		if err := e.post(e.metricsURL, e.metricsRequest(metrics[:n])); err != nil {
+			logger.WithError(err).WithField("metrics", n).Warning("failed to export metrics to the OpenTelemetry collector")
+		}
+		metrics = metrics[n:]
+	}
+}
+
+func (e *otlpExporter) metricsRequest(metrics []otlpMetric) otlpMetricsRequest {
+	return otlpMetricsRequest{ResourceMetrics: []otlpResourceMetrics{{
+		Resource: e.resource,
+		ScopeMetrics: []otlpScopeMetrics{{
+			Scope:   otlpScope{Name: "open-match"},
+			Metrics: metrics,
+		}},
+	}}}
+}
+
+func (e *otlpExporter) tracesRequest(spans []*trace.SpanData) otlpTracesRequest {
+	s := make([]otlpSpan, 0, len(spans))
+	for _, sd := range spans {
+		s = append(s, otlpSpanOf(sd))
+	}
+	return otlpTracesRequest{ResourceSpans: []otlpResourceSpans{{
+		Resource: e.resource,
+		ScopeSpans: []otlpScopeSpans{{
+			Scope: otlpScope{Name: "open-match"},
+			Spans: s,
+		}},
+	}}}
+}
+
+func (e *otlpExporter) post(url string, body interface{}) error {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), otlpTimeout)
+	defer cancel()
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+
+	// The client isn't instrumented, as tracing exports would queue more spans.
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return errors.Errorf("%s responded %s", url, resp.Status)
+	}
+	return nil
+}
+
+// The types below are the JSON encoding of the OTLP protos, see
+// https://github.com/open-telemetry/opentelemetry-proto.  64 bit integers are
+// encoded as strings, and IDs as hex strings.
+
+type otlpTracesRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpSpan struct {
+	TraceID           string         `json:"traceId"`
+	SpanID            string         `json:"spanId"`
+	ParentSpanID      string         `json:"parentSpanId,omitempty"`
+	Name              string         `json:"name"`
+	Kind              int            `json:"kind"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+	Events            []otlpEvent    `json:"events,omitempty"`
+	Links             []otlpLink     `json:"links,omitempty"`
+	Status            otlpStatus     `json:"status"`
+}
+
+type otlpEvent struct {
+	TimeUnixNano string         `json:"timeUnixNano"`
+	Name         string         `json:"name"`
+	Attributes   []otlpKeyValue `json:"attributes,omitempty"`
+}
+
+type otlpLink struct {
+	TraceID    string         `json:"traceId"`
+	SpanID     string         `json:"spanId"`
+	Attributes []otlpKeyValue `json:"attributes,omitempty"`
+}
+
+// OTLP status codes.
+const (
+	otlpStatusUnset = 0
+	otlpStatusError = 2
+)
+
+type otlpStatus struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+type otlpKeyValue struct {
+	Key   string    `json:"key"`
+	Value otlpValue `json:"value"`
+}
+
+type otlpValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+}
+
+func otlpValueOf(v interface{}) otlpValue {
+	switch v := v.(type) {
+	case string:
+		return otlpValue{StringValue: &v}
+	case bool:
+		return otlpValue{BoolValue: &v}
+	case int64:
+		s := strconv.FormatInt(v, 10)
+		return otlpValue{IntValue: &s}
+	case float64:
+		return otlpValue{DoubleValue: &v}
+	default:
+		s := fmt.Sprint(v)
+		return otlpValue{StringValue: &s}
+	}
+}
+
+func otlpAttributes(attributes map[string]interface{}) []otlpKeyValue {
+	var kvs []otlpKeyValue
+	for k, v := range attributes {
+		kvs = append(kvs, otlpKeyValue{Key: k, Value: otlpValueOf(v)})
+	}
+	return kvs
+}
+
+func otlpTime(t time.Time) string {
+	return strconv.FormatInt(t.UnixNano(), 10)
+}
+
+// otlpSpanKinds maps OpenCensus span kinds to the OTLP ones.
+var otlpSpanKinds = map[int]int{
+	trace.SpanKindUnspecified: 1, // SPAN_KIND_INTERNAL
+	trace.SpanKindServer:      2, // SPAN_KIND_SERVER
+	trace.SpanKindClient:      3, // SPAN_KIND_CLIENT
+}
+
+func otlpSpanOf(sd *trace.SpanData) otlpSpan {
+	s := otlpSpan{
+		TraceID:           sd.TraceID.String(),
+		SpanID:            sd.SpanID.String(),
+		Name:              sd.Name,
+		Kind:              otlpSpanKinds[sd.SpanKind],
+		StartTimeUnixNano: otlpTime(sd.StartTime),
+		EndTimeUnixNano:   otlpTime(sd.EndTime),
+		Attributes:        otlpAttributes(sd.Attributes),
+		Status:            otlpStatus{Code: otlpStatusUnset},
+	}
+	if sd.ParentSpanID != (trace.SpanID{}) {
+		s.ParentSpanID = sd.ParentSpanID.String()
+	}
+	if sd.Code != trace.StatusCodeOK {
+		s.Status = otlpStatus{Code: otlpStatusError, Message: sd.Message}
+	}
+	for _, a := range sd.Annotations {
+		s.Events = append(s.Events, otlpEvent{
+			TimeUnixNano: otlpTime(a.Time),
+			Name:         a.Message,
+			Attributes:   otlpAttributes(a.Attributes),
+		})
+	}
+	for _, l := range sd.Links {
+		s.Links = append(s.Links, otlpLink{
+			TraceID:    l.TraceID.String(),
+			SpanID:     l.SpanID.String(),
+			Attributes: otlpAttributes(l.Attributes),
+		})
+	}
+	return s
+}
+
+type otlpMetricsRequest struct {
+	ResourceMetrics []otlpResourceMetrics `json:"resourceMetrics"`
+}
+
+type otlpResourceMetrics struct {
+	Resource     otlpResource       `json:"resource"`
+	ScopeMetrics []otlpScopeMetrics `json:"scopeMetrics"`
+}
+
+type otlpScopeMetrics struct {
+	Scope   otlpScope    `json:"scope"`
+	Metrics []otlpMetric `json:"metrics"`
+}
+
+type otlpMetric struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	Unit        string         `json:"unit,omitempty"`
+	Sum         *otlpSum       `json:"sum,omitempty"`
+	Gauge       *otlpGauge     `json:"gauge,omitempty"`
+	Histogram   *otlpHistogram `json:"histogram,omitempty"`
+}
+
+// otlpCumulative is the cumulative aggregation temporality of OpenCensus
+// views.
+const otlpCumulative = 2
+
+type otlpSum struct {
+	DataPoints             []otlpNumberDataPoint `json:"dataPoints"`
+	AggregationTemporality int                   `json:"aggregationTemporality"`
+	IsMonotonic            bool                  `json:"isMonotonic"`
+}
+
+type otlpGauge struct {
+	DataPoints []otlpNumberDataPoint `json:"dataPoints"`
+}
+
+type otlpHistogram struct {
+	DataPoints             []otlpHistogramDataPoint `json:"dataPoints"`
+	AggregationTemporality int                      `json:"aggregationTemporality"`
+}
+
+type otlpNumberDataPoint struct {
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano"`
+	TimeUnixNano      string         `json:"timeUnixNano"`
+	AsInt             *string        `json:"asInt,omitempty"`
+	AsDouble          *float64       `json:"asDouble,omitempty"`
+}
+
+type otlpHistogramDataPoint struct {
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano"`
+	TimeUnixNano      string         `json:"timeUnixNano"`
+	Count             string         `json:"count"`
+	Sum               float64        `json:"sum"`
+	BucketCounts      []string       `json:"bucketCounts"`
+	ExplicitBounds    []float64      `json:"explicitBounds"`
+}
+
+// otlpMetricOf returns the metric of the view data, and false if its
+// aggregation isn't supported.
+func otlpMetricOf(d *view.Data) (otlpMetric, bool) {
+	m := otlpMetric{
+		Name:        d.View.Name,
+		Description: d.View.Description,
+		Unit:        d.View.Measure.Unit(),
+	}
+	start, end := otlpTime(d.Start), otlpTime(d.End)
+
+	switch d.View.Aggregation.Type {
+	case view.AggTypeCount, view.AggTypeSum:
+		m.Sum = &otlpSum{AggregationTemporality: otlpCumulative, IsMonotonic: true}
+	case view.AggTypeLastValue:
+		m.Gauge = &otlpGauge{}
+	case view.AggTypeDistribution:
+		m.Histogram = &otlpHistogram{AggregationTemporality: otlpCumulative}
+	default:
+		return m, false
+	}
+
+	for _, row := range d.Rows {
+		var attributes []otlpKeyValue
+		for _, t := range row.Tags {
+			attributes = append(attributes, otlpKeyValue{Key: t.Key.Name(), Value: otlpValueOf(t.Value)})
+		}
+		point := otlpNumberDataPoint{Attributes: attributes, StartTimeUnixNano: start, TimeUnixNano: end}
+
+		switch data := row.Data.(type) {
+		case *view.CountData:
+			s := strconv.FormatInt(data.Value, 10)
+			point.AsInt = &s
+			m.Sum.DataPoints = append(m.Sum.DataPoints, point)
+		case *view.SumData:
+			v := data.Value
+			point.AsDouble = &v
+			m.Sum.DataPoints = append(m.Sum.DataPoints, point)
+		case *view.LastValueData:
+			v := data.Value
+			point.AsDouble = &v
+			m.Gauge.DataPoints = append(m.Gauge.DataPoints, point)
+		case *view.DistributionData:
+			counts := make([]string, 0, len(data.CountPerBucket))
+			for _, c := range data.CountPerBucket {
+				counts = append(counts, strconv.FormatInt(c, 10))
+			}
+			m.Histogram.DataPoints = append(m.Histogram.DataPoints, otlpHistogramDataPoint{
+				Attributes:        attributes,
+				StartTimeUnixNano: start,
+				TimeUnixNano:      end,
+				Count:             strconv.FormatInt(data.Count, 10),
+				Sum:               data.Sum(),
+				BucketCounts:      counts,
+				ExplicitBounds:    d.View.Aggregation.Buckets,
+			})
+		}
+	}
+	return m, true
+}
@@ -0,0 +1,214 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"go.opencensus.io/trace"
+)
+
+// fakeCollector records the OTLP requests it receives by path.
+type fakeCollector struct {
+	m        sync.Mutex
+	requests map[string][]map[string]interface{}
+}
+
+func (c *fakeCollector) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	b, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	body := make(map[string]interface{})
+	if err := json.Unmarshal(b, &body); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	c.m.Lock()
+	defer c.m.Unlock()
+	c.requests[req.URL.Path] = append(c.requests[req.URL.Path], body)
+}
+
+func (c *fakeCollector) get(path string) []map[string]interface{} {
+	c.m.Lock()
+	defer c.m.Unlock()
+	return c.requests[path]
+}
+
+func TestOTLPExportSpans(t *testing.T) {
+	require := require.New(t)
+	c := &fakeCollector{requests: make(map[string][]map[string]interface{})}
+	s := httptest.NewServer(c)
+	defer s.Close()
+
+	e, err := newOTLPExporter(s.URL, "frontend")
+	require.Nil(err)
+
+	parent := trace.SpanContext{
+		TraceID: trace.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+		SpanID:  trace.SpanID{1, 2, 3, 4, 5, 6, 7, 8},
+	}
+	start := time.Unix(10, 0)
+	e.ExportSpan(&trace.SpanData{
+		SpanContext:  trace.SpanContext{TraceID: parent.TraceID, SpanID: trace.SpanID{8, 7, 6, 5, 4, 3, 2, 1}},
+		ParentSpanID: parent.SpanID,
+		SpanKind:     trace.SpanKindServer,
+		Name:         "openmatch.FrontendService.CreateTicket",
+		StartTime:    start,
+		EndTime:      start.Add(time.Second),
+		Attributes:   map[string]interface{}{"tickets": int64(3)},
+		Status:       trace.Status{Code: 5, Message: "not found"},
+	})
+	// Stopping exports the queued spans.
+	e.stop()
+
+	requests := c.get("/v1/traces")
+	require.Len(requests, 1)
+	b, err := json.Marshal(requests[0])
+	require.Nil(err)
+	assert.JSONEq(t, `{"resourceSpans": [{
+		"resource": {"attributes": [
+			{"key": "service.name", "value": {"stringValue": "frontend"}},
+			{"key": "service.namespace", "value": {"stringValue": "open-match"}}
+		]},
+		"scopeSpans": [{
+			"scope": {"name": "open-match"},
+			"spans": [{
+				"traceId": "0102030405060708090a0b0c0d0e0f10",
+				"spanId": "0807060504030201",
+				"parentSpanId": "0102030405060708",
+				"name": "openmatch.FrontendService.CreateTicket",
+				"kind": 2,
+				"startTimeUnixNano": "10000000000",
+				"endTimeUnixNano": "11000000000",
+				"attributes": [{"key": "tickets", "value": {"intValue": "3"}}],
+				"status": {"code": 2, "message": "not found"}
+			}]
+		}]
+	}]}`, string(b))
+}
+
+func TestOTLPExportView(t *testing.T) {
+	require := require.New(t)
+	c := &fakeCollector{requests: make(map[string][]map[string]interface{})}
+	s := httptest.NewServer(c)
+	defer s.Close()
+
+	e, err := newOTLPExporter(s.URL+"/", "backend")
+	require.Nil(err)
+
+	key := tag.MustNewKey("profile")
+	v := &view.View{
+		Name:        "open-match.dev/test/latency",
+		Description: "Test latency",
+		Measure:     stats.Float64("open-match.dev/test/latency", "Test latency", stats.UnitMilliseconds),
+		Aggregation: view.Distribution(10, 100),
+		TagKeys:     []tag.Key{key},
+	}
+	e.ExportView(&view.Data{
+		View:  v,
+		Start: time.Unix(10, 0),
+		End:   time.Unix(20, 0),
+		Rows: []*view.Row{{
+			Tags: []tag.Tag{{Key: key, Value: "1v1"}},
+			Data: &view.DistributionData{Count: 4, Mean: 50, CountPerBucket: []int64{1, 2, 1}},
+		}},
+	})
+	// Stopping exports the queued metrics.
+	e.stop()
+
+	requests := c.get("/v1/metrics")
+	require.Len(requests, 1)
+	b, err := json.Marshal(requests[0])
+	require.Nil(err)
+	assert.JSONEq(t, `{"resourceMetrics": [{
+		"resource": {"attributes": [
+			{"key": "service.name", "value": {"stringValue": "backend"}},
+			{"key": "service.namespace", "value": {"stringValue": "open-match"}}
+		]},
+		"scopeMetrics": [{
+			"scope": {"name": "open-match"},
+			"metrics": [{
+				"name": "open-match.dev/test/latency",
+				"description": "Test latency",
+				"unit": "ms",
+				"histogram": {
+					"aggregationTemporality": 2,
+					"dataPoints": [{
+						"attributes": [{"key": "profile", "value": {"stringValue": "1v1"}}],
+						"startTimeUnixNano": "10000000000",
+						"timeUnixNano": "20000000000",
+						"count": "4",
+						"sum": 200,
+						"bucketCounts": ["1", "2", "1"],
+						"explicitBounds": [10, 100]
+					}]
+				}
+			}]
+		}]
+	}]}`, string(b))
+}
+
+func TestOTLPExportViewDoesNotBlock(t *testing.T) {
+	release := make(chan struct{})
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		<-release
+	}))
+	defer s.Close()
+
+	e, err := newOTLPExporter(s.URL, "backend")
+	require.Nil(t, err)
+	defer func() {
+		close(release)
+		e.stop()
+	}()
+
+	v := &view.View{
+		Name:        "open-match.dev/test/count",
+		Measure:     stats.Int64("open-match.dev/test/count", "Test count", stats.UnitDimensionless),
+		Aggregation: view.Count(),
+	}
+	start := time.Now()
+	for i := 0; i < 2*otlpMaxQueuedMetrics; i++ {
+		e.ExportView(&view.Data{
+			View: v,
+			End:  time.Now(),
+			Rows: []*view.Row{{Data: &view.CountData{Value: int64(i)}}},
+		})
+	}
+	// Views are queued, and dropped once the queue is full, while the
+	// collector doesn't respond.
+	assert.True(t, time.Since(start) < otlpTimeout, "ExportView blocked for %v", time.Since(start))
+	e.m.Lock()
+	assert.True(t, len(e.metrics) <= otlpMaxQueuedMetrics)
+	e.m.Unlock()
+}
+
+func TestOTLPEndpoint(t *testing.T) {
+	_, err := newOTLPExporter("localhost:4318", "frontend")
+	assert.NotNil(t, err)
+}
@@ -40,6 +40,7 @@ func Setup(p Params, b Bindings) error {
 		bindPrometheus,
 		bindStackDriverMetrics,
 		bindOpenCensusAgent,
+		bindOTLP,
 		bindZpages,
 		bindHelp,
 		bindConfigz,
@@ -0,0 +1,166 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tenant carries the tenant of a request, which isolates the tickets,
+// matches and synchronizer cycles of several games sharing an Open Match
+// install.  The tenant is sent in the x-open-match-tenant request metadata,
+// or HTTP header.  Requests without a tenant belong to the default tenant,
+// whose data is stored as it was before tenants existed.
+package tenant
+
+import (
+	"context"
+	"regexp"
+
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	"go.opencensus.io/tag"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"open-match.dev/open-match/internal/config"
+)
+
+// MetadataKey is the request metadata key holding the tenant.
+const MetadataKey = "x-open-match-tenant"
+
+// TagKey tags metrics with the tenant of the request.
+var TagKey = tag.MustNewKey("tenant")
+
+var validName = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
+
+type contextKey struct{}
+
+// DefaultMaxActiveTickets is the quota of tenants without a configured quota,
+// unless tenantDefaults.maxActiveTickets is set.
+const DefaultMaxActiveTickets = 10000
+
+// MaxActiveTickets returns the maximum number of tickets the tenant may have
+// in the pool, configured as tenants.<name>.maxActiveTickets.  Clients name
+// their tenant, so tenants without a quota of their own get the default quota
+// rather than an unbounded pool.  Returns 0 if the tenant has no quota, which
+// is always the case for the default tenant.
+func MaxActiveTickets(cfg config.View, name string) int {
+	if name == "" {
+		return 0
+	}
+	if key := "tenants." + name + ".maxActiveTickets"; cfg.IsSet(key) {
+		return cfg.GetInt(key)
+	}
+	if cfg.IsSet("tenantDefaults.maxActiveTickets") {
+		return cfg.GetInt("tenantDefaults.maxActiveTickets")
+	}
+	return DefaultMaxActiveTickets
+}
+
+// Validate returns an error if the name is not a valid tenant.  Tenants are
+// lowercase alphanumeric, with inner dashes, and at most 63 characters.
+func Validate(name string) error {
+	if name == "" || validName.MatchString(name) {
+		return nil
+	}
+	return status.Errorf(codes.InvalidArgument, "%s %q is not a valid tenant, it must be lowercase alphanumeric with inner dashes and at most 63 characters", MetadataKey, name)
+}
+
+// NewContext returns a context of the tenant, whose metrics are tagged with
+// it.
+func NewContext(ctx context.Context, name string) context.Context {
+	ctx = context.WithValue(ctx, contextKey{}, name)
+	if tagged, err := tag.New(ctx, tag.Upsert(TagKey, name)); err == nil {
+		ctx = tagged
+	}
+	return ctx
+}
+
+// FromContext returns the tenant of the context, falling back on the
+// incoming request metadata.  Returns "" for the default tenant.
+func FromContext(ctx context.Context) string {
+	if name, ok := ctx.Value(contextKey{}).(string); ok {
+		return name
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(MetadataKey); len(values) > 0 {
+			return values[0]
+		}
+	}
+	return ""
+}
+
+func fromIncoming(ctx context.Context) (context.Context, error) {
+	var name string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(MetadataKey); len(values) > 0 {
+			name = values[0]
+		}
+	}
+	if err := Validate(name); err != nil {
+		return nil, err
+	}
+	return NewContext(ctx, name), nil
+}
+
+// UnaryServerInterceptor sets the tenant of incoming requests on their
+// context.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := fromIncoming(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor sets the tenant of incoming streams on their
+// context.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := fromIncoming(stream.Context())
+		if err != nil {
+			return err
+		}
+		wrapped := grpc_middleware.WrapServerStream(stream)
+		wrapped.WrappedContext = ctx
+		return handler(srv, wrapped)
+	}
+}
+
+// outgoing adds the tenant of the context to the outgoing request metadata,
+// so that calls made while serving a request stay in its tenant.
+func outgoing(ctx context.Context) context.Context {
+	name := FromContext(ctx)
+	if name == "" {
+		return ctx
+	}
+	if md, ok := metadata.FromOutgoingContext(ctx); ok && len(md.Get(MetadataKey)) > 0 {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, MetadataKey, name)
+}
+
+// UnaryClientInterceptor propagates the tenant of the context to the called
+// service.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(outgoing(ctx), method, req, reply, cc, opts...)
+	}
+}
+
+// StreamClientInterceptor propagates the tenant of the context to the called
+// service.
+func StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(outgoing(ctx), desc, cc, method, opts...)
+	}
+}
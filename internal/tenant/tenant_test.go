@@ -0,0 +1,117 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tenant
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestValidate(t *testing.T) {
+	for name, valid := range map[string]bool{
+		"":                      true,
+		"a":                     true,
+		"game-1":                true,
+		strings.Repeat("a", 63): true,
+		strings.Repeat("a", 64): false,
+		"-game":                 false,
+		"game-":                 false,
+		"Game":                  false,
+		"game_1":                false,
+		"game:1":                false,
+	} {
+		err := Validate(name)
+		if valid {
+			assert.Nil(t, err, name)
+		} else {
+			assert.Equal(t, codes.InvalidArgument, status.Code(err), name)
+		}
+	}
+}
+
+func TestFromContext(t *testing.T) {
+	ctx := context.Background()
+	assert.Equal(t, "", FromContext(ctx))
+
+	incoming := metadata.NewIncomingContext(ctx, metadata.Pairs(MetadataKey, "a"))
+	assert.Equal(t, "a", FromContext(incoming))
+
+	// The context value wins over the metadata.
+	assert.Equal(t, "b", FromContext(NewContext(incoming, "b")))
+	assert.Equal(t, "", FromContext(NewContext(incoming, "")))
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	interceptor := UnaryServerInterceptor()
+	var got string
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		got = FromContext(ctx)
+		return nil, nil
+	}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(MetadataKey, "a"))
+	_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{}, handler)
+	assert.Nil(t, err)
+	assert.Equal(t, "a", got)
+
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs(MetadataKey, "Not Valid"))
+	_, err = interceptor(ctx, nil, &grpc.UnaryServerInfo{}, handler)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestUnaryClientInterceptor(t *testing.T) {
+	interceptor := UnaryClientInterceptor()
+	var got metadata.MD
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		got, _ = metadata.FromOutgoingContext(ctx)
+		return nil
+	}
+
+	assert.Nil(t, interceptor(context.Background(), "", nil, nil, nil, invoker))
+	assert.Empty(t, got.Get(MetadataKey))
+
+	assert.Nil(t, interceptor(NewContext(context.Background(), "a"), "", nil, nil, nil, invoker))
+	assert.Equal(t, []string{"a"}, got.Get(MetadataKey))
+
+	// An explicitly set tenant is kept.
+	ctx := metadata.AppendToOutgoingContext(NewContext(context.Background(), "a"), MetadataKey, "b")
+	assert.Nil(t, interceptor(ctx, "", nil, nil, nil, invoker))
+	assert.Equal(t, []string{"b"}, got.Get(MetadataKey))
+}
+
+func TestMaxActiveTickets(t *testing.T) {
+	cfg := viper.New()
+	cfg.Set("tenants.a.maxActiveTickets", 10)
+
+	assert.Equal(t, 10, MaxActiveTickets(cfg, "a"))
+	assert.Equal(t, DefaultMaxActiveTickets, MaxActiveTickets(cfg, "b"))
+	assert.Equal(t, 0, MaxActiveTickets(cfg, ""))
+
+	cfg.Set("tenantDefaults.maxActiveTickets", 5)
+	assert.Equal(t, 10, MaxActiveTickets(cfg, "a"))
+	assert.Equal(t, 5, MaxActiveTickets(cfg, "b"))
+	assert.Equal(t, 0, MaxActiveTickets(cfg, ""))
+
+	cfg.Set("tenants.a.maxActiveTickets", 0)
+	assert.Equal(t, 0, MaxActiveTickets(cfg, "a"))
+}
@@ -28,7 +28,7 @@ import (
 	mmfService "open-match.dev/open-match/internal/testing/mmf"
 )
 
-func start(t *testing.T, eval evaluator.Evaluator, mmf mmfService.MatchFunction) (config.View, func(time.Duration)) {
+func start(t *testing.T, eval evaluator.RejectingEvaluator, mmf mmfService.MatchFunction) (config.View, func(time.Duration)) {
 	clusterLock.Lock()
 	t.Cleanup(func() {
 		clusterLock.Unlock()
@@ -64,6 +64,6 @@ func start(t *testing.T, eval evaluator.Evaluator, mmf mmfService.MatchFunction)
 }
 
 var clusterLock sync.Mutex
-var clusterEval evaluator.Evaluator
+var clusterEval evaluator.RejectingEvaluator
 var clusterMMF mmfService.MatchFunction
 var clusterStarted bool
@@ -66,11 +66,11 @@ func TestMain(m *testing.M) {
 		return clusterMMF(ctx, profile, out)
 	}
 
-	eval := func(ctx context.Context, in <-chan *pb.Match, out chan<- string) error {
-		return clusterEval(ctx, in, out)
+	eval := func(ctx context.Context, in <-chan *pb.Match, out chan<- string, rejected chan<- *pb.RejectedMatch) error {
+		return clusterEval(ctx, in, out, rejected)
 	}
 
-	cleanup, err := apptest.RunInCluster(mmfService.BindServiceFor(mmf), evaluator.BindServiceFor(eval))
+	cleanup, err := apptest.RunInCluster(mmfService.BindServiceFor(mmf), evaluator.BindServiceForRejecting(eval))
 	if err != nil {
 		fmt.Println("Error starting mmf and evaluator:", err)
 		os.Exit(1)
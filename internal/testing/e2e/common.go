@@ -77,7 +77,7 @@ type om struct {
 	mmfCalled  bool
 	evalCalled bool
 	mmf        mmfService.MatchFunction
-	eval       evaluator.Evaluator
+	eval       evaluator.RejectingEvaluator
 }
 
 func (om *om) SetMMF(mmf mmfService.MatchFunction) {
@@ -106,6 +106,12 @@ func (om *om) runMMF(ctx context.Context, profile *pb.MatchProfile, out chan<- *
 }
 
 func (om *om) SetEvaluator(eval evaluator.Evaluator) {
+	om.SetRejectingEvaluator(func(ctx context.Context, in <-chan *pb.Match, out chan<- string, _ chan<- *pb.RejectedMatch) error {
+		return eval(ctx, in, out)
+	})
+}
+
+func (om *om) SetRejectingEvaluator(eval evaluator.RejectingEvaluator) {
 	om.fLock.Lock()
 	defer om.fLock.Unlock()
 
@@ -116,7 +122,7 @@ func (om *om) SetEvaluator(eval evaluator.Evaluator) {
 	om.t.Fatal("Evaluator function set multiple times")
 }
 
-func (om *om) evaluate(ctx context.Context, in <-chan *pb.Match, out chan<- string) error {
+func (om *om) evaluate(ctx context.Context, in <-chan *pb.Match, out chan<- string, rejected chan<- *pb.RejectedMatch) error {
 	om.fLock.Lock()
 	om.running.Add(1)
 	defer om.running.Done()
@@ -127,7 +133,7 @@ func (om *om) evaluate(ctx context.Context, in <-chan *pb.Match, out chan<- stri
 	if eval == nil {
 		return errors.New("Evaluator called without being set")
 	}
-	return eval(ctx, in, out)
+	return eval(ctx, in, out, rejected)
 }
 
 func (om *om) Frontend() pb.FrontendServiceClient {
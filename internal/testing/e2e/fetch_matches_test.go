@@ -22,6 +22,9 @@ import (
 	"time"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc/codes"
@@ -76,6 +79,82 @@ func TestHappyPath(t *testing.T) {
 	require.Nil(t, resp)
 }
 
+// TestRejectedProposals covers returning the reasons proposals were not
+// accepted, when requested.
+func TestRejectedProposals(t *testing.T) {
+	ctx := context.Background()
+	om := newOM(t)
+
+	t1, err := om.Frontend().CreateTicket(ctx, &pb.CreateTicketRequest{Ticket: &pb.Ticket{}})
+	require.Nil(t, err)
+	t2, err := om.Frontend().CreateTicket(ctx, &pb.CreateTicketRequest{Ticket: &pb.Ticket{}})
+	require.Nil(t, err)
+
+	accepted := &pb.Match{
+		MatchId: "accepted",
+		Tickets: []*pb.Ticket{t1},
+	}
+	collided := &pb.Match{
+		MatchId: "collided",
+		Tickets: []*pb.Ticket{t1},
+	}
+	ignored := &pb.Match{
+		MatchId: "ignored",
+		Tickets: []*pb.Ticket{t2},
+	}
+
+	om.SetMMF(func(ctx context.Context, profile *pb.MatchProfile, out chan<- *pb.Match) error {
+		out <- accepted
+		out <- collided
+		out <- ignored
+		return nil
+	})
+
+	om.SetRejectingEvaluator(func(ctx context.Context, in <-chan *pb.Match, out chan<- string, rejected chan<- *pb.RejectedMatch) error {
+		for range in {
+		}
+		out <- accepted.MatchId
+		rejected <- &pb.RejectedMatch{
+			MatchId:          collided.MatchId,
+			Reason:           pb.RejectedMatch_COLLISION,
+			CollidingMatchId: accepted.MatchId,
+		}
+		return nil
+	})
+
+	stream, err := om.Backend().FetchMatches(ctx, &pb.FetchMatchesRequest{
+		Config:                   om.MMFConfigGRPC(),
+		Profile:                  &pb.MatchProfile{},
+		IncludeRejectedProposals: true,
+	})
+	require.Nil(t, err)
+
+	rejections := map[string]*pb.RejectedProposal{}
+	var matches []*pb.Match
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.Nil(t, err)
+		if resp.RejectedProposal != nil {
+			rejections[resp.RejectedProposal.Proposal.MatchId] = resp.RejectedProposal
+		} else {
+			matches = append(matches, resp.Match)
+		}
+	}
+
+	require.Len(t, matches, 1)
+	require.True(t, proto.Equal(accepted, matches[0]))
+
+	require.Len(t, rejections, 2)
+	require.True(t, proto.Equal(collided, rejections["collided"].Proposal))
+	require.Equal(t, pb.RejectedMatch_COLLISION, rejections["collided"].Rejection.Reason)
+	require.Equal(t, accepted.MatchId, rejections["collided"].Rejection.CollidingMatchId)
+	require.True(t, proto.Equal(ignored, rejections["ignored"].Proposal))
+	require.Equal(t, pb.RejectedMatch_UNKNOWN, rejections["ignored"].Rejection.Reason)
+}
+
 // TestMatchFunctionMatchCollision covers two matches with the same id coming
 // from the same MMF generates an error to the fetch matches call.  Also ensures
 // another function running in the same cycle does not experience an error.
@@ -841,3 +920,129 @@ func TestHTTPMMF(t *testing.T) {
 	require.Equal(t, err, io.EOF)
 	require.Nil(t, resp)
 }
+
+// TestShardsCycleIndependently covers a slow MMF not delaying profiles of
+// another shard.
+func TestShardsCycleIndependently(t *testing.T) {
+	ctx := context.Background()
+	om := newOM(t)
+
+	fastDone := make(chan struct{})
+
+	om.SetMMF(func(ctx context.Context, profile *pb.MatchProfile, out chan<- *pb.Match) error {
+		if profile.GetName() == "slow" {
+			select {
+			case <-fastDone:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		out <- &pb.Match{MatchId: profile.GetName()}
+		return nil
+	})
+
+	om.SetEvaluator(func(ctx context.Context, in <-chan *pb.Match, out chan<- string) error {
+		for m := range in {
+			out <- m.GetMatchId()
+		}
+		return nil
+	})
+
+	profile := func(shard string) *pb.MatchProfile {
+		a, err := ptypes.MarshalAny(&wrappers.StringValue{Value: shard})
+		require.Nil(t, err)
+		return &pb.MatchProfile{
+			Name:       shard,
+			Extensions: map[string]*any.Any{"shard": a},
+		}
+	}
+
+	slowResult := make(chan *pb.Match)
+	go func() {
+		stream, err := om.Backend().FetchMatches(ctx, &pb.FetchMatchesRequest{
+			Config:  om.MMFConfigGRPC(),
+			Profile: profile("slow"),
+		})
+		require.Nil(t, err)
+		resp, err := stream.Recv()
+		require.Nil(t, err)
+		slowResult <- resp.GetMatch()
+	}()
+
+	startTime := time.Now()
+	stream, err := om.Backend().FetchMatches(ctx, &pb.FetchMatchesRequest{
+		Config:  om.MMFConfigGRPC(),
+		Profile: profile("fast"),
+	})
+	require.Nil(t, err)
+
+	resp, err := stream.Recv()
+	require.Nil(t, err)
+	require.Equal(t, "fast", resp.GetMatch().GetMatchId())
+	resp, err = stream.Recv()
+	require.Equal(t, io.EOF, err)
+	require.Nil(t, resp)
+	// A shared cycle would wait for the slow MMF's proposal window to close.
+	require.True(t, time.Since(startTime) < registrationInterval+proposalCollectionInterval, "%s", time.Since(startTime))
+
+	close(fastDone)
+	require.Equal(t, "slow", (<-slowResult).GetMatchId())
+}
+
+// TestMatchRecords covers matches returned by FetchMatches being recorded, so
+// that they can be resumed.
+func TestMatchRecords(t *testing.T) {
+	ctx := context.Background()
+	om := newOM(t)
+
+	t1, err := om.Frontend().CreateTicket(ctx, &pb.CreateTicketRequest{Ticket: &pb.Ticket{}})
+	require.Nil(t, err)
+
+	m := &pb.Match{
+		MatchId: "1",
+		Tickets: []*pb.Ticket{t1},
+	}
+
+	om.SetMMF(func(ctx context.Context, profile *pb.MatchProfile, out chan<- *pb.Match) error {
+		out <- m
+		return nil
+	})
+
+	om.SetEvaluator(func(ctx context.Context, in <-chan *pb.Match, out chan<- string) error {
+		for p := range in {
+			out <- p.GetMatchId()
+		}
+		return nil
+	})
+
+	_, err = om.Backend().GetMatch(ctx, &pb.GetMatchRequest{MatchId: "1"})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	profile := &pb.MatchProfile{Name: "profile"}
+	stream, err := om.Backend().FetchMatches(ctx, &pb.FetchMatchesRequest{
+		Config:  om.MMFConfigGRPC(),
+		Profile: profile,
+	})
+	require.Nil(t, err)
+
+	resp, err := stream.Recv()
+	require.Nil(t, err)
+	require.True(t, proto.Equal(m, resp.Match))
+
+	record, err := om.Backend().GetMatch(ctx, &pb.GetMatchRequest{MatchId: "1"})
+	require.Nil(t, err)
+	require.True(t, proto.Equal(m, record.Match))
+	require.True(t, proto.Equal(profile, record.Profile))
+	require.True(t, proto.Equal(om.MMFConfigGRPC(), record.Config))
+	require.Equal(t, pb.MatchRecord_UNASSIGNED, record.State)
+	require.NotNil(t, record.CreateTime)
+
+	list, err := om.Backend().ListMatches(ctx, &pb.ListMatchesRequest{})
+	require.Nil(t, err)
+	require.Len(t, list.Matches, 1)
+	require.True(t, proto.Equal(record, list.Matches[0]))
+
+	list, err = om.Backend().ListMatches(ctx, &pb.ListMatchesRequest{ProfileName: "other"})
+	require.Nil(t, err)
+	require.Empty(t, list.Matches)
+}
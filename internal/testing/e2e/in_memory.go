@@ -34,7 +34,7 @@ import (
 	mmfService "open-match.dev/open-match/internal/testing/mmf"
 )
 
-func start(t *testing.T, eval evaluator.Evaluator, mmf mmfService.MatchFunction) (config.View, func(time.Duration)) {
+func start(t *testing.T, eval evaluator.RejectingEvaluator, mmf mmfService.MatchFunction) (config.View, func(time.Duration)) {
 	mredis := miniredis.NewMiniRedis()
 	err := mredis.StartAddr("localhost:0")
 	if err != nil {
@@ -79,7 +79,7 @@ func start(t *testing.T, eval evaluator.Evaluator, mmf mmfService.MatchFunction)
 	cfg.Set("redis.sentinelMaster", msentinal.MasterInfo().Name)
 	services := []string{apptest.ServiceName, "synchronizer", "backend", "frontend", "query", "evaluator"}
 	for _, name := range services {
-		cfg.Set("api."+name+".hostname", "localhost")
+		cfg.Set("api."+name+".hostname", "127.0.0.1")
 		cfg.Set("api."+name+".grpcport", grpcPort)
 		cfg.Set("api."+name+".httpport", httpPort)
 	}
@@ -87,6 +87,6 @@ func start(t *testing.T, eval evaluator.Evaluator, mmf mmfService.MatchFunction)
 	cfg.Set("logging.level", *testOnlyLoggingLevel)
 	cfg.Set(telemetry.ConfigNameEnableMetrics, *testOnlyEnableMetrics)
 
-	apptest.TestApp(t, cfg, listeners, minimatch.BindService, mmfService.BindServiceFor(mmf), evaluator.BindServiceFor(eval))
+	apptest.TestApp(t, cfg, listeners, minimatch.BindService, mmfService.BindServiceFor(mmf), evaluator.BindServiceForRejecting(eval))
 	return cfg, mredis.FastForward
 }
@@ -208,3 +208,45 @@ func returnedByQueryID(t *testing.T, tc testcases.TestCase) (found bool) {
 
 	return len(ids) == 1
 }
+
+func TestTicketOrder(t *testing.T) {
+	om := newOM(t)
+	ctx := context.Background()
+
+	var ids []string
+	for _, priority := range []float64{1, 5, 5, 3} {
+		ticket, err := om.Frontend().CreateTicket(ctx, &pb.CreateTicketRequest{Ticket: &pb.Ticket{Priority: priority}})
+		require.Nil(t, err)
+		ids = append(ids, ticket.Id)
+	}
+	// Equal priorities are ordered oldest first.
+	want := []string{ids[1], ids[2], ids[3], ids[0]}
+
+	{
+		stream, err := om.Query().QueryTickets(ctx, &pb.QueryTicketsRequest{Pool: &pb.Pool{}, Order: pb.TicketOrder_PRIORITY})
+		require.Nil(t, err)
+
+		resp, err := stream.Recv()
+		require.Nil(t, err)
+		var got []string
+		for _, ticket := range resp.Tickets {
+			got = append(got, ticket.Id)
+		}
+		require.Equal(t, want, got)
+
+		_, err = stream.Recv()
+		require.Equal(t, io.EOF, err)
+	}
+
+	{
+		stream, err := om.Query().QueryTicketIds(ctx, &pb.QueryTicketIdsRequest{Pool: &pb.Pool{}, Order: pb.TicketOrder_PRIORITY})
+		require.Nil(t, err)
+
+		resp, err := stream.Recv()
+		require.Nil(t, err)
+		require.Equal(t, want, resp.Ids)
+
+		_, err = stream.Recv()
+		require.Equal(t, io.EOF, err)
+	}
+}
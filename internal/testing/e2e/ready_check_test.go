@@ -0,0 +1,182 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package e2e
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+// fetchReadyCheckMatch returns a match of the two tickets with a ready check,
+// and the stream to receive its result from.
+func fetchReadyCheckMatch(t *testing.T, om *om, t1, t2 *pb.Ticket, rc *pb.ReadyCheck) pb.BackendService_FetchMatchesClient {
+	ctx := context.Background()
+
+	om.SetMMF(func(ctx context.Context, profile *pb.MatchProfile, out chan<- *pb.Match) error {
+		out <- &pb.Match{MatchId: "ready-check", Tickets: []*pb.Ticket{t1, t2}}
+		return nil
+	})
+	om.SetEvaluator(func(ctx context.Context, in <-chan *pb.Match, out chan<- string) error {
+		for m := range in {
+			out <- m.MatchId
+		}
+		return nil
+	})
+
+	stream, err := om.Backend().FetchMatches(ctx, &pb.FetchMatchesRequest{
+		Config:     om.MMFConfigGRPC(),
+		Profile:    &pb.MatchProfile{Name: "test-profile"},
+		ReadyCheck: rc,
+	})
+	require.Nil(t, err)
+
+	resp, err := stream.Recv()
+	require.Nil(t, err)
+	require.Equal(t, "ready-check", resp.GetMatch().GetMatchId())
+	return stream
+}
+
+func recvReadyCheckResult(t *testing.T, stream pb.BackendService_FetchMatchesClient) *pb.ReadyCheckResult {
+	resp, err := stream.Recv()
+	require.Nil(t, err)
+	require.NotNil(t, resp.GetReadyCheckResult())
+
+	_, err = stream.Recv()
+	require.Equal(t, io.EOF, err)
+	return resp.GetReadyCheckResult()
+}
+
+func TestReadyCheckAccepted(t *testing.T) {
+	om := newOM(t)
+	ctx := context.Background()
+
+	t1, err := om.Frontend().CreateTicket(ctx, &pb.CreateTicketRequest{Ticket: &pb.Ticket{}})
+	require.Nil(t, err)
+	t2, err := om.Frontend().CreateTicket(ctx, &pb.CreateTicketRequest{Ticket: &pb.Ticket{}})
+	require.Nil(t, err)
+
+	stream := fetchReadyCheckMatch(t, om, t1, t2, &pb.ReadyCheck{Timeout: ptypes.DurationProto(pendingReleaseTimeout / 2)})
+
+	// Not assignable until everyone accepted.
+	_, err = om.Backend().AssignMatch(ctx, &pb.AssignMatchRequest{MatchId: "ready-check", Assignment: &pb.Assignment{Connection: "a"}})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	for _, tid := range []string{t1.Id, t2.Id} {
+		resp, err := om.Frontend().AcceptMatch(ctx, &pb.AcceptMatchRequest{TicketId: tid})
+		require.Nil(t, err)
+		require.Equal(t, "ready-check", resp.MatchId)
+	}
+
+	result := recvReadyCheckResult(t, stream)
+	require.Equal(t, pb.ReadyCheckResult_READY, result.Outcome)
+	require.Empty(t, result.DeclinedTicketIds)
+
+	_, err = om.Backend().AssignMatch(ctx, &pb.AssignMatchRequest{MatchId: "ready-check", Assignment: &pb.Assignment{Connection: "a"}})
+	require.Nil(t, err)
+
+	// The ready check is over.
+	_, err = om.Frontend().DeclineMatch(ctx, &pb.DeclineMatchRequest{TicketId: t1.Id})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func TestReadyCheckDeclined(t *testing.T) {
+	om := newOM(t)
+	ctx := context.Background()
+
+	t1, err := om.Frontend().CreateTicket(ctx, &pb.CreateTicketRequest{Ticket: &pb.Ticket{}})
+	require.Nil(t, err)
+	t2, err := om.Frontend().CreateTicket(ctx, &pb.CreateTicketRequest{Ticket: &pb.Ticket{}})
+	require.Nil(t, err)
+
+	_, err = om.Frontend().AcceptMatch(ctx, &pb.AcceptMatchRequest{TicketId: t1.Id})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	stream := fetchReadyCheckMatch(t, om, t1, t2, &pb.ReadyCheck{
+		Timeout:               ptypes.DurationProto(pendingReleaseTimeout / 2),
+		DeleteDeclinedTickets: true,
+	})
+
+	_, err = om.Frontend().AcceptMatch(ctx, &pb.AcceptMatchRequest{TicketId: t2.Id})
+	require.Nil(t, err)
+	_, err = om.Frontend().DeclineMatch(ctx, &pb.DeclineMatchRequest{TicketId: t1.Id})
+	require.Nil(t, err)
+
+	result := recvReadyCheckResult(t, stream)
+	require.Equal(t, pb.ReadyCheckResult_DECLINED, result.Outcome)
+	require.Equal(t, []string{t1.Id}, result.DeclinedTicketIds)
+
+	_, err = om.Frontend().GetTicket(ctx, &pb.GetTicketRequest{TicketId: t1.Id})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	{ // The other ticket is back in the pool.
+		stream, err := om.Query().QueryTickets(ctx, &pb.QueryTicketsRequest{Pool: &pb.Pool{}})
+		require.Nil(t, err)
+
+		resp, err := stream.Recv()
+		require.Nil(t, err)
+		require.Len(t, resp.Tickets, 1)
+		require.Equal(t, t2.Id, resp.Tickets[0].Id)
+
+		_, err = stream.Recv()
+		require.Equal(t, io.EOF, err)
+	}
+}
+
+func TestReadyCheckTimedOut(t *testing.T) {
+	om := newOM(t)
+	ctx := context.Background()
+
+	t1, err := om.Frontend().CreateTicket(ctx, &pb.CreateTicketRequest{Ticket: &pb.Ticket{}})
+	require.Nil(t, err)
+	t2, err := om.Frontend().CreateTicket(ctx, &pb.CreateTicketRequest{Ticket: &pb.Ticket{}})
+	require.Nil(t, err)
+
+	stream := fetchReadyCheckMatch(t, om, t1, t2, &pb.ReadyCheck{Timeout: ptypes.DurationProto(pendingReleaseTimeout / 4)})
+
+	_, err = om.Frontend().AcceptMatch(ctx, &pb.AcceptMatchRequest{TicketId: t1.Id})
+	require.Nil(t, err)
+
+	result := recvReadyCheckResult(t, stream)
+	require.Equal(t, pb.ReadyCheckResult_TIMED_OUT, result.Outcome)
+	require.Equal(t, []string{t2.Id}, result.DeclinedTicketIds)
+
+	_, err = om.Frontend().AcceptMatch(ctx, &pb.AcceptMatchRequest{TicketId: t2.Id})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	// Without deleting declined tickets, the ticket is kept.
+	_, err = om.Frontend().GetTicket(ctx, &pb.GetTicketRequest{TicketId: t2.Id})
+	require.Nil(t, err)
+}
+
+func TestReadyCheckInvalidTimeout(t *testing.T) {
+	om := newOM(t)
+
+	stream, err := om.Backend().FetchMatches(context.Background(), &pb.FetchMatchesRequest{
+		Config:     om.MMFConfigGRPC(),
+		Profile:    &pb.MatchProfile{Name: "test-profile"},
+		ReadyCheck: &pb.ReadyCheck{Timeout: ptypes.DurationProto(-time.Second)},
+	})
+	require.Nil(t, err)
+	_, err = stream.Recv()
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
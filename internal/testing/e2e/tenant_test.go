@@ -0,0 +1,71 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package e2e
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"open-match.dev/open-match/internal/tenant"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+func TestTenantIsolation(t *testing.T) {
+	om := newOM(t)
+
+	ctxA := metadata.AppendToOutgoingContext(context.Background(), tenant.MetadataKey, "tenant-a")
+	ctxB := metadata.AppendToOutgoingContext(context.Background(), tenant.MetadataKey, "tenant-b")
+
+	ticket, err := om.Frontend().CreateTicket(ctxA, &pb.CreateTicketRequest{Ticket: &pb.Ticket{}})
+	require.Nil(t, err)
+
+	_, err = om.Frontend().GetTicket(ctxB, &pb.GetTicketRequest{TicketId: ticket.Id})
+	require.Equal(t, codes.NotFound, status.Code(err))
+	_, err = om.Frontend().GetTicket(context.Background(), &pb.GetTicketRequest{TicketId: ticket.Id})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	for ctx, want := range map[context.Context]int{
+		ctxA:                 1,
+		ctxB:                 0,
+		context.Background(): 0,
+	} {
+		stream, err := om.Query().QueryTicketIds(ctx, &pb.QueryTicketIdsRequest{Pool: &pb.Pool{}})
+		require.Nil(t, err)
+
+		var ids []string
+		for {
+			resp, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			require.Nil(t, err)
+			ids = append(ids, resp.Ids...)
+		}
+		require.Len(t, ids, want)
+	}
+}
+
+func TestInvalidTenant(t *testing.T) {
+	om := newOM(t)
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), tenant.MetadataKey, "Not_Valid")
+	_, err := om.Frontend().CreateTicket(ctx, &pb.CreateTicketRequest{Ticket: &pb.Ticket{}})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -17,6 +17,7 @@ package e2e
 import (
 	"context"
 	"io"
+	"math"
 	"testing"
 	"time"
 
@@ -515,6 +516,15 @@ func TestCreateTicketErrors(t *testing.T) {
 			},
 			"tickets cannot be created with create time set",
 		},
+		{
+			"priority not a number",
+			&pb.CreateTicketRequest{
+				Ticket: &pb.Ticket{
+					Priority: math.NaN(),
+				},
+			},
+			".ticket.priority must be a finite number",
+		},
 	} {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
@@ -598,3 +608,140 @@ func TestAssignedTicketDeleteTimeout(t *testing.T) {
 	require.Equal(t, codes.NotFound, status.Convert(err).Code())
 
 }
+
+// TestAssignAndReleaseMatch covers handling all of a match's tickets by the
+// match id.
+func TestAssignAndReleaseMatch(t *testing.T) {
+	om := newOM(t)
+	ctx := context.Background()
+
+	t1, err := om.Frontend().CreateTicket(ctx, &pb.CreateTicketRequest{Ticket: &pb.Ticket{}})
+	require.Nil(t, err)
+	t2, err := om.Frontend().CreateTicket(ctx, &pb.CreateTicketRequest{Ticket: &pb.Ticket{}})
+	require.Nil(t, err)
+
+	om.SetMMF(func(ctx context.Context, profile *pb.MatchProfile, out chan<- *pb.Match) error {
+		out <- &pb.Match{MatchId: "assigned", Tickets: []*pb.Ticket{t1}}
+		out <- &pb.Match{MatchId: "released", Tickets: []*pb.Ticket{t2}}
+		return nil
+	})
+	om.SetEvaluator(func(ctx context.Context, in <-chan *pb.Match, out chan<- string) error {
+		for m := range in {
+			out <- m.MatchId
+		}
+		return nil
+	})
+
+	stream, err := om.Backend().FetchMatches(ctx, &pb.FetchMatchesRequest{
+		Config:  om.MMFConfigGRPC(),
+		Profile: &pb.MatchProfile{Name: "test-profile"},
+	})
+	require.Nil(t, err)
+	for i := 0; i < 2; i++ {
+		_, err = stream.Recv()
+		require.Nil(t, err)
+	}
+	_, err = stream.Recv()
+	require.Equal(t, io.EOF, err)
+
+	_, err = om.Backend().AssignMatch(ctx, &pb.AssignMatchRequest{MatchId: "unknown", Assignment: &pb.Assignment{Connection: "a"}})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	resp, err := om.Backend().AssignMatch(ctx, &pb.AssignMatchRequest{MatchId: "assigned", Assignment: &pb.Assignment{Connection: "a"}})
+	require.Nil(t, err)
+	require.Empty(t, resp.Failures)
+
+	got, err := om.Frontend().GetTicket(ctx, &pb.GetTicketRequest{TicketId: t1.Id})
+	require.Nil(t, err)
+	require.Equal(t, "a", got.GetAssignment().GetConnection())
+
+	// Already handled.
+	_, err = om.Backend().AssignMatch(ctx, &pb.AssignMatchRequest{MatchId: "assigned", Assignment: &pb.Assignment{Connection: "b"}})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+	_, err = om.Backend().ReleaseMatch(ctx, &pb.ReleaseMatchRequest{MatchId: "assigned"})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	_, err = om.Backend().ReleaseMatch(ctx, &pb.ReleaseMatchRequest{MatchId: "released"})
+	require.Nil(t, err)
+
+	{ // Only the released ticket is present in query
+		stream, err := om.Query().QueryTickets(ctx, &pb.QueryTicketsRequest{Pool: &pb.Pool{}})
+		require.Nil(t, err)
+
+		resp, err := stream.Recv()
+		require.Nil(t, err)
+		require.Len(t, resp.Tickets, 1)
+		require.Equal(t, t2.Id, resp.Tickets[0].Id)
+
+		resp, err = stream.Recv()
+		require.Equal(t, io.EOF, err)
+		require.Nil(t, resp)
+	}
+
+	list, err := om.Backend().ListMatches(ctx, &pb.ListMatchesRequest{})
+	require.Nil(t, err)
+	require.Empty(t, list.Matches)
+}
+
+// TestAssignAndReleaseMatchTickets covers handling a match's tickets by their
+// ids, which stops listing the match as unassigned.
+func TestAssignAndReleaseMatchTickets(t *testing.T) {
+	om := newOM(t)
+	ctx := context.Background()
+
+	t1, err := om.Frontend().CreateTicket(ctx, &pb.CreateTicketRequest{Ticket: &pb.Ticket{}})
+	require.Nil(t, err)
+	t2, err := om.Frontend().CreateTicket(ctx, &pb.CreateTicketRequest{Ticket: &pb.Ticket{}})
+	require.Nil(t, err)
+
+	om.SetMMF(func(ctx context.Context, profile *pb.MatchProfile, out chan<- *pb.Match) error {
+		out <- &pb.Match{MatchId: "assigned", Tickets: []*pb.Ticket{t1}}
+		out <- &pb.Match{MatchId: "released", Tickets: []*pb.Ticket{t2}}
+		return nil
+	})
+	om.SetEvaluator(func(ctx context.Context, in <-chan *pb.Match, out chan<- string) error {
+		for m := range in {
+			out <- m.MatchId
+		}
+		return nil
+	})
+
+	stream, err := om.Backend().FetchMatches(ctx, &pb.FetchMatchesRequest{
+		Config:  om.MMFConfigGRPC(),
+		Profile: &pb.MatchProfile{Name: "test-profile"},
+	})
+	require.Nil(t, err)
+	for i := 0; i < 2; i++ {
+		_, err = stream.Recv()
+		require.Nil(t, err)
+	}
+	_, err = stream.Recv()
+	require.Equal(t, io.EOF, err)
+
+	list, err := om.Backend().ListMatches(ctx, &pb.ListMatchesRequest{})
+	require.Nil(t, err)
+	require.Len(t, list.Matches, 2)
+
+	_, err = om.Backend().AssignTickets(ctx, &pb.AssignTicketsRequest{
+		Assignments: []*pb.AssignmentGroup{{TicketIds: []string{t1.Id}, Assignment: &pb.Assignment{Connection: "a"}}},
+	})
+	require.Nil(t, err)
+
+	list, err = om.Backend().ListMatches(ctx, &pb.ListMatchesRequest{})
+	require.Nil(t, err)
+	require.Len(t, list.Matches, 1)
+	require.Equal(t, "released", list.Matches[0].GetMatch().GetMatchId())
+
+	_, err = om.Backend().ReleaseTickets(ctx, &pb.ReleaseTicketsRequest{TicketIds: []string{t2.Id}})
+	require.Nil(t, err)
+
+	list, err = om.Backend().ListMatches(ctx, &pb.ListMatchesRequest{})
+	require.Nil(t, err)
+	require.Empty(t, list.Matches)
+
+	// Already handled.
+	_, err = om.Backend().AssignMatch(ctx, &pb.AssignMatchRequest{MatchId: "assigned", Assignment: &pb.Assignment{Connection: "b"}})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+	_, err = om.Backend().ReleaseMatch(ctx, &pb.ReleaseMatchRequest{MatchId: "released"})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
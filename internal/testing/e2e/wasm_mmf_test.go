@@ -0,0 +1,265 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"open-match.dev/open-match/pkg/pb"
+)
+
+// TestWasmMMF covers running an uploaded WebAssembly module as the MMF.
+func TestWasmMMF(t *testing.T) {
+	ctx := context.Background()
+	om := newOM(t)
+
+	t1, err := om.Frontend().CreateTicket(ctx, &pb.CreateTicketRequest{Ticket: &pb.Ticket{}})
+	require.Nil(t, err)
+	t2, err := om.Frontend().CreateTicket(ctx, &pb.CreateTicketRequest{Ticket: &pb.Ticket{}})
+	require.Nil(t, err)
+
+	m := &pb.Match{
+		MatchId: "1",
+		Tickets: []*pb.Ticket{t1, t2},
+	}
+	mBytes, err := proto.Marshal(m)
+	require.Nil(t, err)
+
+	_, err = om.Backend().UploadMatchFunctionModule(ctx, &pb.UploadMatchFunctionModuleRequest{
+		Name:   "emitter",
+		Module: emitProposalWasmModule(mBytes),
+	})
+	require.Nil(t, err)
+
+	om.SetEvaluator(func(ctx context.Context, in <-chan *pb.Match, out chan<- string) error {
+		p, ok := <-in
+		require.True(t, ok)
+		require.True(t, proto.Equal(p, m))
+		_, ok = <-in
+		require.False(t, ok)
+
+		out <- m.MatchId
+		return nil
+	})
+
+	stream, err := om.Backend().FetchMatches(ctx, &pb.FetchMatchesRequest{
+		Config: &pb.FunctionConfig{
+			Type:       pb.FunctionConfig_WASM,
+			WasmModule: "emitter",
+		},
+		Profile: &pb.MatchProfile{},
+	})
+	require.Nil(t, err)
+
+	resp, err := stream.Recv()
+	require.Nil(t, err)
+	require.True(t, proto.Equal(m, resp.Match))
+
+	resp, err = stream.Recv()
+	require.Equal(t, err, io.EOF)
+	require.Nil(t, resp)
+}
+
+// TestWasmMMFInvalidModule covers rejecting modules which can't be run.
+func TestWasmMMFInvalidModule(t *testing.T) {
+	ctx := context.Background()
+	om := newOM(t)
+
+	_, err := om.Backend().UploadMatchFunctionModule(ctx, &pb.UploadMatchFunctionModuleRequest{
+		Name:   "garbage",
+		Module: []byte("not a wasm module"),
+	})
+	require.Equal(t, codes.InvalidArgument, status.Convert(err).Code())
+
+	_, err = om.Backend().UploadMatchFunctionModule(ctx, &pb.UploadMatchFunctionModuleRequest{
+		Name:   "empty",
+		Module: []byte{},
+	})
+	require.Equal(t, codes.InvalidArgument, status.Convert(err).Code())
+
+	stream, err := om.Backend().FetchMatches(ctx, &pb.FetchMatchesRequest{
+		Config: &pb.FunctionConfig{
+			Type: pb.FunctionConfig_WASM,
+		},
+		Profile: &pb.MatchProfile{},
+	})
+	require.Nil(t, err)
+	resp, err := stream.Recv()
+	require.Equal(t, codes.InvalidArgument, status.Convert(err).Code())
+	require.Nil(t, resp)
+}
+
+// TestWasmMMFReplacedModules covers running modules after more of them were
+// uploaded than the backend keeps compiled.
+func TestWasmMMFReplacedModules(t *testing.T) {
+	ctx := context.Background()
+	om := newOM(t)
+
+	om.SetEvaluator(func(ctx context.Context, in <-chan *pb.Match, out chan<- string) error {
+		for m := range in {
+			out <- m.MatchId
+		}
+		return nil
+	})
+
+	run := func(m *pb.Match) {
+		stream, err := om.Backend().FetchMatches(ctx, &pb.FetchMatchesRequest{
+			Config: &pb.FunctionConfig{
+				Type:       pb.FunctionConfig_WASM,
+				WasmModule: "emitter",
+			},
+			Profile: &pb.MatchProfile{},
+		})
+		require.Nil(t, err)
+
+		resp, err := stream.Recv()
+		require.Nil(t, err)
+		require.True(t, proto.Equal(m, resp.Match))
+
+		_, err = stream.Recv()
+		require.Equal(t, io.EOF, err)
+	}
+
+	var first []byte
+	var firstMatch *pb.Match
+	for i := 0; i < 20; i++ {
+		m := &pb.Match{MatchId: fmt.Sprintf("%d", i)}
+		mBytes, err := proto.Marshal(m)
+		require.Nil(t, err)
+		module := emitProposalWasmModule(mBytes)
+		if i == 0 {
+			first, firstMatch = module, m
+		}
+
+		_, err = om.Backend().UploadMatchFunctionModule(ctx, &pb.UploadMatchFunctionModuleRequest{
+			Name:   "emitter",
+			Module: module,
+		})
+		require.Nil(t, err)
+		run(m)
+	}
+
+	// The first module was evicted, and is compiled again.
+	_, err := om.Backend().UploadMatchFunctionModule(ctx, &pb.UploadMatchFunctionModuleRequest{
+		Name:   "emitter",
+		Module: first,
+	})
+	require.Nil(t, err)
+	run(firstMatch)
+}
+
+// emitProposalWasmModule builds a WebAssembly module whose run function emits
+// the given serialized match as its only proposal.
+func emitProposalWasmModule(match []byte) []byte {
+	const (
+		i32       = 0x7f
+		funcType  = 0x60
+		i32Const  = 0x41
+		callOp    = 0x10
+		endOp     = 0x0b
+		funcKind  = 0x00
+		memKind   = 0x02
+		typeSec   = 1
+		importSec = 2
+		funcSec   = 3
+		memSec    = 5
+		exportSec = 7
+		codeSec   = 10
+		dataSec   = 11
+	)
+
+	uleb := func(v uint32) []byte {
+		var b []byte
+		for {
+			c := byte(v & 0x7f)
+			v >>= 7
+			if v != 0 {
+				c |= 0x80
+			}
+			b = append(b, c)
+			if v == 0 {
+				return b
+			}
+		}
+	}
+	sleb := func(v int32) []byte {
+		var b []byte
+		for {
+			c := byte(v & 0x7f)
+			v >>= 7
+			if (v == 0 && c&0x40 == 0) || (v == -1 && c&0x40 != 0) {
+				return append(b, c)
+			}
+			b = append(b, c|0x80)
+		}
+	}
+	vec := func(items ...[]byte) []byte {
+		b := uleb(uint32(len(items)))
+		for _, item := range items {
+			b = append(b, item...)
+		}
+		return b
+	}
+	name := func(s string) []byte {
+		return append(uleb(uint32(len(s))), s...)
+	}
+	section := func(id byte, content []byte) []byte {
+		return append(append([]byte{id}, uleb(uint32(len(content)))...), content...)
+	}
+	concat := func(parts ...[]byte) []byte {
+		var b []byte
+		for _, p := range parts {
+			b = append(b, p...)
+		}
+		return b
+	}
+
+	body := concat(
+		[]byte{0x00}, // No locals.
+		[]byte{i32Const}, sleb(0),
+		[]byte{i32Const}, sleb(int32(len(match))),
+		[]byte{callOp}, uleb(0),
+		[]byte{endOp},
+	)
+
+	return concat(
+		[]byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00},
+		section(typeSec, vec(
+			[]byte{funcType, 2, i32, i32, 1, i32}, // emit_proposal
+			[]byte{funcType, 0, 1, i32},           // run
+		)),
+		section(importSec, vec(
+			concat(name("openmatch"), name("emit_proposal"), []byte{funcKind}, uleb(0)),
+		)),
+		section(funcSec, vec(uleb(1))),
+		section(memSec, vec([]byte{0x00, 0x01})),
+		section(exportSec, vec(
+			concat(name("run"), []byte{funcKind}, uleb(1)),
+			concat(name("memory"), []byte{memKind}, uleb(0)),
+		)),
+		section(codeSec, vec(concat(uleb(uint32(len(body))), body))),
+		section(dataSec, vec(concat(
+			[]byte{0x00, i32Const}, sleb(0), []byte{endOp},
+			uleb(uint32(len(match))), match,
+		))),
+	)
+}
@@ -54,3 +54,13 @@ func (s *FakeFrontend) GetTicket(ctx context.Context, req *pb.GetTicketRequest)
 func (s *FakeFrontend) WatchAssignments(req *pb.WatchAssignmentsRequest, stream pb.FrontendService_WatchAssignmentsServer) error {
 	return status.Error(codes.Unimplemented, "not implemented")
 }
+
+// AcceptMatch accepts the ready check of the match the Ticket is in.
+func (s *FakeFrontend) AcceptMatch(ctx context.Context, req *pb.AcceptMatchRequest) (*pb.AcceptMatchResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "not implemented")
+}
+
+// DeclineMatch declines the ready check of the match the Ticket is in.
+func (s *FakeFrontend) DeclineMatch(ctx context.Context, req *pb.DeclineMatchRequest) (*pb.DeclineMatchResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "not implemented")
+}
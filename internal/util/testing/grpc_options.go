@@ -20,7 +20,6 @@ import (
 	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	grpc_logrus "github.com/grpc-ecosystem/go-grpc-middleware/logging/logrus"
 	grpc_recovery "github.com/grpc-ecosystem/go-grpc-middleware/recovery"
-	grpc_tracing "github.com/grpc-ecosystem/go-grpc-middleware/tracing/opentracing"
 	grpc_validator "github.com/grpc-ecosystem/go-grpc-middleware/validator"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
@@ -43,11 +42,9 @@ func NewGRPCDialOptions(grpcLogger *logrus.Entry) []grpc.DialOption {
 
 	si := []grpc.StreamClientInterceptor{
 		grpc_logrus.StreamClientInterceptor(grpcLogger),
-		grpc_tracing.StreamClientInterceptor(),
 	}
 	ui := []grpc.UnaryClientInterceptor{
 		grpc_logrus.UnaryClientInterceptor(grpcLogger),
-		grpc_tracing.UnaryClientInterceptor(),
 	}
 	opts := []grpc.DialOption{
 		grpc.WithInsecure(),
@@ -69,13 +66,11 @@ func NewGRPCServerOptions(grpcLogger *logrus.Entry) []grpc.ServerOption {
 	si := []grpc.StreamServerInterceptor{
 		grpc_recovery.StreamServerInterceptor(),
 		grpc_validator.StreamServerInterceptor(),
-		grpc_tracing.StreamServerInterceptor(),
 		grpc_logrus.StreamServerInterceptor(grpcLogger),
 	}
 	ui := []grpc.UnaryServerInterceptor{
 		grpc_recovery.UnaryServerInterceptor(),
 		grpc_validator.UnaryServerInterceptor(),
-		grpc_tracing.UnaryServerInterceptor(),
 		grpc_logrus.UnaryServerInterceptor(grpcLogger),
 	}
 
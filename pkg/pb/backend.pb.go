@@ -7,6 +7,8 @@ import (
 	context "context"
 	fmt "fmt"
 	proto "github.com/golang/protobuf/proto"
+	duration "github.com/golang/protobuf/ptypes/duration"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
 	_ "github.com/grpc-ecosystem/grpc-gateway/protoc-gen-swagger/options"
 	_ "google.golang.org/genproto/googleapis/api/annotations"
 	grpc "google.golang.org/grpc"
@@ -31,16 +33,22 @@ type FunctionConfig_Type int32
 const (
 	FunctionConfig_GRPC FunctionConfig_Type = 0
 	FunctionConfig_REST FunctionConfig_Type = 1
+	// WASM runs a WebAssembly module previously uploaded with
+	// UploadMatchFunctionModule inside the backend, instead of calling out to
+	// a MatchFunction server.  host and port are ignored.
+	FunctionConfig_WASM FunctionConfig_Type = 2
 )
 
 var FunctionConfig_Type_name = map[int32]string{
 	0: "GRPC",
 	1: "REST",
+	2: "WASM",
 }
 
 var FunctionConfig_Type_value = map[string]int32{
 	"GRPC": 0,
 	"REST": 1,
+	"WASM": 2,
 }
 
 func (x FunctionConfig_Type) String() string {
@@ -51,6 +59,80 @@ func (FunctionConfig_Type) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_8dab762378f455cd, []int{0, 0}
 }
 
+type ReadyCheckResult_Outcome int32
+
+const (
+	ReadyCheckResult_UNKNOWN ReadyCheckResult_Outcome = 0
+	// Every player accepted.  The match can be assigned.
+	ReadyCheckResult_READY ReadyCheckResult_Outcome = 1
+	// A player declined.
+	ReadyCheckResult_DECLINED ReadyCheckResult_Outcome = 2
+	// Not every player accepted before the timeout.
+	ReadyCheckResult_TIMED_OUT ReadyCheckResult_Outcome = 3
+)
+
+var ReadyCheckResult_Outcome_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "READY",
+	2: "DECLINED",
+	3: "TIMED_OUT",
+}
+
+var ReadyCheckResult_Outcome_value = map[string]int32{
+	"UNKNOWN":   0,
+	"READY":     1,
+	"DECLINED":  2,
+	"TIMED_OUT": 3,
+}
+
+func (x ReadyCheckResult_Outcome) String() string {
+	return proto.EnumName(ReadyCheckResult_Outcome_name, int32(x))
+}
+
+func (ReadyCheckResult_Outcome) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_8dab762378f455cd, []int{3, 0}
+}
+
+type MatchRecord_State int32
+
+const (
+	// The match has been returned by FetchMatches, and not yet handled.
+	MatchRecord_UNASSIGNED MatchRecord_State = 0
+	// The match's tickets have been assigned.
+	MatchRecord_ASSIGNED MatchRecord_State = 1
+	// The match's tickets have been released back to the pool.
+	MatchRecord_RELEASED MatchRecord_State = 2
+	// The match's players have not yet all accepted its ready check.  It
+	// becomes UNASSIGNED once they have.
+	MatchRecord_AWAITING_READY MatchRecord_State = 3
+	// A player declined the ready check, or it timed out.
+	MatchRecord_DECLINED MatchRecord_State = 4
+)
+
+var MatchRecord_State_name = map[int32]string{
+	0: "UNASSIGNED",
+	1: "ASSIGNED",
+	2: "RELEASED",
+	3: "AWAITING_READY",
+	4: "DECLINED",
+}
+
+var MatchRecord_State_value = map[string]int32{
+	"UNASSIGNED":     0,
+	"ASSIGNED":       1,
+	"RELEASED":       2,
+	"AWAITING_READY": 3,
+	"DECLINED":       4,
+}
+
+func (x MatchRecord_State) String() string {
+	return proto.EnumName(MatchRecord_State_name, int32(x))
+}
+
+func (MatchRecord_State) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_8dab762378f455cd, []int{6, 0}
+}
+
 type AssignmentFailure_Cause int32
 
 const (
@@ -73,17 +155,20 @@ func (x AssignmentFailure_Cause) String() string {
 }
 
 func (AssignmentFailure_Cause) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_8dab762378f455cd, []int{8, 0}
+	return fileDescriptor_8dab762378f455cd, []int{21, 0}
 }
 
 // FunctionConfig specifies a MMF address and client type for Backend to establish connections with the MMF
 type FunctionConfig struct {
-	Host                 string              `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`
-	Port                 int32               `protobuf:"varint,2,opt,name=port,proto3" json:"port,omitempty"`
-	Type                 FunctionConfig_Type `protobuf:"varint,3,opt,name=type,proto3,enum=openmatch.FunctionConfig_Type" json:"type,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
-	XXX_unrecognized     []byte              `json:"-"`
-	XXX_sizecache        int32               `json:"-"`
+	Host string              `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`
+	Port int32               `protobuf:"varint,2,opt,name=port,proto3" json:"port,omitempty"`
+	Type FunctionConfig_Type `protobuf:"varint,3,opt,name=type,proto3,enum=openmatch.FunctionConfig_Type" json:"type,omitempty"`
+	// Name of the uploaded WebAssembly module to run.  Required when type is
+	// WASM.
+	WasmModule           string   `protobuf:"bytes,4,opt,name=wasm_module,json=wasmModule,proto3" json:"wasm_module,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *FunctionConfig) Reset()         { *m = FunctionConfig{} }
@@ -132,14 +217,28 @@ func (m *FunctionConfig) GetType() FunctionConfig_Type {
 	return FunctionConfig_GRPC
 }
 
+func (m *FunctionConfig) GetWasmModule() string {
+	if m != nil {
+		return m.WasmModule
+	}
+	return ""
+}
+
 type FetchMatchesRequest struct {
 	// A configuration for the MatchFunction server of this FetchMatches call.
 	Config *FunctionConfig `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`
 	// A MatchProfile that will be sent to the MatchFunction server of this FetchMatches call.
-	Profile              *MatchProfile `protobuf:"bytes,2,opt,name=profile,proto3" json:"profile,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
-	XXX_unrecognized     []byte        `json:"-"`
-	XXX_sizecache        int32         `json:"-"`
+	Profile *MatchProfile `protobuf:"bytes,2,opt,name=profile,proto3" json:"profile,omitempty"`
+	// If true, proposals from this call's MatchFunction which were not accepted
+	// by the evaluator are also streamed back as rejected_proposal responses.
+	IncludeRejectedProposals bool `protobuf:"varint,3,opt,name=include_rejected_proposals,json=includeRejectedProposals,proto3" json:"include_rejected_proposals,omitempty"`
+	// If set, players must accept matches before they can be assigned.  The
+	// call streams back each match, then a ready_check_result once every player
+	// of the match accepted, one declined, or the ready check timed out.
+	ReadyCheck           *ReadyCheck `protobuf:"bytes,4,opt,name=ready_check,json=readyCheck,proto3" json:"ready_check,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
 }
 
 func (m *FetchMatchesRequest) Reset()         { *m = FetchMatchesRequest{} }
@@ -181,20 +280,152 @@ func (m *FetchMatchesRequest) GetProfile() *MatchProfile {
 	return nil
 }
 
-type FetchMatchesResponse struct {
-	// A Match generated by the user-defined MMF with the specified MatchProfiles.
-	// A valid Match response will contain at least one ticket.
-	Match                *Match   `protobuf:"bytes,1,opt,name=match,proto3" json:"match,omitempty"`
+func (m *FetchMatchesRequest) GetIncludeRejectedProposals() bool {
+	if m != nil {
+		return m.IncludeRejectedProposals
+	}
+	return false
+}
+
+func (m *FetchMatchesRequest) GetReadyCheck() *ReadyCheck {
+	if m != nil {
+		return m.ReadyCheck
+	}
+	return nil
+}
+
+// ReadyCheck configures players accepting their match, through the frontend's
+// AcceptMatch and DeclineMatch, before it is assigned.
+type ReadyCheck struct {
+	// How long players have to accept the match.  Required.  Should be shorter
+	// than pendingReleaseTimeout, after which the match's tickets return to the
+	// pool, and matchRecordTimeout.
+	Timeout *duration.Duration `protobuf:"bytes,1,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	// If true, the tickets of players who declined or didn't respond are
+	// deleted.  Otherwise they remain pending until pendingReleaseTimeout.
+	// The tickets of other players are always released back to the pool.
+	DeleteDeclinedTickets bool     `protobuf:"varint,2,opt,name=delete_declined_tickets,json=deleteDeclinedTickets,proto3" json:"delete_declined_tickets,omitempty"`
+	XXX_NoUnkeyedLiteral  struct{} `json:"-"`
+	XXX_unrecognized      []byte   `json:"-"`
+	XXX_sizecache         int32    `json:"-"`
+}
+
+func (m *ReadyCheck) Reset()         { *m = ReadyCheck{} }
+func (m *ReadyCheck) String() string { return proto.CompactTextString(m) }
+func (*ReadyCheck) ProtoMessage()    {}
+func (*ReadyCheck) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8dab762378f455cd, []int{2}
+}
+
+func (m *ReadyCheck) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReadyCheck.Unmarshal(m, b)
+}
+func (m *ReadyCheck) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReadyCheck.Marshal(b, m, deterministic)
+}
+func (m *ReadyCheck) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReadyCheck.Merge(m, src)
+}
+func (m *ReadyCheck) XXX_Size() int {
+	return xxx_messageInfo_ReadyCheck.Size(m)
+}
+func (m *ReadyCheck) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReadyCheck.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReadyCheck proto.InternalMessageInfo
+
+func (m *ReadyCheck) GetTimeout() *duration.Duration {
+	if m != nil {
+		return m.Timeout
+	}
+	return nil
+}
+
+func (m *ReadyCheck) GetDeleteDeclinedTickets() bool {
+	if m != nil {
+		return m.DeleteDeclinedTickets
+	}
+	return false
+}
+
+// ReadyCheckResult is the outcome of the ready check of a match.
+type ReadyCheckResult struct {
+	MatchId string                   `protobuf:"bytes,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	Outcome ReadyCheckResult_Outcome `protobuf:"varint,2,opt,name=outcome,proto3,enum=openmatch.ReadyCheckResult_Outcome" json:"outcome,omitempty"`
+	// Tickets of players who declined or didn't respond.
+	DeclinedTicketIds    []string `protobuf:"bytes,3,rep,name=declined_ticket_ids,json=declinedTicketIds,proto3" json:"declined_ticket_ids,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
+func (m *ReadyCheckResult) Reset()         { *m = ReadyCheckResult{} }
+func (m *ReadyCheckResult) String() string { return proto.CompactTextString(m) }
+func (*ReadyCheckResult) ProtoMessage()    {}
+func (*ReadyCheckResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8dab762378f455cd, []int{3}
+}
+
+func (m *ReadyCheckResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReadyCheckResult.Unmarshal(m, b)
+}
+func (m *ReadyCheckResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReadyCheckResult.Marshal(b, m, deterministic)
+}
+func (m *ReadyCheckResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReadyCheckResult.Merge(m, src)
+}
+func (m *ReadyCheckResult) XXX_Size() int {
+	return xxx_messageInfo_ReadyCheckResult.Size(m)
+}
+func (m *ReadyCheckResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReadyCheckResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReadyCheckResult proto.InternalMessageInfo
+
+func (m *ReadyCheckResult) GetMatchId() string {
+	if m != nil {
+		return m.MatchId
+	}
+	return ""
+}
+
+func (m *ReadyCheckResult) GetOutcome() ReadyCheckResult_Outcome {
+	if m != nil {
+		return m.Outcome
+	}
+	return ReadyCheckResult_UNKNOWN
+}
+
+func (m *ReadyCheckResult) GetDeclinedTicketIds() []string {
+	if m != nil {
+		return m.DeclinedTicketIds
+	}
+	return nil
+}
+
+type FetchMatchesResponse struct {
+	// A Match generated by the user-defined MMF with the specified MatchProfiles.
+	// A valid Match response will contain at least one ticket.
+	Match *Match `protobuf:"bytes,1,opt,name=match,proto3" json:"match,omitempty"`
+	// A proposal which was not accepted, set instead of match.  Only returned
+	// when include_rejected_proposals is set on the request.
+	RejectedProposal *RejectedProposal `protobuf:"bytes,2,opt,name=rejected_proposal,json=rejectedProposal,proto3" json:"rejected_proposal,omitempty"`
+	// The outcome of the ready check of a match previously sent on the stream.
+	// Only returned when ready_check is set on the request.
+	ReadyCheckResult     *ReadyCheckResult `protobuf:"bytes,3,opt,name=ready_check_result,json=readyCheckResult,proto3" json:"ready_check_result,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
 func (m *FetchMatchesResponse) Reset()         { *m = FetchMatchesResponse{} }
 func (m *FetchMatchesResponse) String() string { return proto.CompactTextString(m) }
 func (*FetchMatchesResponse) ProtoMessage()    {}
 func (*FetchMatchesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8dab762378f455cd, []int{2}
+	return fileDescriptor_8dab762378f455cd, []int{4}
 }
 
 func (m *FetchMatchesResponse) XXX_Unmarshal(b []byte) error {
@@ -222,6 +453,564 @@ func (m *FetchMatchesResponse) GetMatch() *Match {
 	return nil
 }
 
+func (m *FetchMatchesResponse) GetRejectedProposal() *RejectedProposal {
+	if m != nil {
+		return m.RejectedProposal
+	}
+	return nil
+}
+
+func (m *FetchMatchesResponse) GetReadyCheckResult() *ReadyCheckResult {
+	if m != nil {
+		return m.ReadyCheckResult
+	}
+	return nil
+}
+
+// A RejectedProposal is a Match proposed by the MatchFunction which was not
+// accepted by the evaluator.
+type RejectedProposal struct {
+	// The Match as proposed by the MatchFunction.
+	Proposal *Match `protobuf:"bytes,1,opt,name=proposal,proto3" json:"proposal,omitempty"`
+	// Why the proposal was rejected.
+	Rejection            *RejectedMatch `protobuf:"bytes,2,opt,name=rejection,proto3" json:"rejection,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *RejectedProposal) Reset()         { *m = RejectedProposal{} }
+func (m *RejectedProposal) String() string { return proto.CompactTextString(m) }
+func (*RejectedProposal) ProtoMessage()    {}
+func (*RejectedProposal) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8dab762378f455cd, []int{5}
+}
+
+func (m *RejectedProposal) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RejectedProposal.Unmarshal(m, b)
+}
+func (m *RejectedProposal) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RejectedProposal.Marshal(b, m, deterministic)
+}
+func (m *RejectedProposal) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RejectedProposal.Merge(m, src)
+}
+func (m *RejectedProposal) XXX_Size() int {
+	return xxx_messageInfo_RejectedProposal.Size(m)
+}
+func (m *RejectedProposal) XXX_DiscardUnknown() {
+	xxx_messageInfo_RejectedProposal.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RejectedProposal proto.InternalMessageInfo
+
+func (m *RejectedProposal) GetProposal() *Match {
+	if m != nil {
+		return m.Proposal
+	}
+	return nil
+}
+
+func (m *RejectedProposal) GetRejection() *RejectedMatch {
+	if m != nil {
+		return m.Rejection
+	}
+	return nil
+}
+
+// A MatchRecord is a Match returned by FetchMatches.  Open Match keeps it until
+// it expires, so that a director which restarts before handling the match can
+// resume it.
+type MatchRecord struct {
+	// The Match as returned by FetchMatches.
+	Match *Match `protobuf:"bytes,1,opt,name=match,proto3" json:"match,omitempty"`
+	// The MatchProfile of the FetchMatches call which returned the match.
+	Profile *MatchProfile `protobuf:"bytes,2,opt,name=profile,proto3" json:"profile,omitempty"`
+	// The FunctionConfig of the FetchMatches call which returned the match.
+	Config *FunctionConfig   `protobuf:"bytes,3,opt,name=config,proto3" json:"config,omitempty"`
+	State  MatchRecord_State `protobuf:"varint,4,opt,name=state,proto3,enum=openmatch.MatchRecord_State" json:"state,omitempty"`
+	// Time the match was returned by FetchMatches.
+	CreateTime *timestamp.Timestamp `protobuf:"bytes,5,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	// The ReadyCheck of the FetchMatches call which returned the match, if any.
+	ReadyCheck *ReadyCheck `protobuf:"bytes,6,opt,name=ready_check,json=readyCheck,proto3" json:"ready_check,omitempty"`
+	// Time by which every player must accept the ready check.
+	ReadyDeadline *timestamp.Timestamp `protobuf:"bytes,7,opt,name=ready_deadline,json=readyDeadline,proto3" json:"ready_deadline,omitempty"`
+	// Tickets of players who accepted the ready check.
+	AcceptedTicketIds []string `protobuf:"bytes,8,rep,name=accepted_ticket_ids,json=acceptedTicketIds,proto3" json:"accepted_ticket_ids,omitempty"`
+	// Tickets of players who declined the ready check, or didn't respond.
+	DeclinedTicketIds    []string `protobuf:"bytes,9,rep,name=declined_ticket_ids,json=declinedTicketIds,proto3" json:"declined_ticket_ids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MatchRecord) Reset()         { *m = MatchRecord{} }
+func (m *MatchRecord) String() string { return proto.CompactTextString(m) }
+func (*MatchRecord) ProtoMessage()    {}
+func (*MatchRecord) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8dab762378f455cd, []int{6}
+}
+
+func (m *MatchRecord) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MatchRecord.Unmarshal(m, b)
+}
+func (m *MatchRecord) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MatchRecord.Marshal(b, m, deterministic)
+}
+func (m *MatchRecord) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MatchRecord.Merge(m, src)
+}
+func (m *MatchRecord) XXX_Size() int {
+	return xxx_messageInfo_MatchRecord.Size(m)
+}
+func (m *MatchRecord) XXX_DiscardUnknown() {
+	xxx_messageInfo_MatchRecord.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MatchRecord proto.InternalMessageInfo
+
+func (m *MatchRecord) GetMatch() *Match {
+	if m != nil {
+		return m.Match
+	}
+	return nil
+}
+
+func (m *MatchRecord) GetProfile() *MatchProfile {
+	if m != nil {
+		return m.Profile
+	}
+	return nil
+}
+
+func (m *MatchRecord) GetConfig() *FunctionConfig {
+	if m != nil {
+		return m.Config
+	}
+	return nil
+}
+
+func (m *MatchRecord) GetState() MatchRecord_State {
+	if m != nil {
+		return m.State
+	}
+	return MatchRecord_UNASSIGNED
+}
+
+func (m *MatchRecord) GetCreateTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreateTime
+	}
+	return nil
+}
+
+func (m *MatchRecord) GetReadyCheck() *ReadyCheck {
+	if m != nil {
+		return m.ReadyCheck
+	}
+	return nil
+}
+
+func (m *MatchRecord) GetReadyDeadline() *timestamp.Timestamp {
+	if m != nil {
+		return m.ReadyDeadline
+	}
+	return nil
+}
+
+func (m *MatchRecord) GetAcceptedTicketIds() []string {
+	if m != nil {
+		return m.AcceptedTicketIds
+	}
+	return nil
+}
+
+func (m *MatchRecord) GetDeclinedTicketIds() []string {
+	if m != nil {
+		return m.DeclinedTicketIds
+	}
+	return nil
+}
+
+type GetMatchRequest struct {
+	// A match_id of a Match returned by FetchMatches.
+	MatchId              string   `protobuf:"bytes,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetMatchRequest) Reset()         { *m = GetMatchRequest{} }
+func (m *GetMatchRequest) String() string { return proto.CompactTextString(m) }
+func (*GetMatchRequest) ProtoMessage()    {}
+func (*GetMatchRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8dab762378f455cd, []int{7}
+}
+
+func (m *GetMatchRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetMatchRequest.Unmarshal(m, b)
+}
+func (m *GetMatchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetMatchRequest.Marshal(b, m, deterministic)
+}
+func (m *GetMatchRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetMatchRequest.Merge(m, src)
+}
+func (m *GetMatchRequest) XXX_Size() int {
+	return xxx_messageInfo_GetMatchRequest.Size(m)
+}
+func (m *GetMatchRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetMatchRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetMatchRequest proto.InternalMessageInfo
+
+func (m *GetMatchRequest) GetMatchId() string {
+	if m != nil {
+		return m.MatchId
+	}
+	return ""
+}
+
+type ListMatchesRequest struct {
+	// If set, only matches returned for the MatchProfile with this name are
+	// listed.
+	ProfileName          string   `protobuf:"bytes,1,opt,name=profile_name,json=profileName,proto3" json:"profile_name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListMatchesRequest) Reset()         { *m = ListMatchesRequest{} }
+func (m *ListMatchesRequest) String() string { return proto.CompactTextString(m) }
+func (*ListMatchesRequest) ProtoMessage()    {}
+func (*ListMatchesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8dab762378f455cd, []int{8}
+}
+
+func (m *ListMatchesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListMatchesRequest.Unmarshal(m, b)
+}
+func (m *ListMatchesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListMatchesRequest.Marshal(b, m, deterministic)
+}
+func (m *ListMatchesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListMatchesRequest.Merge(m, src)
+}
+func (m *ListMatchesRequest) XXX_Size() int {
+	return xxx_messageInfo_ListMatchesRequest.Size(m)
+}
+func (m *ListMatchesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListMatchesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListMatchesRequest proto.InternalMessageInfo
+
+func (m *ListMatchesRequest) GetProfileName() string {
+	if m != nil {
+		return m.ProfileName
+	}
+	return ""
+}
+
+type ListMatchesResponse struct {
+	// Unassigned matches which have not yet expired.
+	Matches              []*MatchRecord `protobuf:"bytes,1,rep,name=matches,proto3" json:"matches,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *ListMatchesResponse) Reset()         { *m = ListMatchesResponse{} }
+func (m *ListMatchesResponse) String() string { return proto.CompactTextString(m) }
+func (*ListMatchesResponse) ProtoMessage()    {}
+func (*ListMatchesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8dab762378f455cd, []int{9}
+}
+
+func (m *ListMatchesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListMatchesResponse.Unmarshal(m, b)
+}
+func (m *ListMatchesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListMatchesResponse.Marshal(b, m, deterministic)
+}
+func (m *ListMatchesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListMatchesResponse.Merge(m, src)
+}
+func (m *ListMatchesResponse) XXX_Size() int {
+	return xxx_messageInfo_ListMatchesResponse.Size(m)
+}
+func (m *ListMatchesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListMatchesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListMatchesResponse proto.InternalMessageInfo
+
+func (m *ListMatchesResponse) GetMatches() []*MatchRecord {
+	if m != nil {
+		return m.Matches
+	}
+	return nil
+}
+
+type AssignMatchRequest struct {
+	// A match_id of an unassigned Match returned by FetchMatches.
+	MatchId string `protobuf:"bytes,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	// The Assignment applied to all of the match's Tickets.
+	Assignment           *Assignment `protobuf:"bytes,2,opt,name=assignment,proto3" json:"assignment,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *AssignMatchRequest) Reset()         { *m = AssignMatchRequest{} }
+func (m *AssignMatchRequest) String() string { return proto.CompactTextString(m) }
+func (*AssignMatchRequest) ProtoMessage()    {}
+func (*AssignMatchRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8dab762378f455cd, []int{10}
+}
+
+func (m *AssignMatchRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AssignMatchRequest.Unmarshal(m, b)
+}
+func (m *AssignMatchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AssignMatchRequest.Marshal(b, m, deterministic)
+}
+func (m *AssignMatchRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AssignMatchRequest.Merge(m, src)
+}
+func (m *AssignMatchRequest) XXX_Size() int {
+	return xxx_messageInfo_AssignMatchRequest.Size(m)
+}
+func (m *AssignMatchRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AssignMatchRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AssignMatchRequest proto.InternalMessageInfo
+
+func (m *AssignMatchRequest) GetMatchId() string {
+	if m != nil {
+		return m.MatchId
+	}
+	return ""
+}
+
+func (m *AssignMatchRequest) GetAssignment() *Assignment {
+	if m != nil {
+		return m.Assignment
+	}
+	return nil
+}
+
+type AssignMatchResponse struct {
+	// Failures is a list of the match's Tickets that failed assignment along
+	// with the cause of failure.
+	Failures             []*AssignmentFailure `protobuf:"bytes,1,rep,name=failures,proto3" json:"failures,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *AssignMatchResponse) Reset()         { *m = AssignMatchResponse{} }
+func (m *AssignMatchResponse) String() string { return proto.CompactTextString(m) }
+func (*AssignMatchResponse) ProtoMessage()    {}
+func (*AssignMatchResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8dab762378f455cd, []int{11}
+}
+
+func (m *AssignMatchResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AssignMatchResponse.Unmarshal(m, b)
+}
+func (m *AssignMatchResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AssignMatchResponse.Marshal(b, m, deterministic)
+}
+func (m *AssignMatchResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AssignMatchResponse.Merge(m, src)
+}
+func (m *AssignMatchResponse) XXX_Size() int {
+	return xxx_messageInfo_AssignMatchResponse.Size(m)
+}
+func (m *AssignMatchResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AssignMatchResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AssignMatchResponse proto.InternalMessageInfo
+
+func (m *AssignMatchResponse) GetFailures() []*AssignmentFailure {
+	if m != nil {
+		return m.Failures
+	}
+	return nil
+}
+
+type ReleaseMatchRequest struct {
+	// A match_id of an unassigned Match returned by FetchMatches.
+	MatchId              string   `protobuf:"bytes,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReleaseMatchRequest) Reset()         { *m = ReleaseMatchRequest{} }
+func (m *ReleaseMatchRequest) String() string { return proto.CompactTextString(m) }
+func (*ReleaseMatchRequest) ProtoMessage()    {}
+func (*ReleaseMatchRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8dab762378f455cd, []int{12}
+}
+
+func (m *ReleaseMatchRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReleaseMatchRequest.Unmarshal(m, b)
+}
+func (m *ReleaseMatchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReleaseMatchRequest.Marshal(b, m, deterministic)
+}
+func (m *ReleaseMatchRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReleaseMatchRequest.Merge(m, src)
+}
+func (m *ReleaseMatchRequest) XXX_Size() int {
+	return xxx_messageInfo_ReleaseMatchRequest.Size(m)
+}
+func (m *ReleaseMatchRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReleaseMatchRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReleaseMatchRequest proto.InternalMessageInfo
+
+func (m *ReleaseMatchRequest) GetMatchId() string {
+	if m != nil {
+		return m.MatchId
+	}
+	return ""
+}
+
+type ReleaseMatchResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReleaseMatchResponse) Reset()         { *m = ReleaseMatchResponse{} }
+func (m *ReleaseMatchResponse) String() string { return proto.CompactTextString(m) }
+func (*ReleaseMatchResponse) ProtoMessage()    {}
+func (*ReleaseMatchResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8dab762378f455cd, []int{13}
+}
+
+func (m *ReleaseMatchResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReleaseMatchResponse.Unmarshal(m, b)
+}
+func (m *ReleaseMatchResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReleaseMatchResponse.Marshal(b, m, deterministic)
+}
+func (m *ReleaseMatchResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReleaseMatchResponse.Merge(m, src)
+}
+func (m *ReleaseMatchResponse) XXX_Size() int {
+	return xxx_messageInfo_ReleaseMatchResponse.Size(m)
+}
+func (m *ReleaseMatchResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReleaseMatchResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReleaseMatchResponse proto.InternalMessageInfo
+
+type UploadMatchFunctionModuleRequest struct {
+	// Name used to reference the module from FunctionConfig.wasm_module.
+	// Uploading a module with an existing name replaces it.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// A WebAssembly binary module.  The module must export a function named
+	// "run" with no parameters returning an i32, where a non-zero result is
+	// reported as a MatchFunction error.  It may import the following host
+	// functions from the "openmatch" module:
+	//   profile_size() -> i32
+	//     Size of the serialized MatchProfile for this run.
+	//   profile_read(ptr i32)
+	//     Writes the serialized MatchProfile to memory at ptr.
+	//   query_pool(ptr i32, len i32) -> i32
+	//     Queries the tickets for the serialized Pool at ptr, returning the size
+	//     of the serialized QueryTicketsResponse holding all of them, or -1 on
+	//     error.
+	//   result_read(ptr i32)
+	//     Writes the result of the last query_pool call to memory at ptr.
+	//   emit_proposal(ptr i32, len i32) -> i32
+	//     Sends the serialized Match at ptr as a proposal, returning 0 on
+	//     success, or -1 if the proposal could not be sent.
+	Module               []byte   `protobuf:"bytes,2,opt,name=module,proto3" json:"module,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UploadMatchFunctionModuleRequest) Reset()         { *m = UploadMatchFunctionModuleRequest{} }
+func (m *UploadMatchFunctionModuleRequest) String() string { return proto.CompactTextString(m) }
+func (*UploadMatchFunctionModuleRequest) ProtoMessage()    {}
+func (*UploadMatchFunctionModuleRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8dab762378f455cd, []int{14}
+}
+
+func (m *UploadMatchFunctionModuleRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UploadMatchFunctionModuleRequest.Unmarshal(m, b)
+}
+func (m *UploadMatchFunctionModuleRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UploadMatchFunctionModuleRequest.Marshal(b, m, deterministic)
+}
+func (m *UploadMatchFunctionModuleRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UploadMatchFunctionModuleRequest.Merge(m, src)
+}
+func (m *UploadMatchFunctionModuleRequest) XXX_Size() int {
+	return xxx_messageInfo_UploadMatchFunctionModuleRequest.Size(m)
+}
+func (m *UploadMatchFunctionModuleRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UploadMatchFunctionModuleRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UploadMatchFunctionModuleRequest proto.InternalMessageInfo
+
+func (m *UploadMatchFunctionModuleRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *UploadMatchFunctionModuleRequest) GetModule() []byte {
+	if m != nil {
+		return m.Module
+	}
+	return nil
+}
+
+type UploadMatchFunctionModuleResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UploadMatchFunctionModuleResponse) Reset()         { *m = UploadMatchFunctionModuleResponse{} }
+func (m *UploadMatchFunctionModuleResponse) String() string { return proto.CompactTextString(m) }
+func (*UploadMatchFunctionModuleResponse) ProtoMessage()    {}
+func (*UploadMatchFunctionModuleResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8dab762378f455cd, []int{15}
+}
+
+func (m *UploadMatchFunctionModuleResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UploadMatchFunctionModuleResponse.Unmarshal(m, b)
+}
+func (m *UploadMatchFunctionModuleResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UploadMatchFunctionModuleResponse.Marshal(b, m, deterministic)
+}
+func (m *UploadMatchFunctionModuleResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UploadMatchFunctionModuleResponse.Merge(m, src)
+}
+func (m *UploadMatchFunctionModuleResponse) XXX_Size() int {
+	return xxx_messageInfo_UploadMatchFunctionModuleResponse.Size(m)
+}
+func (m *UploadMatchFunctionModuleResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UploadMatchFunctionModuleResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UploadMatchFunctionModuleResponse proto.InternalMessageInfo
+
 type ReleaseTicketsRequest struct {
 	// TicketIds is a list of string representing Open Match generated Ids to be re-enabled for MMF querying
 	// because they are no longer awaiting assignment from a previous match result
@@ -235,7 +1024,7 @@ func (m *ReleaseTicketsRequest) Reset()         { *m = ReleaseTicketsRequest{} }
 func (m *ReleaseTicketsRequest) String() string { return proto.CompactTextString(m) }
 func (*ReleaseTicketsRequest) ProtoMessage()    {}
 func (*ReleaseTicketsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8dab762378f455cd, []int{3}
+	return fileDescriptor_8dab762378f455cd, []int{16}
 }
 
 func (m *ReleaseTicketsRequest) XXX_Unmarshal(b []byte) error {
@@ -273,7 +1062,7 @@ func (m *ReleaseTicketsResponse) Reset()         { *m = ReleaseTicketsResponse{}
 func (m *ReleaseTicketsResponse) String() string { return proto.CompactTextString(m) }
 func (*ReleaseTicketsResponse) ProtoMessage()    {}
 func (*ReleaseTicketsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8dab762378f455cd, []int{4}
+	return fileDescriptor_8dab762378f455cd, []int{17}
 }
 
 func (m *ReleaseTicketsResponse) XXX_Unmarshal(b []byte) error {
@@ -304,7 +1093,7 @@ func (m *ReleaseAllTicketsRequest) Reset()         { *m = ReleaseAllTicketsReque
 func (m *ReleaseAllTicketsRequest) String() string { return proto.CompactTextString(m) }
 func (*ReleaseAllTicketsRequest) ProtoMessage()    {}
 func (*ReleaseAllTicketsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8dab762378f455cd, []int{5}
+	return fileDescriptor_8dab762378f455cd, []int{18}
 }
 
 func (m *ReleaseAllTicketsRequest) XXX_Unmarshal(b []byte) error {
@@ -335,7 +1124,7 @@ func (m *ReleaseAllTicketsResponse) Reset()         { *m = ReleaseAllTicketsResp
 func (m *ReleaseAllTicketsResponse) String() string { return proto.CompactTextString(m) }
 func (*ReleaseAllTicketsResponse) ProtoMessage()    {}
 func (*ReleaseAllTicketsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8dab762378f455cd, []int{6}
+	return fileDescriptor_8dab762378f455cd, []int{19}
 }
 
 func (m *ReleaseAllTicketsResponse) XXX_Unmarshal(b []byte) error {
@@ -371,7 +1160,7 @@ func (m *AssignmentGroup) Reset()         { *m = AssignmentGroup{} }
 func (m *AssignmentGroup) String() string { return proto.CompactTextString(m) }
 func (*AssignmentGroup) ProtoMessage()    {}
 func (*AssignmentGroup) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8dab762378f455cd, []int{7}
+	return fileDescriptor_8dab762378f455cd, []int{20}
 }
 
 func (m *AssignmentGroup) XXX_Unmarshal(b []byte) error {
@@ -419,7 +1208,7 @@ func (m *AssignmentFailure) Reset()         { *m = AssignmentFailure{} }
 func (m *AssignmentFailure) String() string { return proto.CompactTextString(m) }
 func (*AssignmentFailure) ProtoMessage()    {}
 func (*AssignmentFailure) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8dab762378f455cd, []int{8}
+	return fileDescriptor_8dab762378f455cd, []int{21}
 }
 
 func (m *AssignmentFailure) XXX_Unmarshal(b []byte) error {
@@ -466,7 +1255,7 @@ func (m *AssignTicketsRequest) Reset()         { *m = AssignTicketsRequest{} }
 func (m *AssignTicketsRequest) String() string { return proto.CompactTextString(m) }
 func (*AssignTicketsRequest) ProtoMessage()    {}
 func (*AssignTicketsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8dab762378f455cd, []int{9}
+	return fileDescriptor_8dab762378f455cd, []int{22}
 }
 
 func (m *AssignTicketsRequest) XXX_Unmarshal(b []byte) error {
@@ -506,7 +1295,7 @@ func (m *AssignTicketsResponse) Reset()         { *m = AssignTicketsResponse{} }
 func (m *AssignTicketsResponse) String() string { return proto.CompactTextString(m) }
 func (*AssignTicketsResponse) ProtoMessage()    {}
 func (*AssignTicketsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8dab762378f455cd, []int{10}
+	return fileDescriptor_8dab762378f455cd, []int{23}
 }
 
 func (m *AssignTicketsResponse) XXX_Unmarshal(b []byte) error {
@@ -536,10 +1325,25 @@ func (m *AssignTicketsResponse) GetFailures() []*AssignmentFailure {
 
 func init() {
 	proto.RegisterEnum("openmatch.FunctionConfig_Type", FunctionConfig_Type_name, FunctionConfig_Type_value)
+	proto.RegisterEnum("openmatch.ReadyCheckResult_Outcome", ReadyCheckResult_Outcome_name, ReadyCheckResult_Outcome_value)
+	proto.RegisterEnum("openmatch.MatchRecord_State", MatchRecord_State_name, MatchRecord_State_value)
 	proto.RegisterEnum("openmatch.AssignmentFailure_Cause", AssignmentFailure_Cause_name, AssignmentFailure_Cause_value)
 	proto.RegisterType((*FunctionConfig)(nil), "openmatch.FunctionConfig")
 	proto.RegisterType((*FetchMatchesRequest)(nil), "openmatch.FetchMatchesRequest")
+	proto.RegisterType((*ReadyCheck)(nil), "openmatch.ReadyCheck")
+	proto.RegisterType((*ReadyCheckResult)(nil), "openmatch.ReadyCheckResult")
 	proto.RegisterType((*FetchMatchesResponse)(nil), "openmatch.FetchMatchesResponse")
+	proto.RegisterType((*RejectedProposal)(nil), "openmatch.RejectedProposal")
+	proto.RegisterType((*MatchRecord)(nil), "openmatch.MatchRecord")
+	proto.RegisterType((*GetMatchRequest)(nil), "openmatch.GetMatchRequest")
+	proto.RegisterType((*ListMatchesRequest)(nil), "openmatch.ListMatchesRequest")
+	proto.RegisterType((*ListMatchesResponse)(nil), "openmatch.ListMatchesResponse")
+	proto.RegisterType((*AssignMatchRequest)(nil), "openmatch.AssignMatchRequest")
+	proto.RegisterType((*AssignMatchResponse)(nil), "openmatch.AssignMatchResponse")
+	proto.RegisterType((*ReleaseMatchRequest)(nil), "openmatch.ReleaseMatchRequest")
+	proto.RegisterType((*ReleaseMatchResponse)(nil), "openmatch.ReleaseMatchResponse")
+	proto.RegisterType((*UploadMatchFunctionModuleRequest)(nil), "openmatch.UploadMatchFunctionModuleRequest")
+	proto.RegisterType((*UploadMatchFunctionModuleResponse)(nil), "openmatch.UploadMatchFunctionModuleResponse")
 	proto.RegisterType((*ReleaseTicketsRequest)(nil), "openmatch.ReleaseTicketsRequest")
 	proto.RegisterType((*ReleaseTicketsResponse)(nil), "openmatch.ReleaseTicketsResponse")
 	proto.RegisterType((*ReleaseAllTicketsRequest)(nil), "openmatch.ReleaseAllTicketsRequest")
@@ -553,66 +1357,115 @@ func init() {
 func init() { proto.RegisterFile("api/backend.proto", fileDescriptor_8dab762378f455cd) }
 
 var fileDescriptor_8dab762378f455cd = []byte{
-	// 929 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x55, 0xdd, 0x6e, 0x1b, 0x45,
-	0x14, 0xee, 0x3a, 0xce, 0x8f, 0x4f, 0xc0, 0x38, 0x43, 0x52, 0xdc, 0x6d, 0xa1, 0x9b, 0x29, 0x2d,
-	0x91, 0xa9, 0xbd, 0xce, 0x12, 0x50, 0x65, 0x7e, 0xd4, 0xd4, 0x4d, 0xaa, 0xa8, 0xc5, 0x29, 0x1b,
-	0x17, 0x24, 0x6e, 0xa2, 0xf5, 0xfa, 0x78, 0xbd, 0x64, 0xbd, 0xb3, 0xec, 0xcc, 0xa6, 0x54, 0x48,
-	0x08, 0x21, 0x2e, 0x10, 0x57, 0x08, 0x24, 0x2e, 0x78, 0x04, 0x24, 0x2e, 0x78, 0x16, 0x6e, 0x78,
-	0x00, 0x1e, 0x04, 0xed, 0xcc, 0xfa, 0xdf, 0x49, 0xaf, 0xbc, 0x33, 0xe7, 0x3b, 0xdf, 0xf7, 0xcd,
-	0x99, 0x73, 0x3c, 0xb0, 0xe1, 0x44, 0xbe, 0xd9, 0x71, 0xdc, 0x33, 0x0c, 0xbb, 0xb5, 0x28, 0x66,
-	0x82, 0x91, 0x02, 0x8b, 0x30, 0x1c, 0x38, 0xc2, 0xed, 0xeb, 0x24, 0x8d, 0x0e, 0x90, 0x73, 0xc7,
-	0x43, 0xae, 0xc2, 0xfa, 0x0d, 0x8f, 0x31, 0x2f, 0x40, 0x33, 0x0d, 0x39, 0x61, 0xc8, 0x84, 0x23,
-	0x7c, 0x16, 0x0e, 0xa3, 0x77, 0xe5, 0x8f, 0x5b, 0xf5, 0x30, 0xac, 0xf2, 0xe7, 0x8e, 0xe7, 0x61,
-	0x6c, 0xb2, 0x48, 0x22, 0xe6, 0xd1, 0xf4, 0x27, 0x0d, 0x8a, 0x87, 0x49, 0xe8, 0xa6, 0x7b, 0x4d,
-	0x16, 0xf6, 0x7c, 0x8f, 0x10, 0xc8, 0xf7, 0x19, 0x17, 0x65, 0xcd, 0xd0, 0x76, 0x0a, 0xb6, 0xfc,
-	0x4e, 0xf7, 0x22, 0x16, 0x8b, 0x72, 0xce, 0xd0, 0x76, 0x96, 0x6d, 0xf9, 0x4d, 0x2c, 0xc8, 0x8b,
-	0x17, 0x11, 0x96, 0x97, 0x0c, 0x6d, 0xa7, 0x68, 0xbd, 0x55, 0x1b, 0x99, 0xae, 0x4d, 0x13, 0xd6,
-	0xda, 0x2f, 0x22, 0xb4, 0x25, 0x96, 0xea, 0x90, 0x4f, 0x57, 0x64, 0x0d, 0xf2, 0x8f, 0xec, 0xa7,
-	0xcd, 0xd2, 0x95, 0xf4, 0xcb, 0x3e, 0x38, 0x69, 0x97, 0x34, 0xfa, 0x2d, 0xbc, 0x7e, 0x88, 0xc2,
-	0xed, 0x7f, 0x9a, 0x72, 0x20, 0xb7, 0xf1, 0xeb, 0x04, 0xb9, 0x20, 0xbb, 0xb0, 0xe2, 0x4a, 0x1e,
-	0x69, 0x68, 0xdd, 0xba, 0x76, 0xa1, 0x90, 0x9d, 0x01, 0xc9, 0x2e, 0xac, 0x46, 0x31, 0xeb, 0xf9,
-	0x01, 0x4a, 0xc3, 0xeb, 0xd6, 0x1b, 0x13, 0x39, 0x92, 0xfe, 0xa9, 0x0a, 0xdb, 0x43, 0x1c, 0xfd,
-	0x04, 0x36, 0xa7, 0xc5, 0x79, 0xc4, 0x42, 0x8e, 0xe4, 0x0e, 0x2c, 0xcb, 0xb4, 0x4c, 0xbc, 0x34,
-	0x4b, 0x64, 0xab, 0x30, 0xfd, 0x00, 0xb6, 0x6c, 0x0c, 0xd0, 0xe1, 0xd8, 0xf6, 0xdd, 0x33, 0x14,
-	0x23, 0xfb, 0x6f, 0x02, 0x08, 0xb9, 0x73, 0xea, 0x77, 0x79, 0x59, 0x33, 0x96, 0x76, 0x0a, 0x76,
-	0x41, 0xed, 0x1c, 0x75, 0x39, 0x2d, 0xc3, 0xd5, 0xd9, 0x3c, 0xa5, 0x4c, 0x75, 0x28, 0x67, 0x91,
-	0xfd, 0x20, 0x98, 0x26, 0xa5, 0xd7, 0xe1, 0xda, 0x82, 0x58, 0x96, 0xe8, 0xc1, 0x6b, 0xfb, 0x9c,
-	0xfb, 0x5e, 0x38, 0xc0, 0x50, 0x3c, 0x8a, 0x59, 0x12, 0xbd, 0xc4, 0x04, 0x79, 0x1f, 0xc0, 0x19,
-	0x65, 0x64, 0x25, 0xdb, 0x9a, 0x38, 0xe9, 0x98, 0xce, 0x9e, 0x00, 0xd2, 0xdf, 0x35, 0xd8, 0x18,
-	0x87, 0x0e, 0x1d, 0x3f, 0x48, 0x62, 0x24, 0xd7, 0xa1, 0x30, 0xd2, 0xca, 0x7a, 0x68, 0x6d, 0x28,
-	0x45, 0xee, 0xc1, 0xb2, 0xeb, 0x24, 0x5c, 0xdd, 0x4b, 0xd1, 0xa2, 0x0b, 0x45, 0x32, 0xa6, 0x5a,
-	0x33, 0x45, 0xda, 0x2a, 0x81, 0x56, 0x60, 0x59, 0xae, 0xc9, 0x3a, 0xac, 0x3e, 0x6b, 0x3d, 0x6e,
-	0x1d, 0x7f, 0xd1, 0x2a, 0x5d, 0x21, 0x9b, 0x50, 0x6a, 0x1f, 0x35, 0x1f, 0x1f, 0xb4, 0x4f, 0x5b,
-	0xc7, 0xed, 0xd3, 0xc3, 0xe3, 0x67, 0xad, 0x87, 0x25, 0x8d, 0xb6, 0x61, 0x53, 0xb1, 0xcd, 0xdc,
-	0xc5, 0x47, 0xb0, 0x3e, 0xb6, 0xaf, 0xea, 0xb0, 0x6e, 0xe9, 0x0b, 0x3d, 0xc8, 0xba, 0xd9, 0x93,
-	0x70, 0xfa, 0x19, 0x6c, 0xcd, 0xb0, 0x66, 0x3d, 0x72, 0x0f, 0xd6, 0x7a, 0xca, 0xf2, 0x90, 0xf3,
-	0xc6, 0x65, 0xe7, 0xb2, 0x47, 0x68, 0xeb, 0xaf, 0x3c, 0x14, 0x1f, 0xa8, 0xd1, 0x3f, 0xc1, 0xf8,
-	0xdc, 0x77, 0x91, 0x7c, 0x07, 0xaf, 0x4c, 0x36, 0x22, 0x99, 0x9a, 0xab, 0xf9, 0xf1, 0xd0, 0x6f,
-	0x5e, 0x18, 0xcf, 0xda, 0xe1, 0xdd, 0x1f, 0xfe, 0xf9, 0xef, 0xb7, 0xdc, 0x6d, 0x6a, 0x98, 0xe7,
-	0xbb, 0xc3, 0xff, 0x19, 0xae, 0xc4, 0xcc, 0x81, 0xc2, 0x36, 0x7a, 0x69, 0x62, 0x43, 0xab, 0xd4,
-	0x35, 0xf2, 0xbd, 0x06, 0xaf, 0x4e, 0x1d, 0x93, 0xdc, 0x9c, 0x3b, 0xcc, 0x74, 0x59, 0x75, 0xe3,
-	0x62, 0x40, 0xe6, 0xe1, 0xae, 0xf4, 0x70, 0x87, 0x6e, 0x2f, 0xf0, 0xa0, 0x7a, 0x83, 0x37, 0x54,
-	0xa9, 0x1b, 0x5a, 0x85, 0xfc, 0xa8, 0x41, 0x71, 0x7a, 0x28, 0xc8, 0xa4, 0xc4, 0xc2, 0x39, 0xd3,
-	0xb7, 0x2f, 0x41, 0x64, 0x2e, 0xaa, 0xd2, 0xc5, 0x3b, 0x94, 0x5e, 0xe2, 0x22, 0x56, 0xa9, 0xa9,
-	0x8d, 0x5f, 0x34, 0xd8, 0x98, 0x9b, 0x32, 0x72, 0x6b, 0x5e, 0x67, 0x6e, 0x3e, 0xf5, 0xb7, 0x2f,
-	0x07, 0x65, 0x7e, 0xea, 0xd2, 0x4f, 0x85, 0xde, 0x7e, 0xb9, 0x1f, 0x27, 0x08, 0x1a, 0x5a, 0xe5,
-	0xc1, 0xcf, 0x4b, 0xbf, 0xee, 0xff, 0x9b, 0x23, 0x7f, 0x6b, 0xb0, 0x9a, 0xb5, 0x0d, 0x3d, 0x02,
-	0x38, 0x8e, 0x30, 0x34, 0xe4, 0xb5, 0x93, 0xab, 0x7d, 0x21, 0x22, 0xde, 0x30, 0xcd, 0x54, 0xbf,
-	0xaa, 0x0c, 0x74, 0xf1, 0x5c, 0xbf, 0x35, 0x5e, 0x57, 0xbb, 0x3e, 0x77, 0x13, 0xce, 0xef, 0xab,
-	0x57, 0xc4, 0x4b, 0x1b, 0x9d, 0xd7, 0x5c, 0x36, 0xa8, 0x7c, 0x0e, 0x64, 0x3f, 0x72, 0xdc, 0x3e,
-	0x1a, 0x56, 0xad, 0x6e, 0x3c, 0xf1, 0x5d, 0x4c, 0xbb, 0xfb, 0xfe, 0x90, 0xd2, 0xf3, 0x45, 0x3f,
-	0xe9, 0xa4, 0x48, 0x53, 0xa5, 0xf6, 0x58, 0xec, 0x39, 0x03, 0xe4, 0x13, 0x62, 0x66, 0x27, 0x60,
-	0x1d, 0x73, 0xe0, 0x70, 0x81, 0xb1, 0xf9, 0xe4, 0xa8, 0x79, 0xd0, 0x3a, 0x39, 0xb0, 0x96, 0x76,
-	0x6b, 0xf5, 0x4a, 0x4e, 0xcb, 0x59, 0x25, 0x27, 0x8a, 0x02, 0xdf, 0x95, 0x0f, 0x90, 0xf9, 0x15,
-	0x67, 0x61, 0x63, 0x6e, 0xc7, 0xfe, 0x10, 0x96, 0xf6, 0xea, 0x7b, 0x64, 0x0f, 0x2a, 0x36, 0x8a,
-	0x24, 0x0e, 0xb1, 0x6b, 0x3c, 0xef, 0x63, 0x68, 0x88, 0x3e, 0x1a, 0x31, 0x72, 0x96, 0xc4, 0x2e,
-	0x1a, 0x5d, 0x86, 0xdc, 0x08, 0x99, 0x30, 0xf0, 0x1b, 0x9f, 0x8b, 0x1a, 0x59, 0x81, 0xfc, 0x1f,
-	0x39, 0x6d, 0x35, 0xfe, 0x18, 0xca, 0xe3, 0x62, 0x18, 0x0f, 0x99, 0x9b, 0xa4, 0x73, 0x27, 0xd9,
-	0xc9, 0xf6, 0xe2, 0xd2, 0x98, 0xdc, 0x17, 0x68, 0x76, 0x99, 0xcb, 0xcd, 0x2f, 0x8d, 0x99, 0xd0,
-	0xc4, 0xb9, 0xa2, 0x33, 0xcf, 0x8c, 0x3a, 0x7f, 0xe6, 0x0a, 0x29, 0xbf, 0xa4, 0xef, 0xac, 0xc8,
-	0x17, 0xf4, 0xbd, 0xff, 0x03, 0x00, 0x00, 0xff, 0xff, 0xea, 0xe8, 0xfb, 0x0c, 0xc1, 0x07, 0x00,
-	0x00,
+	// 1721 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x57, 0x4f, 0x6f, 0xdb, 0xc8,
+	0x15, 0x0f, 0x25, 0xd9, 0x92, 0x9e, 0x1c, 0xad, 0x3c, 0x4e, 0xbc, 0x8a, 0x92, 0x8d, 0x69, 0x66,
+	0xb3, 0x35, 0xbc, 0xb6, 0x68, 0x2b, 0x69, 0x36, 0xf5, 0xee, 0x16, 0xab, 0xb5, 0x14, 0x57, 0x58,
+	0x47, 0x4e, 0x69, 0xb9, 0x41, 0x7b, 0x11, 0x68, 0x72, 0x2c, 0x71, 0x43, 0x91, 0x2c, 0x67, 0x98,
+	0x6c, 0x50, 0xb4, 0x28, 0x8a, 0xf6, 0xd0, 0x9e, 0x8a, 0x16, 0xe8, 0xa1, 0x1f, 0xa1, 0xb7, 0xa2,
+	0xf7, 0x7e, 0x89, 0x05, 0x8a, 0x1e, 0x7a, 0xec, 0x17, 0xe8, 0x37, 0x28, 0xe6, 0x0f, 0x65, 0x52,
+	0x7f, 0x6c, 0x21, 0x7b, 0x12, 0x39, 0xef, 0xf7, 0xde, 0xfb, 0xcd, 0x9b, 0xf7, 0x7b, 0x43, 0xc1,
+	0xaa, 0x19, 0x38, 0xfa, 0xb9, 0x69, 0xbd, 0xc2, 0x9e, 0x5d, 0x0f, 0x42, 0x9f, 0xfa, 0xa8, 0xe8,
+	0x07, 0xd8, 0x1b, 0x99, 0xd4, 0x1a, 0xd6, 0x10, 0xb3, 0x8e, 0x30, 0x21, 0xe6, 0x00, 0x13, 0x61,
+	0xae, 0xdd, 0x1b, 0xf8, 0xfe, 0xc0, 0xc5, 0x3a, 0x33, 0x99, 0x9e, 0xe7, 0x53, 0x93, 0x3a, 0xbe,
+	0x17, 0x5b, 0xef, 0x4b, 0x2b, 0x7f, 0x3b, 0x8f, 0x2e, 0x74, 0x3b, 0x0a, 0x39, 0x40, 0xda, 0x37,
+	0x26, 0xed, 0xd4, 0x19, 0x61, 0x42, 0xcd, 0x51, 0x20, 0x01, 0x3b, 0xfc, 0xc7, 0xda, 0x1d, 0x60,
+	0x6f, 0x97, 0xbc, 0x31, 0x07, 0x03, 0x1c, 0xea, 0x7e, 0xc0, 0x53, 0x4c, 0xa7, 0xd3, 0xfe, 0xa1,
+	0x40, 0xf9, 0x59, 0xe4, 0x59, 0x6c, 0xed, 0xd0, 0xf7, 0x2e, 0x9c, 0x01, 0x42, 0x90, 0x1b, 0xfa,
+	0x84, 0x56, 0x15, 0x55, 0xd9, 0x2a, 0x1a, 0xfc, 0x99, 0xad, 0x05, 0x7e, 0x48, 0xab, 0x19, 0x55,
+	0xd9, 0x5a, 0x32, 0xf8, 0x33, 0x6a, 0x40, 0x8e, 0xbe, 0x0d, 0x70, 0x35, 0xab, 0x2a, 0x5b, 0xe5,
+	0xc6, 0xfd, 0xfa, 0x78, 0xd7, 0xf5, 0x74, 0xc0, 0x7a, 0xef, 0x6d, 0x80, 0x0d, 0x8e, 0x45, 0x1b,
+	0x50, 0x7a, 0x63, 0x92, 0x51, 0x7f, 0xe4, 0xdb, 0x91, 0x8b, 0xab, 0x39, 0x9e, 0x02, 0xd8, 0xd2,
+	0x73, 0xbe, 0xa2, 0x7d, 0x08, 0x39, 0x06, 0x47, 0x05, 0xc8, 0x1d, 0x19, 0x2f, 0x0e, 0x2b, 0x37,
+	0xd8, 0x93, 0xd1, 0x3e, 0xed, 0x55, 0x14, 0xf6, 0xf4, 0xb2, 0x79, 0xfa, 0xbc, 0x92, 0xd1, 0xfe,
+	0xa7, 0xc0, 0xda, 0x33, 0x4c, 0xad, 0xe1, 0x73, 0x96, 0x0f, 0x13, 0x03, 0xff, 0x3c, 0xc2, 0x84,
+	0xa2, 0x7d, 0x58, 0xb6, 0x78, 0x4e, 0x4e, 0xbe, 0xd4, 0xb8, 0x33, 0x97, 0x94, 0x21, 0x81, 0x68,
+	0x1f, 0xf2, 0x41, 0xe8, 0x5f, 0x38, 0x2e, 0xe6, 0x9b, 0x2b, 0x35, 0xde, 0x4f, 0xf8, 0xf0, 0xf0,
+	0x2f, 0x84, 0xd9, 0x88, 0x71, 0xe8, 0x33, 0xa8, 0x39, 0x9e, 0xe5, 0x46, 0x36, 0xee, 0x87, 0xf8,
+	0x6b, 0x6c, 0x51, 0x6c, 0xf7, 0x83, 0xd0, 0x0f, 0x7c, 0x62, 0xba, 0x84, 0x97, 0xa3, 0x60, 0x54,
+	0x25, 0xc2, 0x90, 0x80, 0x17, 0xb1, 0x1d, 0x3d, 0x81, 0x52, 0x88, 0x4d, 0xfb, 0x6d, 0xdf, 0x1a,
+	0x62, 0xeb, 0x15, 0x2f, 0x41, 0xa9, 0x71, 0x3b, 0x91, 0xd4, 0x60, 0xd6, 0x43, 0x66, 0x34, 0x20,
+	0x1c, 0x3f, 0x6b, 0x6f, 0x01, 0x2e, 0x2d, 0xe8, 0x11, 0xe4, 0xd9, 0xc1, 0xfb, 0x11, 0x1d, 0x6f,
+	0x55, 0x34, 0x46, 0x3d, 0x6e, 0x8c, 0x7a, 0x4b, 0x36, 0x8e, 0x11, 0x23, 0xd1, 0x13, 0x78, 0xdf,
+	0xc6, 0x2e, 0xa6, 0xb8, 0x6f, 0x63, 0xcb, 0x75, 0x3c, 0x6c, 0xf7, 0xa9, 0x63, 0xbd, 0xc2, 0x94,
+	0xf0, 0xbd, 0x17, 0x8c, 0xdb, 0xc2, 0xdc, 0x92, 0xd6, 0x9e, 0x30, 0x6a, 0xff, 0x51, 0xa0, 0x92,
+	0x60, 0x85, 0x49, 0xe4, 0x52, 0x74, 0x07, 0x0a, 0x9c, 0x6f, 0xdf, 0xb1, 0x65, 0xab, 0xe4, 0xf9,
+	0x7b, 0xc7, 0x46, 0x9f, 0x43, 0xde, 0x8f, 0xa8, 0xe5, 0x8f, 0x44, 0x4d, 0xcb, 0x8d, 0x07, 0xb3,
+	0xb7, 0xc7, 0x03, 0xd5, 0x4f, 0x04, 0xd4, 0x88, 0x7d, 0x50, 0x1d, 0xd6, 0x26, 0xf8, 0xf5, 0x1d,
+	0x9b, 0x15, 0x36, 0xbb, 0x55, 0x34, 0x56, 0xed, 0x14, 0xb9, 0x8e, 0x4d, 0xb4, 0x1f, 0x42, 0x5e,
+	0xc6, 0x40, 0x25, 0xc8, 0x9f, 0x75, 0xbf, 0xea, 0x9e, 0xbc, 0xec, 0x56, 0x6e, 0xa0, 0x22, 0x2c,
+	0x19, 0xed, 0x66, 0xeb, 0xa7, 0x15, 0x05, 0xad, 0x40, 0xa1, 0xd5, 0x3e, 0x3c, 0xee, 0x74, 0xdb,
+	0xad, 0x4a, 0x06, 0xdd, 0x84, 0x62, 0xaf, 0xf3, 0xbc, 0xdd, 0xea, 0x9f, 0x9c, 0xf5, 0x2a, 0x59,
+	0xed, 0x5b, 0x05, 0x6e, 0xa5, 0xbb, 0x89, 0x04, 0xbe, 0x47, 0x30, 0xfa, 0x08, 0x96, 0x38, 0x67,
+	0x59, 0xe2, 0xca, 0x64, 0x67, 0x18, 0xc2, 0x8c, 0x7e, 0x04, 0xab, 0x53, 0x8d, 0x20, 0xbb, 0xe9,
+	0x6e, 0x6a, 0xe7, 0xe9, 0x5e, 0x30, 0x2a, 0xe1, 0xc4, 0x0a, 0xea, 0x00, 0x4a, 0x34, 0x47, 0x3f,
+	0xe4, 0x15, 0xaa, 0x66, 0x67, 0x84, 0x4a, 0x17, 0x91, 0x85, 0x4a, 0xaf, 0x68, 0xdf, 0xb0, 0x33,
+	0x9b, 0x08, 0xbf, 0x03, 0x85, 0x31, 0xbf, 0x79, 0x7b, 0x1a, 0x23, 0xd0, 0x13, 0x28, 0x0a, 0x82,
+	0x8e, 0xef, 0xc9, 0xed, 0x54, 0x67, 0x6c, 0x47, 0xb8, 0x5d, 0x42, 0xb5, 0x7f, 0xe6, 0xa0, 0x24,
+	0x16, 0xb1, 0xe5, 0x87, 0xf6, 0xc2, 0x65, 0x7c, 0x07, 0x29, 0x5e, 0x0a, 0x3e, 0xbb, 0xa8, 0xe0,
+	0x1b, 0xb0, 0x44, 0xa8, 0x49, 0xc5, 0xf0, 0x29, 0x37, 0xee, 0x4d, 0xb1, 0xe1, 0xa4, 0xeb, 0xa7,
+	0x0c, 0x63, 0x08, 0x28, 0xfa, 0x14, 0x4a, 0x56, 0x88, 0x4d, 0x8a, 0xfb, 0x4c, 0x4a, 0xd5, 0x25,
+	0x9e, 0xab, 0x36, 0xa5, 0xb8, 0x5e, 0x3c, 0x8a, 0x0d, 0x10, 0x70, 0xb6, 0x30, 0x29, 0xf8, 0xe5,
+	0x05, 0x05, 0x8f, 0x9a, 0x50, 0x16, 0x7e, 0x36, 0x36, 0x6d, 0xd6, 0xf2, 0xd5, 0xfc, 0xb5, 0x79,
+	0x6f, 0x72, 0x8f, 0x96, 0x74, 0x60, 0x4a, 0x32, 0x2d, 0x0b, 0x07, 0x34, 0xad, 0xa4, 0x82, 0x50,
+	0x52, 0x6c, 0x1a, 0x2b, 0x69, 0x9e, 0xf2, 0x8a, 0xf3, 0x94, 0x77, 0x06, 0x4b, 0xbc, 0x4e, 0xa8,
+	0x0c, 0x70, 0xd6, 0x6d, 0x9e, 0x9e, 0x76, 0x8e, 0x98, 0xc2, 0x6e, 0x30, 0xbd, 0x8d, 0xdf, 0xb8,
+	0xfa, 0x8c, 0xf6, 0x71, 0xbb, 0x79, 0xca, 0xd5, 0x87, 0xa0, 0xdc, 0x7c, 0xd9, 0xec, 0xf4, 0x3a,
+	0xdd, 0xa3, 0xbe, 0xd0, 0x67, 0x36, 0xa5, 0xcf, 0x9c, 0xb6, 0x03, 0xef, 0x1d, 0x61, 0x2a, 0x4f,
+	0x43, 0x4c, 0xf6, 0xf9, 0xd3, 0x46, 0xfb, 0x04, 0xd0, 0xb1, 0x43, 0xe8, 0xc4, 0x55, 0xb0, 0x09,
+	0x2b, 0xb2, 0x49, 0xfa, 0x9e, 0x39, 0xc2, 0xd2, 0xa9, 0x24, 0xd7, 0xba, 0xe6, 0x08, 0x6b, 0x47,
+	0xb0, 0x96, 0x72, 0x94, 0xaa, 0xdf, 0x03, 0x11, 0x1a, 0x93, 0xaa, 0xa2, 0x66, 0xb7, 0x4a, 0x8d,
+	0xf5, 0xd9, 0x2d, 0x62, 0xc4, 0x30, 0xed, 0x02, 0x50, 0x93, 0x10, 0x67, 0xe0, 0x2d, 0x48, 0x19,
+	0x7d, 0x1f, 0xc0, 0xe4, 0x0e, 0x23, 0xec, 0xd1, 0x6a, 0x66, 0xaa, 0x23, 0x9a, 0x63, 0xa3, 0x91,
+	0x00, 0x6a, 0x27, 0xb0, 0x96, 0xca, 0x23, 0x09, 0x3f, 0x85, 0xc2, 0x85, 0xe9, 0xb8, 0x51, 0x38,
+	0x66, 0x7c, 0x6f, 0x66, 0xac, 0x67, 0x02, 0x64, 0x8c, 0xd1, 0xda, 0x1e, 0xac, 0x19, 0xd8, 0xc5,
+	0x26, 0xc1, 0x8b, 0x16, 0x7b, 0x1d, 0x6e, 0xa5, 0x3d, 0x04, 0x07, 0xad, 0x0b, 0xea, 0x59, 0xe0,
+	0xfa, 0xa6, 0x98, 0x06, 0xb1, 0xf4, 0xc4, 0xa5, 0x1e, 0x87, 0x45, 0x90, 0x4b, 0x1c, 0x05, 0x7f,
+	0x46, 0xeb, 0xb0, 0x2c, 0xbf, 0x05, 0x58, 0x15, 0x56, 0x0c, 0xf9, 0xa6, 0x3d, 0x80, 0xcd, 0x2b,
+	0xe2, 0xc9, 0xa4, 0x4f, 0xe0, 0xb6, 0x24, 0x23, 0x6f, 0xaa, 0x38, 0xd3, 0x07, 0x00, 0x89, 0xf6,
+	0x55, 0x78, 0xfb, 0x16, 0xe9, 0xb8, 0x6d, 0xab, 0xb0, 0x3e, 0xe9, 0x27, 0x23, 0xd6, 0xa0, 0x2a,
+	0x2d, 0x4d, 0xd7, 0x4d, 0x07, 0xd5, 0xee, 0xc2, 0x9d, 0x19, 0x36, 0xe9, 0x38, 0x80, 0xf7, 0x2e,
+	0x0b, 0x7d, 0x14, 0xfa, 0x51, 0x70, 0x0d, 0x89, 0x77, 0xed, 0x81, 0xbf, 0x28, 0xb0, 0x3a, 0x75,
+	0xa4, 0xe8, 0x2e, 0x14, 0xc7, 0xb9, 0x64, 0x7d, 0x0b, 0x71, 0x2a, 0xf4, 0x14, 0x96, 0x2c, 0x33,
+	0x22, 0xf1, 0x65, 0xac, 0x5d, 0xd5, 0x1c, 0xf5, 0x43, 0x86, 0x34, 0x84, 0x83, 0xb6, 0x0d, 0x4b,
+	0xfc, 0x3d, 0x7d, 0xaf, 0xde, 0x82, 0x4a, 0xaf, 0x73, 0xf8, 0x55, 0xbb, 0xd7, 0xef, 0x9e, 0xf4,
+	0xfa, 0xcf, 0x4e, 0xce, 0xba, 0xad, 0x8a, 0xa2, 0xf5, 0xe0, 0x96, 0x88, 0x36, 0x71, 0x16, 0x9f,
+	0x41, 0xe9, 0x92, 0x7e, 0xdc, 0xa0, 0xb5, 0x99, 0x1c, 0x78, 0xdd, 0x8c, 0x24, 0x5c, 0xfb, 0x31,
+	0xdc, 0x9e, 0x88, 0xfa, 0x5d, 0x9b, 0xbe, 0xf1, 0xaf, 0x22, 0x94, 0xbf, 0x14, 0x1f, 0xec, 0xa7,
+	0x38, 0x7c, 0xed, 0x58, 0x18, 0xfd, 0x0a, 0x56, 0x92, 0x1f, 0x00, 0x28, 0xf5, 0x31, 0x3b, 0xfd,
+	0x9d, 0x59, 0xdb, 0x98, 0x6b, 0x97, 0xed, 0xf0, 0xf1, 0x6f, 0xbe, 0xfd, 0xef, 0x9f, 0x33, 0x0f,
+	0x35, 0x55, 0x7f, 0xbd, 0x1f, 0xff, 0x3b, 0x20, 0x22, 0x99, 0x2e, 0xa7, 0xc6, 0xc1, 0x05, 0x73,
+	0x3c, 0x50, 0xb6, 0xf7, 0x14, 0x34, 0x82, 0x42, 0x3c, 0xf0, 0x50, 0xb2, 0x34, 0x13, 0x53, 0xb0,
+	0x36, 0x67, 0x12, 0x69, 0xbb, 0x3c, 0xdd, 0xf7, 0xd0, 0xc3, 0xf9, 0xe9, 0xf4, 0x5f, 0xc4, 0x92,
+	0xfe, 0x25, 0x0a, 0xa0, 0x94, 0x18, 0x7c, 0xe8, 0x83, 0x44, 0xd4, 0xe9, 0x49, 0x5a, 0xbb, 0x3f,
+	0xcf, 0x2c, 0xf7, 0xaa, 0xf1, 0xe4, 0xf7, 0x50, 0x6d, 0x7e, 0x72, 0xf4, 0x3b, 0x05, 0x4a, 0x89,
+	0xd1, 0x95, 0x4a, 0x39, 0x3d, 0x3a, 0x6b, 0xf7, 0xe7, 0x99, 0x65, 0xca, 0x4f, 0x78, 0xca, 0x7d,
+	0x6d, 0x67, 0xa1, 0xfd, 0x1e, 0x88, 0x86, 0x3a, 0x50, 0xb6, 0xd1, 0xef, 0x15, 0x58, 0x49, 0xce,
+	0xaf, 0xd4, 0x49, 0xcf, 0x18, 0x85, 0xb5, 0x8d, 0xb9, 0x76, 0x49, 0xe5, 0x29, 0xa7, 0xd2, 0xd0,
+	0x76, 0x17, 0xa3, 0x12, 0x8a, 0x18, 0x8c, 0xcb, 0xdf, 0x15, 0xb8, 0x33, 0x77, 0xc6, 0xa1, 0x8f,
+	0x13, 0x89, 0xaf, 0x9b, 0xac, 0xb5, 0x9d, 0xc5, 0xc0, 0x92, 0xf2, 0x0f, 0x38, 0xe5, 0x47, 0x5a,
+	0x7d, 0x1e, 0xe5, 0x0b, 0xe9, 0x27, 0x66, 0x31, 0x39, 0x88, 0x78, 0x48, 0xc6, 0xf9, 0xd7, 0x0a,
+	0xdc, 0x4c, 0xe9, 0x11, 0x6d, 0x4c, 0x1d, 0x55, 0x5a, 0xff, 0x35, 0x75, 0x3e, 0x40, 0xf2, 0xd9,
+	0xe1, 0x7c, 0x3e, 0xd2, 0x36, 0x67, 0xf0, 0x91, 0xff, 0x4f, 0x12, 0x47, 0xf8, 0x5b, 0x05, 0xca,
+	0xe9, 0xe9, 0x8d, 0xd4, 0xe9, 0x43, 0x9a, 0x20, 0xb1, 0x79, 0x05, 0x42, 0xb2, 0x90, 0x1a, 0xd2,
+	0xb4, 0x2b, 0x58, 0x24, 0x4e, 0xef, 0x8f, 0x0a, 0xac, 0x4e, 0x5d, 0x07, 0xe8, 0xc1, 0x74, 0x9e,
+	0xa9, 0x8b, 0xa4, 0xf6, 0xe1, 0xd5, 0x20, 0xc9, 0x67, 0x8f, 0xf3, 0xd9, 0xd6, 0x1e, 0x5e, 0xcf,
+	0xc7, 0x74, 0xdd, 0x03, 0x65, 0xfb, 0xcb, 0x3f, 0x64, 0xff, 0xd4, 0xfc, 0x77, 0x86, 0xf5, 0x55,
+	0x5e, 0xce, 0x37, 0xad, 0x03, 0x70, 0x12, 0x60, 0x4f, 0x15, 0xcd, 0xbe, 0x3e, 0xa4, 0x34, 0x20,
+	0x07, 0xba, 0xce, 0xf2, 0xef, 0x0a, 0x02, 0x36, 0x7e, 0x5d, 0x7b, 0x70, 0xf9, 0xbe, 0x6b, 0x3b,
+	0xc4, 0x8a, 0x08, 0xf9, 0x42, 0x7c, 0x63, 0x0e, 0xd8, 0x44, 0x26, 0x75, 0xcb, 0x1f, 0x6d, 0xff,
+	0x04, 0x50, 0x33, 0x30, 0xad, 0x21, 0x56, 0x1b, 0xf5, 0x3d, 0xf5, 0xd8, 0xb1, 0x30, 0x1b, 0xc3,
+	0x5f, 0xc4, 0x21, 0x07, 0x0e, 0x1d, 0x46, 0xe7, 0x0c, 0xa9, 0x0b, 0xd7, 0x0b, 0x3f, 0x1c, 0x98,
+	0x23, 0x4c, 0x12, 0xc9, 0xf4, 0x73, 0xd7, 0x3f, 0xd7, 0x47, 0x26, 0xa1, 0x38, 0xd4, 0x8f, 0x3b,
+	0x87, 0xed, 0xee, 0x69, 0xbb, 0x91, 0xdd, 0xaf, 0xef, 0x6d, 0x67, 0x94, 0x4c, 0xa3, 0x62, 0x06,
+	0x81, 0xeb, 0x58, 0xfc, 0x3f, 0xab, 0xfe, 0x35, 0xf1, 0xbd, 0x83, 0xa9, 0x15, 0xe3, 0x53, 0xc8,
+	0x3e, 0xde, 0x7b, 0x8c, 0x1e, 0xc3, 0xb6, 0x81, 0x69, 0x14, 0x7a, 0xd8, 0x56, 0xdf, 0x0c, 0xb1,
+	0xa7, 0xd2, 0x21, 0x56, 0x43, 0x4c, 0xfc, 0x28, 0xb4, 0xb0, 0x6a, 0xfb, 0x98, 0xa8, 0x9e, 0x4f,
+	0x55, 0xfc, 0x8d, 0x43, 0x68, 0x1d, 0x2d, 0x43, 0xee, 0xaf, 0x19, 0x25, 0x1f, 0x7e, 0x0e, 0xd5,
+	0xcb, 0x62, 0xa8, 0x2d, 0xdf, 0x8a, 0xd8, 0x05, 0xc1, 0xa3, 0xa3, 0xcd, 0xd9, 0xa5, 0xd1, 0x89,
+	0x43, 0xb1, 0x6e, 0xfb, 0x16, 0xd1, 0x7f, 0xa6, 0x4e, 0x98, 0x12, 0xfb, 0x0a, 0x5e, 0x0d, 0xf4,
+	0xe0, 0xfc, 0x6f, 0x99, 0x22, 0x8b, 0xcf, 0xc3, 0x9f, 0x2f, 0xf3, 0xaf, 0xf3, 0x47, 0xff, 0x1f,
+	0x00, 0x46, 0xf3, 0x92, 0x1e, 0x20, 0x12, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -627,15 +1480,52 @@ const _ = grpc.SupportPackageIsVersion4
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
 type BackendServiceClient interface {
-	// FetchMatches triggers a MatchFunction with the specified MatchProfile and returns a set of match proposals that
-	// match the description of that MatchProfile.
-	// FetchMatches immediately returns an error if it encounters any execution failures.
+	// FetchMatches triggers a MatchFunction with the specified MatchProfile and
+	// returns a set of matches generated by the Match Making Function, and
+	// accepted by the evaluator.
+	// Tickets in matches returned by FetchMatches are moved from active to
+	// pending, and will not be returned by query.
 	FetchMatches(ctx context.Context, in *FetchMatchesRequest, opts ...grpc.CallOption) (BackendService_FetchMatchesClient, error)
+	// GetMatch gets the record of a Match returned by FetchMatches.  Records
+	// expire after matchRecordTimeout, defaulting to pendingReleaseTimeout.
+	//
+	// BETA FEATURE WARNING:  This call and the associated Request and Response
+	// messages are not finalized and still subject to possible change or removal.
+	GetMatch(ctx context.Context, in *GetMatchRequest, opts ...grpc.CallOption) (*MatchRecord, error)
+	// ListMatches lists the records of Matches returned by FetchMatches which
+	// are still unassigned, so that a restarted director can resume them.
+	//
+	// BETA FEATURE WARNING:  This call and the associated Request and Response
+	// messages are not finalized and still subject to possible change or removal.
+	ListMatches(ctx context.Context, in *ListMatchesRequest, opts ...grpc.CallOption) (*ListMatchesResponse, error)
+	// AssignMatch sets the Assignment of the Tickets of a Match returned by
+	// FetchMatches.  It fails with NOT_FOUND if the match is unknown or has
+	// expired, and FAILED_PRECONDITION if it was already assigned or released.
+	// The Tickets and the match are assigned in one transaction.  Tickets which
+	// can't be assigned, such as deleted ones, are returned as failures while
+	// the others are still assigned.
+	//
+	// BETA FEATURE WARNING:  This call and the associated Request and Response
+	// messages are not finalized and still subject to possible change or removal.
+	AssignMatch(ctx context.Context, in *AssignMatchRequest, opts ...grpc.CallOption) (*AssignMatchResponse, error)
+	// ReleaseMatch moves all of the Tickets of a Match returned by FetchMatches
+	// from the pending state, to the active state.  It fails with NOT_FOUND if
+	// the match is unknown or has expired, and FAILED_PRECONDITION if it was
+	// already assigned or released.
+	//
+	// BETA FEATURE WARNING:  This call and the associated Request and Response
+	// messages are not finalized and still subject to possible change or removal.
+	ReleaseMatch(ctx context.Context, in *ReleaseMatchRequest, opts ...grpc.CallOption) (*ReleaseMatchResponse, error)
+	// UploadMatchFunctionModule stores a WebAssembly MatchFunction module which
+	// can then be run by FetchMatches with a FunctionConfig of type WASM.
+	//
+	// BETA FEATURE WARNING:  This call and the associated Request and Response
+	// messages are not finalized and still subject to possible change or removal.
+	UploadMatchFunctionModule(ctx context.Context, in *UploadMatchFunctionModuleRequest, opts ...grpc.CallOption) (*UploadMatchFunctionModuleResponse, error)
 	// AssignTickets overwrites the Assignment field of the input TicketIds.
 	AssignTickets(ctx context.Context, in *AssignTicketsRequest, opts ...grpc.CallOption) (*AssignTicketsResponse, error)
-	// ReleaseTickets removes the submitted tickets from the list that prevents tickets
-	// that are awaiting assignment from appearing in MMF queries, effectively putting them back into
-	// the matchmaking pool
+	// ReleaseTickets moves tickets from the pending state, to the active state.
+	// This enables them to be returned by query, and find different matches.
 	//
 	// BETA FEATURE WARNING:  This call and the associated Request and Response
 	// messages are not finalized and still subject to possible change or removal.
@@ -689,6 +1579,51 @@ func (x *backendServiceFetchMatchesClient) Recv() (*FetchMatchesResponse, error)
 	return m, nil
 }
 
+func (c *backendServiceClient) GetMatch(ctx context.Context, in *GetMatchRequest, opts ...grpc.CallOption) (*MatchRecord, error) {
+	out := new(MatchRecord)
+	err := c.cc.Invoke(ctx, "/openmatch.BackendService/GetMatch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backendServiceClient) ListMatches(ctx context.Context, in *ListMatchesRequest, opts ...grpc.CallOption) (*ListMatchesResponse, error) {
+	out := new(ListMatchesResponse)
+	err := c.cc.Invoke(ctx, "/openmatch.BackendService/ListMatches", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backendServiceClient) AssignMatch(ctx context.Context, in *AssignMatchRequest, opts ...grpc.CallOption) (*AssignMatchResponse, error) {
+	out := new(AssignMatchResponse)
+	err := c.cc.Invoke(ctx, "/openmatch.BackendService/AssignMatch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backendServiceClient) ReleaseMatch(ctx context.Context, in *ReleaseMatchRequest, opts ...grpc.CallOption) (*ReleaseMatchResponse, error) {
+	out := new(ReleaseMatchResponse)
+	err := c.cc.Invoke(ctx, "/openmatch.BackendService/ReleaseMatch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backendServiceClient) UploadMatchFunctionModule(ctx context.Context, in *UploadMatchFunctionModuleRequest, opts ...grpc.CallOption) (*UploadMatchFunctionModuleResponse, error) {
+	out := new(UploadMatchFunctionModuleResponse)
+	err := c.cc.Invoke(ctx, "/openmatch.BackendService/UploadMatchFunctionModule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *backendServiceClient) AssignTickets(ctx context.Context, in *AssignTicketsRequest, opts ...grpc.CallOption) (*AssignTicketsResponse, error) {
 	out := new(AssignTicketsResponse)
 	err := c.cc.Invoke(ctx, "/openmatch.BackendService/AssignTickets", in, out, opts...)
@@ -718,15 +1653,52 @@ func (c *backendServiceClient) ReleaseAllTickets(ctx context.Context, in *Releas
 
 // BackendServiceServer is the server API for BackendService service.
 type BackendServiceServer interface {
-	// FetchMatches triggers a MatchFunction with the specified MatchProfile and returns a set of match proposals that
-	// match the description of that MatchProfile.
-	// FetchMatches immediately returns an error if it encounters any execution failures.
+	// FetchMatches triggers a MatchFunction with the specified MatchProfile and
+	// returns a set of matches generated by the Match Making Function, and
+	// accepted by the evaluator.
+	// Tickets in matches returned by FetchMatches are moved from active to
+	// pending, and will not be returned by query.
 	FetchMatches(*FetchMatchesRequest, BackendService_FetchMatchesServer) error
+	// GetMatch gets the record of a Match returned by FetchMatches.  Records
+	// expire after matchRecordTimeout, defaulting to pendingReleaseTimeout.
+	//
+	// BETA FEATURE WARNING:  This call and the associated Request and Response
+	// messages are not finalized and still subject to possible change or removal.
+	GetMatch(context.Context, *GetMatchRequest) (*MatchRecord, error)
+	// ListMatches lists the records of Matches returned by FetchMatches which
+	// are still unassigned, so that a restarted director can resume them.
+	//
+	// BETA FEATURE WARNING:  This call and the associated Request and Response
+	// messages are not finalized and still subject to possible change or removal.
+	ListMatches(context.Context, *ListMatchesRequest) (*ListMatchesResponse, error)
+	// AssignMatch sets the Assignment of the Tickets of a Match returned by
+	// FetchMatches.  It fails with NOT_FOUND if the match is unknown or has
+	// expired, and FAILED_PRECONDITION if it was already assigned or released.
+	// The Tickets and the match are assigned in one transaction.  Tickets which
+	// can't be assigned, such as deleted ones, are returned as failures while
+	// the others are still assigned.
+	//
+	// BETA FEATURE WARNING:  This call and the associated Request and Response
+	// messages are not finalized and still subject to possible change or removal.
+	AssignMatch(context.Context, *AssignMatchRequest) (*AssignMatchResponse, error)
+	// ReleaseMatch moves all of the Tickets of a Match returned by FetchMatches
+	// from the pending state, to the active state.  It fails with NOT_FOUND if
+	// the match is unknown or has expired, and FAILED_PRECONDITION if it was
+	// already assigned or released.
+	//
+	// BETA FEATURE WARNING:  This call and the associated Request and Response
+	// messages are not finalized and still subject to possible change or removal.
+	ReleaseMatch(context.Context, *ReleaseMatchRequest) (*ReleaseMatchResponse, error)
+	// UploadMatchFunctionModule stores a WebAssembly MatchFunction module which
+	// can then be run by FetchMatches with a FunctionConfig of type WASM.
+	//
+	// BETA FEATURE WARNING:  This call and the associated Request and Response
+	// messages are not finalized and still subject to possible change or removal.
+	UploadMatchFunctionModule(context.Context, *UploadMatchFunctionModuleRequest) (*UploadMatchFunctionModuleResponse, error)
 	// AssignTickets overwrites the Assignment field of the input TicketIds.
 	AssignTickets(context.Context, *AssignTicketsRequest) (*AssignTicketsResponse, error)
-	// ReleaseTickets removes the submitted tickets from the list that prevents tickets
-	// that are awaiting assignment from appearing in MMF queries, effectively putting them back into
-	// the matchmaking pool
+	// ReleaseTickets moves tickets from the pending state, to the active state.
+	// This enables them to be returned by query, and find different matches.
 	//
 	// BETA FEATURE WARNING:  This call and the associated Request and Response
 	// messages are not finalized and still subject to possible change or removal.
@@ -747,6 +1719,21 @@ type UnimplementedBackendServiceServer struct {
 func (*UnimplementedBackendServiceServer) FetchMatches(req *FetchMatchesRequest, srv BackendService_FetchMatchesServer) error {
 	return status.Errorf(codes.Unimplemented, "method FetchMatches not implemented")
 }
+func (*UnimplementedBackendServiceServer) GetMatch(ctx context.Context, req *GetMatchRequest) (*MatchRecord, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMatch not implemented")
+}
+func (*UnimplementedBackendServiceServer) ListMatches(ctx context.Context, req *ListMatchesRequest) (*ListMatchesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMatches not implemented")
+}
+func (*UnimplementedBackendServiceServer) AssignMatch(ctx context.Context, req *AssignMatchRequest) (*AssignMatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AssignMatch not implemented")
+}
+func (*UnimplementedBackendServiceServer) ReleaseMatch(ctx context.Context, req *ReleaseMatchRequest) (*ReleaseMatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleaseMatch not implemented")
+}
+func (*UnimplementedBackendServiceServer) UploadMatchFunctionModule(ctx context.Context, req *UploadMatchFunctionModuleRequest) (*UploadMatchFunctionModuleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UploadMatchFunctionModule not implemented")
+}
 func (*UnimplementedBackendServiceServer) AssignTickets(ctx context.Context, req *AssignTicketsRequest) (*AssignTicketsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AssignTickets not implemented")
 }
@@ -782,6 +1769,96 @@ func (x *backendServiceFetchMatchesServer) Send(m *FetchMatchesResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _BackendService_GetMatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackendServiceServer).GetMatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/openmatch.BackendService/GetMatch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackendServiceServer).GetMatch(ctx, req.(*GetMatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BackendService_ListMatches_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMatchesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackendServiceServer).ListMatches(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/openmatch.BackendService/ListMatches",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackendServiceServer).ListMatches(ctx, req.(*ListMatchesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BackendService_AssignMatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AssignMatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackendServiceServer).AssignMatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/openmatch.BackendService/AssignMatch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackendServiceServer).AssignMatch(ctx, req.(*AssignMatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BackendService_ReleaseMatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseMatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackendServiceServer).ReleaseMatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/openmatch.BackendService/ReleaseMatch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackendServiceServer).ReleaseMatch(ctx, req.(*ReleaseMatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BackendService_UploadMatchFunctionModule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UploadMatchFunctionModuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackendServiceServer).UploadMatchFunctionModule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/openmatch.BackendService/UploadMatchFunctionModule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackendServiceServer).UploadMatchFunctionModule(ctx, req.(*UploadMatchFunctionModuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _BackendService_AssignTickets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(AssignTicketsRequest)
 	if err := dec(in); err != nil {
@@ -840,6 +1917,26 @@ var _BackendService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "openmatch.BackendService",
 	HandlerType: (*BackendServiceServer)(nil),
 	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetMatch",
+			Handler:    _BackendService_GetMatch_Handler,
+		},
+		{
+			MethodName: "ListMatches",
+			Handler:    _BackendService_ListMatches_Handler,
+		},
+		{
+			MethodName: "AssignMatch",
+			Handler:    _BackendService_AssignMatch_Handler,
+		},
+		{
+			MethodName: "ReleaseMatch",
+			Handler:    _BackendService_ReleaseMatch_Handler,
+		},
+		{
+			MethodName: "UploadMatchFunctionModule",
+			Handler:    _BackendService_UploadMatchFunctionModule_Handler,
+		},
 		{
 			MethodName: "AssignTickets",
 			Handler:    _BackendService_AssignTickets_Handler,
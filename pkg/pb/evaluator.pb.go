@@ -68,10 +68,14 @@ func (m *EvaluateRequest) GetMatch() *Match {
 
 type EvaluateResponse struct {
 	// A Match ID representing a shortlisted match returned by the evaluator as the final result.
-	MatchId              string   `protobuf:"bytes,2,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	MatchId string `protobuf:"bytes,2,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	// Optionally set instead of match_id to report a match which was not
+	// shortlisted, and why.  Matches which are neither accepted nor rejected are
+	// reported to FetchMatches callers as rejected with an UNKNOWN reason.
+	RejectedMatch        *RejectedMatch `protobuf:"bytes,3,opt,name=rejected_match,json=rejectedMatch,proto3" json:"rejected_match,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
 }
 
 func (m *EvaluateResponse) Reset()         { *m = EvaluateResponse{} }
@@ -106,6 +110,13 @@ func (m *EvaluateResponse) GetMatchId() string {
 	return ""
 }
 
+func (m *EvaluateResponse) GetRejectedMatch() *RejectedMatch {
+	if m != nil {
+		return m.RejectedMatch
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*EvaluateRequest)(nil), "openmatch.EvaluateRequest")
 	proto.RegisterType((*EvaluateResponse)(nil), "openmatch.EvaluateResponse")
@@ -114,38 +125,40 @@ func init() {
 func init() { proto.RegisterFile("api/evaluator.proto", fileDescriptor_8c58cb7dff9acb0f) }
 
 var fileDescriptor_8c58cb7dff9acb0f = []byte{
-	// 492 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x92, 0x31, 0x8f, 0xd3, 0x30,
-	0x14, 0xc7, 0x95, 0xf4, 0xb8, 0x6b, 0xcd, 0x40, 0x65, 0x24, 0x54, 0x0a, 0x42, 0xa6, 0x27, 0xa1,
-	0x52, 0xd1, 0xb8, 0xd7, 0xeb, 0x42, 0x11, 0xd2, 0x1d, 0xd0, 0xa1, 0xe8, 0x00, 0xa9, 0x48, 0x0c,
-	0x2c, 0xc8, 0x49, 0x1e, 0x49, 0xa0, 0xf1, 0x33, 0x7e, 0x4e, 0x8f, 0x0d, 0x89, 0x99, 0x09, 0x36,
-	0x3e, 0x02, 0x2b, 0x1f, 0x85, 0x8d, 0x99, 0x0f, 0x82, 0x92, 0x50, 0x5a, 0xdd, 0xdd, 0x92, 0xc8,
-	0xfe, 0x3d, 0xff, 0xff, 0xef, 0xfd, 0x6d, 0x76, 0x55, 0x99, 0x4c, 0xc2, 0x4a, 0x2d, 0x0b, 0xe5,
-	0xd0, 0x06, 0xc6, 0xa2, 0x43, 0xde, 0x42, 0x03, 0x3a, 0x57, 0x2e, 0x4a, 0xbb, 0xbc, 0xe4, 0x39,
-	0x10, 0xa9, 0x04, 0xa8, 0xc6, 0xdd, 0x9b, 0x09, 0x62, 0xb2, 0x04, 0x59, 0x22, 0xa5, 0x35, 0x3a,
-	0xe5, 0x32, 0xd4, 0x6b, 0x7a, 0xaf, 0xfa, 0x45, 0xc3, 0x04, 0xf4, 0x90, 0x4e, 0x55, 0x92, 0x80,
-	0x95, 0x68, 0xaa, 0x8a, 0xf3, 0xd5, 0xbd, 0xfb, 0xec, 0xca, 0xac, 0x76, 0x87, 0x05, 0x7c, 0x28,
-	0x80, 0x1c, 0xbf, 0xc3, 0x2e, 0x55, 0xde, 0x1d, 0x4f, 0x78, 0xfd, 0xcb, 0xe3, 0x76, 0xf0, 0xbf,
-	0x9b, 0xe0, 0x59, 0xf9, 0x5d, 0xd4, 0xb8, 0x77, 0xc8, 0xda, 0x9b, 0xa3, 0x64, 0x50, 0x13, 0xf0,
-	0xeb, 0xac, 0x59, 0xc1, 0x37, 0x59, 0xdc, 0xf1, 0x85, 0xd7, 0x6f, 0x2d, 0xf6, 0xaa, 0xf5, 0x3c,
-	0x7e, 0xba, 0xd3, 0xf4, 0xda, 0xfe, 0xf8, 0x13, 0x6b, 0xcd, 0xd6, 0xd3, 0x72, 0xcb, 0x9a, 0x6b,
-	0x05, 0xde, 0xdd, 0xb2, 0x39, 0xd3, 0x51, 0xf7, 0xc6, 0x85, 0xac, 0xb6, 0xec, 0xdd, 0xfd, 0xfc,
-	0xeb, 0xcf, 0x37, 0x7f, 0xbf, 0x77, 0x4b, 0xae, 0x0e, 0x36, 0x49, 0xca, 0xaa, 0x1a, 0x68, 0xfa,
-	0x6f, 0x07, 0xa6, 0xde, 0xa0, 0xef, 0x8d, 0xbc, 0x47, 0x5f, 0x1a, 0x5f, 0x8f, 0x7f, 0xfb, 0xfc,
-	0xa7, 0xb7, 0xd5, 0x48, 0x6f, 0xce, 0xd8, 0x0b, 0x03, 0x5a, 0x54, 0xf3, 0xf1, 0x6b, 0xa9, 0x73,
-	0x86, 0xa6, 0x52, 0x96, 0xae, 0xc3, 0xda, 0x36, 0x86, 0x55, 0x77, 0x7f, 0xb3, 0x1e, 0xc6, 0x19,
-	0x45, 0x05, 0xd1, 0x51, 0x7d, 0x15, 0x89, 0xc5, 0xc2, 0x50, 0x10, 0x61, 0x3e, 0x78, 0xc5, 0xf8,
-	0xb1, 0x51, 0x51, 0x0a, 0x62, 0x1c, 0x8c, 0xc4, 0x49, 0x16, 0x41, 0x99, 0xcb, 0xd1, 0x5a, 0x32,
-	0xc9, 0x5c, 0x5a, 0x84, 0x65, 0xa5, 0xac, 0x8f, 0xbe, 0x45, 0x9b, 0xa8, 0x1c, 0x68, 0xcb, 0x4c,
-	0x86, 0x4b, 0x0c, 0x65, 0xae, 0xc8, 0x81, 0x95, 0x27, 0xf3, 0xc7, 0xb3, 0xe7, 0x2f, 0x67, 0xe3,
-	0xc6, 0x41, 0x30, 0x1a, 0xf8, 0x9e, 0x3f, 0x6e, 0x2b, 0x63, 0x96, 0x59, 0x54, 0xdd, 0xa2, 0x7c,
-	0x47, 0xa8, 0xa7, 0xe7, 0x76, 0x16, 0x0f, 0x58, 0x63, 0x32, 0x9a, 0xf0, 0x09, 0x1b, 0x2c, 0xc0,
-	0x15, 0x56, 0x43, 0x2c, 0x4e, 0x53, 0xd0, 0xc2, 0xa5, 0x20, 0x2c, 0x10, 0x16, 0x36, 0x02, 0x11,
-	0x23, 0x90, 0xd0, 0xe8, 0x04, 0x7c, 0xcc, 0xc8, 0x05, 0x7c, 0x97, 0xed, 0x7c, 0xf7, 0xbd, 0x3d,
-	0xfb, 0x90, 0x75, 0x36, 0x61, 0x88, 0x27, 0x18, 0x15, 0x39, 0xe8, 0xfa, 0xd5, 0xf0, 0xdb, 0x17,
-	0x47, 0x23, 0x29, 0x73, 0x20, 0x63, 0x8c, 0x48, 0xbe, 0x16, 0x67, 0xd0, 0xd6, 0x5c, 0xe6, 0x7d,
-	0x22, 0x4d, 0xf8, 0xc3, 0x6f, 0x95, 0xfa, 0x95, 0x7c, 0xb8, 0x5b, 0x3d, 0xc3, 0xc3, 0xbf, 0x01,
-	0x00, 0x00, 0xff, 0xff, 0xf4, 0x55, 0x7c, 0x81, 0x08, 0x03, 0x00, 0x00,
+	// 514 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x52, 0x41, 0x6f, 0xd3, 0x30,
+	0x14, 0x56, 0xd2, 0xb1, 0xb5, 0x46, 0x40, 0x65, 0x24, 0x54, 0x0a, 0x42, 0xa6, 0x93, 0x50, 0xa9,
+	0x68, 0xdc, 0x95, 0x5e, 0x28, 0x42, 0x6c, 0x40, 0x0f, 0x45, 0x03, 0xa4, 0x20, 0x71, 0xe0, 0x32,
+	0xb9, 0xc9, 0x23, 0xc9, 0x68, 0x6c, 0xe3, 0xe7, 0x74, 0xdc, 0x90, 0x38, 0x73, 0x82, 0x1b, 0x3f,
+	0x81, 0x2b, 0x3f, 0x85, 0x1b, 0x67, 0x7e, 0x08, 0x8a, 0xd3, 0xae, 0xd5, 0xb6, 0x4b, 0xa2, 0xcf,
+	0xdf, 0xe7, 0xef, 0x7b, 0x7e, 0xef, 0x91, 0xeb, 0x42, 0x67, 0x1c, 0x16, 0x62, 0x5e, 0x08, 0xab,
+	0x4c, 0xa0, 0x8d, 0xb2, 0x8a, 0x36, 0x94, 0x06, 0x99, 0x0b, 0x1b, 0xa5, 0x6d, 0x5a, 0xf2, 0x39,
+	0x20, 0x8a, 0x04, 0xb0, 0xa2, 0xdb, 0xb7, 0x13, 0xa5, 0x92, 0x39, 0xf0, 0x92, 0x12, 0x52, 0x2a,
+	0x2b, 0x6c, 0xa6, 0xe4, 0x8a, 0x7d, 0xe0, 0x7e, 0x51, 0x3f, 0x01, 0xd9, 0xc7, 0x13, 0x91, 0x24,
+	0x60, 0xb8, 0xd2, 0x4e, 0x71, 0x5e, 0xdd, 0x79, 0x44, 0xae, 0x4d, 0xaa, 0x74, 0x08, 0xe1, 0x53,
+	0x01, 0x68, 0xe9, 0x3d, 0x72, 0xc9, 0x65, 0xb7, 0x3c, 0xe6, 0x75, 0x2f, 0x0f, 0x9b, 0xc1, 0x69,
+	0x35, 0xc1, 0xab, 0xf2, 0x1b, 0x56, 0x74, 0xc7, 0x92, 0xe6, 0xfa, 0x2a, 0x6a, 0x25, 0x11, 0xe8,
+	0x4d, 0x52, 0x77, 0xe4, 0x51, 0x16, 0xb7, 0x7c, 0xe6, 0x75, 0x1b, 0xe1, 0x8e, 0xc3, 0xd3, 0x98,
+	0x3e, 0x25, 0x57, 0x0d, 0x1c, 0x43, 0x64, 0x21, 0x3e, 0xaa, 0xfc, 0x6b, 0xce, 0xbf, 0xb5, 0xe1,
+	0x1f, 0x2e, 0x05, 0x55, 0xce, 0x15, 0xb3, 0x09, 0x5f, 0x6e, 0xd5, 0xbd, 0xa6, 0x3f, 0xfc, 0x42,
+	0x1a, 0x93, 0x55, 0xbb, 0xa8, 0x21, 0xf5, 0x25, 0x00, 0xda, 0xde, 0xf0, 0x39, 0xf3, 0xa4, 0xf6,
+	0xad, 0x0b, 0xb9, 0xaa, 0xe6, 0xce, 0xfd, 0xaf, 0x7f, 0xfe, 0xfd, 0xf0, 0x77, 0x3b, 0x77, 0xf8,
+	0x62, 0x6f, 0x3d, 0x0a, 0xee, 0xd4, 0x80, 0xe3, 0xe5, 0x09, 0x8c, 0xbd, 0x5e, 0xd7, 0x1b, 0x78,
+	0xcf, 0xbe, 0xd5, 0xbe, 0x1f, 0xfc, 0xf5, 0xe9, 0x6f, 0x6f, 0xa3, 0x90, 0xce, 0x94, 0x90, 0x37,
+	0x1a, 0x24, 0x73, 0x95, 0xd2, 0x1b, 0xa9, 0xb5, 0x1a, 0xc7, 0x9c, 0x97, 0xa9, 0xfd, 0x2a, 0x36,
+	0x86, 0x45, 0x7b, 0x77, 0x8d, 0xfb, 0x71, 0x86, 0x51, 0x81, 0xb8, 0x5f, 0xcd, 0x32, 0x31, 0xaa,
+	0xd0, 0x18, 0x44, 0x2a, 0xef, 0xbd, 0x23, 0xf4, 0x40, 0x8b, 0x28, 0x05, 0x36, 0x0c, 0x06, 0xec,
+	0x30, 0x8b, 0xa0, 0x6c, 0xec, 0xfe, 0xca, 0x32, 0xc9, 0x6c, 0x5a, 0xcc, 0x4a, 0x25, 0xaf, 0xae,
+	0x7e, 0x50, 0x26, 0x11, 0x39, 0xe0, 0x46, 0x18, 0x9f, 0xcd, 0xd5, 0x8c, 0xe7, 0x02, 0x2d, 0x18,
+	0x7e, 0x38, 0x7d, 0x3e, 0x79, 0xfd, 0x76, 0x32, 0xac, 0xed, 0x05, 0x83, 0x9e, 0xef, 0xf9, 0xc3,
+	0xa6, 0xd0, 0x7a, 0x9e, 0x45, 0x6e, 0x0d, 0xf8, 0x31, 0x2a, 0x39, 0x3e, 0x77, 0x12, 0x3e, 0x26,
+	0xb5, 0xd1, 0x60, 0x44, 0x47, 0xa4, 0x17, 0x82, 0x2d, 0x8c, 0x84, 0x98, 0x9d, 0xa4, 0x20, 0x99,
+	0x4d, 0x81, 0x19, 0x40, 0x55, 0x98, 0x08, 0x58, 0xac, 0x00, 0x99, 0x54, 0x96, 0xc1, 0xe7, 0x0c,
+	0x6d, 0x40, 0xb7, 0xc9, 0xd6, 0x4f, 0xdf, 0xdb, 0x31, 0x4f, 0x48, 0x6b, 0xdd, 0x0c, 0xf6, 0x42,
+	0x45, 0x45, 0x0e, 0xb2, 0x5a, 0x3b, 0x7a, 0xf7, 0xe2, 0xd6, 0x70, 0xcc, 0x2c, 0xf0, 0x58, 0x45,
+	0xc8, 0xdf, 0xb3, 0x33, 0xd4, 0x1a, 0x72, 0xfd, 0x31, 0xe1, 0x7a, 0xf6, 0xcb, 0x6f, 0x94, 0xfe,
+	0xce, 0x7e, 0xb6, 0xed, 0xf6, 0xf8, 0xe1, 0xff, 0x01, 0x00, 0x5a, 0xb7, 0x8c, 0xaf, 0x49, 0x03,
+	0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
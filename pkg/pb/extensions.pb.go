@@ -20,13 +20,63 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+// Strategy is how the default evaluator chooses between matches which share
+// tickets.
+type DefaultEvaluationCriteria_Strategy int32
+
+const (
+	// Use the strategy configured for the evaluator, which is GREEDY unless
+	// defaultEvaluator.strategy is set.
+	DefaultEvaluationCriteria_DEFAULT DefaultEvaluationCriteria_Strategy = 0
+	// Accept matches in order of descending score, rejecting any match which
+	// collides with an already accepted match.
+	DefaultEvaluationCriteria_GREEDY DefaultEvaluationCriteria_Strategy = 1
+	// Accept the set of matches with the highest total score.  This is exact
+	// for small groups of colliding matches, and a bounded local search for
+	// large groups.
+	DefaultEvaluationCriteria_OPTIMAL DefaultEvaluationCriteria_Strategy = 2
+)
+
+var DefaultEvaluationCriteria_Strategy_name = map[int32]string{
+	0: "DEFAULT",
+	1: "GREEDY",
+	2: "OPTIMAL",
+}
+
+var DefaultEvaluationCriteria_Strategy_value = map[string]int32{
+	"DEFAULT": 0,
+	"GREEDY":  1,
+	"OPTIMAL": 2,
+}
+
+func (x DefaultEvaluationCriteria_Strategy) String() string {
+	return proto.EnumName(DefaultEvaluationCriteria_Strategy_name, int32(x))
+}
+
+func (DefaultEvaluationCriteria_Strategy) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_09e3e066475ff045, []int{0, 0}
+}
+
 // A DefaultEvaluationCriteria is used for a match's evaluation_input when using
 // the default evaluator.
 type DefaultEvaluationCriteria struct {
-	Score                float64  `protobuf:"fixed64,1,opt,name=score,proto3" json:"score,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Score float64 `protobuf:"fixed64,1,opt,name=score,proto3" json:"score,omitempty"`
+	// Requested strategy.  If matches evaluated together request different
+	// strategies, OPTIMAL takes precedence over GREEDY.
+	Strategy DefaultEvaluationCriteria_Strategy `protobuf:"varint,2,opt,name=strategy,proto3,enum=openmatch.DefaultEvaluationCriteria_Strategy" json:"strategy,omitempty"`
+	// Secondary keys, compared in order between matches with equal scores.
+	// Higher values are preferred, and a match missing a key sorts after matches
+	// which have it.  Remaining ties are broken by preferring the match whose
+	// oldest ticket has waited longest, then the match with more tickets.
+	//
+	// When defaultEvaluator.waitTimeBoost is set, the default evaluator adds
+	// that much to the score for every second the match's oldest ticket has
+	// waited, so that long waiting tickets are not starved by consistently
+	// higher scoring matches.
+	TieBreakers          []float64 `protobuf:"fixed64,3,rep,packed,name=tie_breakers,json=tieBreakers,proto3" json:"tie_breakers,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
 }
 
 func (m *DefaultEvaluationCriteria) Reset()         { *m = DefaultEvaluationCriteria{} }
@@ -61,21 +111,43 @@ func (m *DefaultEvaluationCriteria) GetScore() float64 {
 	return 0
 }
 
+func (m *DefaultEvaluationCriteria) GetStrategy() DefaultEvaluationCriteria_Strategy {
+	if m != nil {
+		return m.Strategy
+	}
+	return DefaultEvaluationCriteria_DEFAULT
+}
+
+func (m *DefaultEvaluationCriteria) GetTieBreakers() []float64 {
+	if m != nil {
+		return m.TieBreakers
+	}
+	return nil
+}
+
 func init() {
+	proto.RegisterEnum("openmatch.DefaultEvaluationCriteria_Strategy", DefaultEvaluationCriteria_Strategy_name, DefaultEvaluationCriteria_Strategy_value)
 	proto.RegisterType((*DefaultEvaluationCriteria)(nil), "openmatch.DefaultEvaluationCriteria")
 }
 
 func init() { proto.RegisterFile("api/extensions.proto", fileDescriptor_09e3e066475ff045) }
 
 var fileDescriptor_09e3e066475ff045 = []byte{
-	// 142 bytes of a gzipped FileDescriptorProto
+	// 245 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x12, 0x49, 0x2c, 0xc8, 0xd4,
 	0x4f, 0xad, 0x28, 0x49, 0xcd, 0x2b, 0xce, 0xcc, 0xcf, 0x2b, 0xd6, 0x2b, 0x28, 0xca, 0x2f, 0xc9,
-	0x17, 0xe2, 0xcc, 0x2f, 0x48, 0xcd, 0xcb, 0x4d, 0x2c, 0x49, 0xce, 0x50, 0x32, 0xe4, 0x92, 0x74,
-	0x49, 0x4d, 0x4b, 0x2c, 0xcd, 0x29, 0x71, 0x2d, 0x4b, 0xcc, 0x29, 0x4d, 0x2c, 0xc9, 0xcc, 0xcf,
-	0x73, 0x2e, 0xca, 0x2c, 0x49, 0x2d, 0xca, 0x4c, 0x14, 0x12, 0xe1, 0x62, 0x2d, 0x4e, 0xce, 0x2f,
-	0x4a, 0x95, 0x60, 0x54, 0x60, 0xd4, 0x60, 0x0c, 0x82, 0x70, 0x9c, 0xf4, 0xa2, 0x14, 0x40, 0xfa,
-	0x75, 0xc1, 0x06, 0xe8, 0xa5, 0xa4, 0x96, 0xe9, 0x23, 0xb8, 0xfa, 0x05, 0xd9, 0xe9, 0xfa, 0x05,
-	0x49, 0xab, 0x98, 0x38, 0xfd, 0x0b, 0x52, 0xf3, 0x7c, 0x41, 0x42, 0x49, 0x6c, 0x60, 0x4b, 0x8d,
-	0x01, 0x01, 0x00, 0x00, 0xff, 0xff, 0xb6, 0x69, 0xf6, 0x37, 0x8c, 0x00, 0x00, 0x00,
+	0x17, 0xe2, 0xcc, 0x2f, 0x48, 0xcd, 0xcb, 0x4d, 0x2c, 0x49, 0xce, 0x50, 0xba, 0xc8, 0xc8, 0x25,
+	0xe9, 0x92, 0x9a, 0x96, 0x58, 0x9a, 0x53, 0xe2, 0x5a, 0x96, 0x98, 0x53, 0x9a, 0x58, 0x92, 0x99,
+	0x9f, 0xe7, 0x5c, 0x94, 0x59, 0x92, 0x5a, 0x94, 0x99, 0x28, 0x24, 0xc2, 0xc5, 0x5a, 0x9c, 0x9c,
+	0x5f, 0x94, 0x2a, 0xc1, 0xa8, 0xc0, 0xa8, 0xc1, 0x18, 0x04, 0xe1, 0x08, 0x79, 0x72, 0x71, 0x14,
+	0x97, 0x14, 0x25, 0x96, 0xa4, 0xa6, 0x57, 0x4a, 0x30, 0x29, 0x30, 0x6a, 0xf0, 0x19, 0xe9, 0xea,
+	0xc1, 0x4d, 0xd4, 0xc3, 0x69, 0x9a, 0x5e, 0x30, 0x54, 0x53, 0x10, 0x5c, 0xbb, 0x90, 0x22, 0x17,
+	0x4f, 0x49, 0x66, 0x6a, 0x7c, 0x52, 0x51, 0x6a, 0x62, 0x76, 0x6a, 0x51, 0xb1, 0x04, 0xb3, 0x02,
+	0xb3, 0x06, 0x63, 0x10, 0x77, 0x49, 0x66, 0xaa, 0x13, 0x54, 0x48, 0xc9, 0x80, 0x8b, 0x03, 0xa6,
+	0x51, 0x88, 0x9b, 0x8b, 0xdd, 0xc5, 0xd5, 0xcd, 0x31, 0xd4, 0x27, 0x44, 0x80, 0x41, 0x88, 0x8b,
+	0x8b, 0xcd, 0x3d, 0xc8, 0xd5, 0xd5, 0x25, 0x52, 0x80, 0x11, 0x24, 0xe1, 0x1f, 0x10, 0xe2, 0xe9,
+	0xeb, 0xe8, 0x23, 0xc0, 0xe4, 0xa4, 0x17, 0xa5, 0x00, 0x72, 0x8e, 0x2e, 0xc4, 0x3d, 0x29, 0xa9,
+	0x65, 0xfa, 0x08, 0xae, 0x7e, 0x41, 0x76, 0xba, 0x7e, 0x41, 0xd2, 0x2a, 0x26, 0x4e, 0xff, 0x82,
+	0xd4, 0x3c, 0x5f, 0x90, 0x50, 0x12, 0x1b, 0x38, 0x54, 0x8c, 0x01, 0x03, 0x00, 0xe0, 0xa0, 0xc6,
+	0x55, 0x2d, 0x01, 0x00, 0x00,
 }
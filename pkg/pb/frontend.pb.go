@@ -227,59 +227,230 @@ func (m *WatchAssignmentsResponse) GetAssignment() *Assignment {
 	return nil
 }
 
+type AcceptMatchRequest struct {
+	// A TicketId of a Ticket in a match awaiting its ready check.
+	TicketId             string   `protobuf:"bytes,1,opt,name=ticket_id,json=ticketId,proto3" json:"ticket_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AcceptMatchRequest) Reset()         { *m = AcceptMatchRequest{} }
+func (m *AcceptMatchRequest) String() string { return proto.CompactTextString(m) }
+func (*AcceptMatchRequest) ProtoMessage()    {}
+func (*AcceptMatchRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_06c902cf58d2ae57, []int{5}
+}
+
+func (m *AcceptMatchRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AcceptMatchRequest.Unmarshal(m, b)
+}
+func (m *AcceptMatchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AcceptMatchRequest.Marshal(b, m, deterministic)
+}
+func (m *AcceptMatchRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AcceptMatchRequest.Merge(m, src)
+}
+func (m *AcceptMatchRequest) XXX_Size() int {
+	return xxx_messageInfo_AcceptMatchRequest.Size(m)
+}
+func (m *AcceptMatchRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AcceptMatchRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AcceptMatchRequest proto.InternalMessageInfo
+
+func (m *AcceptMatchRequest) GetTicketId() string {
+	if m != nil {
+		return m.TicketId
+	}
+	return ""
+}
+
+type AcceptMatchResponse struct {
+	// The match the player accepted.
+	MatchId              string   `protobuf:"bytes,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AcceptMatchResponse) Reset()         { *m = AcceptMatchResponse{} }
+func (m *AcceptMatchResponse) String() string { return proto.CompactTextString(m) }
+func (*AcceptMatchResponse) ProtoMessage()    {}
+func (*AcceptMatchResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_06c902cf58d2ae57, []int{6}
+}
+
+func (m *AcceptMatchResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AcceptMatchResponse.Unmarshal(m, b)
+}
+func (m *AcceptMatchResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AcceptMatchResponse.Marshal(b, m, deterministic)
+}
+func (m *AcceptMatchResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AcceptMatchResponse.Merge(m, src)
+}
+func (m *AcceptMatchResponse) XXX_Size() int {
+	return xxx_messageInfo_AcceptMatchResponse.Size(m)
+}
+func (m *AcceptMatchResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AcceptMatchResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AcceptMatchResponse proto.InternalMessageInfo
+
+func (m *AcceptMatchResponse) GetMatchId() string {
+	if m != nil {
+		return m.MatchId
+	}
+	return ""
+}
+
+type DeclineMatchRequest struct {
+	// A TicketId of a Ticket in a match awaiting its ready check.
+	TicketId             string   `protobuf:"bytes,1,opt,name=ticket_id,json=ticketId,proto3" json:"ticket_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeclineMatchRequest) Reset()         { *m = DeclineMatchRequest{} }
+func (m *DeclineMatchRequest) String() string { return proto.CompactTextString(m) }
+func (*DeclineMatchRequest) ProtoMessage()    {}
+func (*DeclineMatchRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_06c902cf58d2ae57, []int{7}
+}
+
+func (m *DeclineMatchRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeclineMatchRequest.Unmarshal(m, b)
+}
+func (m *DeclineMatchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeclineMatchRequest.Marshal(b, m, deterministic)
+}
+func (m *DeclineMatchRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeclineMatchRequest.Merge(m, src)
+}
+func (m *DeclineMatchRequest) XXX_Size() int {
+	return xxx_messageInfo_DeclineMatchRequest.Size(m)
+}
+func (m *DeclineMatchRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeclineMatchRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeclineMatchRequest proto.InternalMessageInfo
+
+func (m *DeclineMatchRequest) GetTicketId() string {
+	if m != nil {
+		return m.TicketId
+	}
+	return ""
+}
+
+type DeclineMatchResponse struct {
+	// The match the player declined.
+	MatchId              string   `protobuf:"bytes,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeclineMatchResponse) Reset()         { *m = DeclineMatchResponse{} }
+func (m *DeclineMatchResponse) String() string { return proto.CompactTextString(m) }
+func (*DeclineMatchResponse) ProtoMessage()    {}
+func (*DeclineMatchResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_06c902cf58d2ae57, []int{8}
+}
+
+func (m *DeclineMatchResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeclineMatchResponse.Unmarshal(m, b)
+}
+func (m *DeclineMatchResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeclineMatchResponse.Marshal(b, m, deterministic)
+}
+func (m *DeclineMatchResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeclineMatchResponse.Merge(m, src)
+}
+func (m *DeclineMatchResponse) XXX_Size() int {
+	return xxx_messageInfo_DeclineMatchResponse.Size(m)
+}
+func (m *DeclineMatchResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeclineMatchResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeclineMatchResponse proto.InternalMessageInfo
+
+func (m *DeclineMatchResponse) GetMatchId() string {
+	if m != nil {
+		return m.MatchId
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*CreateTicketRequest)(nil), "openmatch.CreateTicketRequest")
 	proto.RegisterType((*DeleteTicketRequest)(nil), "openmatch.DeleteTicketRequest")
 	proto.RegisterType((*GetTicketRequest)(nil), "openmatch.GetTicketRequest")
 	proto.RegisterType((*WatchAssignmentsRequest)(nil), "openmatch.WatchAssignmentsRequest")
 	proto.RegisterType((*WatchAssignmentsResponse)(nil), "openmatch.WatchAssignmentsResponse")
+	proto.RegisterType((*AcceptMatchRequest)(nil), "openmatch.AcceptMatchRequest")
+	proto.RegisterType((*AcceptMatchResponse)(nil), "openmatch.AcceptMatchResponse")
+	proto.RegisterType((*DeclineMatchRequest)(nil), "openmatch.DeclineMatchRequest")
+	proto.RegisterType((*DeclineMatchResponse)(nil), "openmatch.DeclineMatchResponse")
 }
 
 func init() { proto.RegisterFile("api/frontend.proto", fileDescriptor_06c902cf58d2ae57) }
 
 var fileDescriptor_06c902cf58d2ae57 = []byte{
-	// 643 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x54, 0x5f, 0x4f, 0x13, 0x4f,
-	0x14, 0xcd, 0x16, 0xc2, 0x8f, 0xce, 0x8f, 0x44, 0x1c, 0x22, 0x92, 0xd6, 0x98, 0x71, 0x49, 0x54,
-	0x1a, 0xbb, 0x53, 0x0a, 0xf8, 0x00, 0x31, 0x01, 0x01, 0x0d, 0x09, 0x6a, 0x2c, 0x46, 0x13, 0x5f,
-	0xcc, 0x76, 0xf7, 0xb2, 0x1d, 0xe9, 0xce, 0x8c, 0x7b, 0x67, 0x41, 0x63, 0x4c, 0x8c, 0xaf, 0xbe,
-	0xe9, 0x1b, 0x1f, 0xc1, 0x47, 0xbf, 0x8a, 0x4f, 0xbe, 0xfb, 0x41, 0xcc, 0xce, 0xf6, 0xcf, 0x5a,
-	0x2a, 0xc1, 0xa7, 0x66, 0xe7, 0x9e, 0x7b, 0xce, 0xbd, 0xe7, 0xdc, 0x94, 0x50, 0x5f, 0x0b, 0x7e,
-	0x98, 0x28, 0x69, 0x40, 0x86, 0x9e, 0x4e, 0x94, 0x51, 0xb4, 0xac, 0x34, 0xc8, 0xd8, 0x37, 0x41,
-	0xa7, 0x62, 0xcb, 0x31, 0x20, 0xfa, 0x11, 0x60, 0x5e, 0xae, 0x5c, 0x8b, 0x94, 0x8a, 0xba, 0xc0,
-	0xb3, 0x92, 0x2f, 0xa5, 0x32, 0xbe, 0x11, 0x4a, 0xf6, 0xab, 0x77, 0xec, 0x4f, 0x50, 0x8f, 0x40,
-	0xd6, 0xf1, 0xc4, 0x8f, 0x22, 0x48, 0xb8, 0xd2, 0x16, 0x31, 0x06, 0x5d, 0xed, 0x71, 0xd9, 0xaf,
-	0x76, 0x7a, 0xc8, 0x21, 0xd6, 0xe6, 0x5d, 0x5e, 0x74, 0x37, 0xc9, 0xdc, 0x76, 0x02, 0xbe, 0x81,
-	0x67, 0x22, 0x38, 0x02, 0xd3, 0x82, 0x37, 0x29, 0xa0, 0xa1, 0x4b, 0x64, 0xca, 0xd8, 0x87, 0x05,
-	0x87, 0x39, 0xb7, 0xff, 0x6f, 0x5e, 0xf6, 0x06, 0xf3, 0x7a, 0x3d, 0x64, 0x0f, 0xe0, 0x36, 0xc9,
-	0xdc, 0x0e, 0x74, 0x61, 0x94, 0xa1, 0x4a, 0xca, 0x39, 0xe0, 0x95, 0x08, 0x2d, 0x49, 0xb9, 0x35,
-	0x9d, 0x3f, 0xec, 0x85, 0x2e, 0x27, 0xb3, 0x0f, 0xc1, 0xfc, 0x43, 0xc3, 0x5d, 0x72, 0xf5, 0x45,
-	0x26, 0xbe, 0x85, 0x28, 0x22, 0x19, 0x83, 0x34, 0x78, 0xa1, 0xbe, 0xa7, 0x64, 0xe1, 0x6c, 0x1f,
-	0x6a, 0x25, 0x11, 0xe8, 0x1a, 0x21, 0xfe, 0xe0, 0xb9, 0xb7, 0xe7, 0x95, 0xc2, 0x9e, 0xc3, 0x9e,
-	0x56, 0x01, 0xd8, 0xfc, 0x38, 0x49, 0x2e, 0x3d, 0xe8, 0x85, 0x79, 0x00, 0xc9, 0xb1, 0x08, 0x80,
-	0x0a, 0x32, 0x53, 0x74, 0x91, 0x5e, 0x2f, 0xd0, 0x8c, 0xb1, 0xb7, 0x72, 0xd6, 0x4e, 0xf7, 0xe6,
-	0xa7, 0x1f, 0xbf, 0xbe, 0x96, 0x98, 0x5b, 0xe5, 0xc7, 0xcb, 0x83, 0x63, 0xc1, 0x9c, 0x9f, 0xe7,
-	0xfb, 0xe0, 0xba, 0x53, 0xa3, 0x27, 0x64, 0xa6, 0x68, 0xf7, 0x1f, 0x52, 0x63, 0x72, 0xa8, 0xcc,
-	0x7b, 0x79, 0xfc, 0x5e, 0x3f, 0x7e, 0x6f, 0x37, 0x8b, 0xdf, 0xe5, 0x56, 0x6f, 0xa9, 0x76, 0xeb,
-	0x1c, 0x3d, 0xfe, 0x7e, 0xe0, 0xec, 0x07, 0xda, 0x25, 0xe5, 0x41, 0x66, 0xb4, 0x5a, 0x50, 0x1d,
-	0x4d, 0x72, 0xdc, 0x76, 0x3d, 0x35, 0x7a, 0x61, 0xb5, 0x53, 0x87, 0xcc, 0x8e, 0x26, 0x47, 0xdd,
-	0x02, 0xf1, 0x5f, 0xce, 0xa1, 0xb2, 0x78, 0x2e, 0x26, 0x8f, 0xde, 0xdd, 0xb0, 0xe3, 0xac, 0xd1,
-	0x95, 0x0b, 0x8e, 0xc3, 0x87, 0xf9, 0x63, 0xc3, 0xb9, 0xff, 0x79, 0xe2, 0xcb, 0xd6, 0xcf, 0x12,
-	0xfd, 0xee, 0x90, 0xe9, 0xfe, 0x25, 0xb8, 0x7b, 0x84, 0x3c, 0xd1, 0x20, 0xd9, 0xa3, 0x4c, 0x93,
-	0xce, 0x77, 0x8c, 0xd1, 0xb8, 0xce, 0x79, 0x36, 0x4c, 0x3d, 0x9f, 0x26, 0x84, 0xe3, 0xca, 0xe2,
-	0xf0, 0xbb, 0x1e, 0x0a, 0x0c, 0x52, 0xc4, 0xcd, 0x3c, 0x9f, 0x28, 0x51, 0xa9, 0x46, 0x2f, 0x50,
-	0x71, 0xed, 0x39, 0xa1, 0x5b, 0xda, 0x0f, 0x3a, 0xc0, 0x9a, 0x5e, 0x83, 0xed, 0x8b, 0x00, 0xb2,
-	0x73, 0xdd, 0xec, 0x53, 0x46, 0xc2, 0x74, 0xd2, 0x76, 0x86, 0xe4, 0x79, 0xeb, 0xa1, 0x4a, 0x22,
-	0x3f, 0x06, 0x2c, 0x88, 0xf1, 0x76, 0x57, 0xb5, 0x79, 0xec, 0xa3, 0x81, 0x84, 0xef, 0xef, 0x6d,
-	0xef, 0x3e, 0x3e, 0xd8, 0x6d, 0x4e, 0x2c, 0x7b, 0x8d, 0x5a, 0xc9, 0x29, 0x35, 0x67, 0x7d, 0xad,
-	0xbb, 0x22, 0xb0, 0xff, 0x12, 0xfc, 0x35, 0x2a, 0xb9, 0x7e, 0xe6, 0xa5, 0xb5, 0x41, 0x26, 0x56,
-	0x1b, 0xab, 0x74, 0x95, 0xd4, 0x5a, 0x60, 0xd2, 0x44, 0x42, 0xc8, 0x4e, 0x3a, 0x20, 0x99, 0xe9,
-	0x00, 0x4b, 0x00, 0x55, 0x9a, 0x04, 0xc0, 0x42, 0x05, 0xc8, 0xa4, 0x32, 0x0c, 0xde, 0x0a, 0x34,
-	0x1e, 0x9d, 0x22, 0x93, 0xa7, 0x25, 0xe7, 0xbf, 0xe4, 0x1e, 0x59, 0x18, 0x9a, 0xc1, 0x76, 0x54,
-	0x90, 0x66, 0xd6, 0x59, 0x76, 0x7a, 0x63, 0xbc, 0x35, 0x1c, 0x85, 0x01, 0x1e, 0xaa, 0x00, 0xf9,
-	0x4b, 0x36, 0x52, 0x2a, 0xec, 0xa5, 0x8f, 0x22, 0xae, 0xdb, 0xdf, 0x4a, 0xe5, 0x8c, 0xdf, 0xd2,
-	0xb7, 0xa7, 0xec, 0x65, 0xaf, 0xfc, 0x0e, 0x00, 0x00, 0xff, 0xff, 0x05, 0x01, 0x35, 0x43, 0x67,
-	0x05, 0x00, 0x00,
+	// 755 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x95, 0xc1, 0x6f, 0xd3, 0x48,
+	0x14, 0xc6, 0xe5, 0x74, 0x95, 0x36, 0xd3, 0x4a, 0xdb, 0x9d, 0xee, 0x76, 0xbb, 0xce, 0x6e, 0xd7,
+	0xb8, 0x12, 0xd0, 0x88, 0x78, 0x92, 0x34, 0x45, 0x22, 0x15, 0x22, 0xa1, 0x2d, 0x28, 0x52, 0x01,
+	0x91, 0x22, 0x90, 0xb8, 0x20, 0xc7, 0x7e, 0x75, 0x4c, 0x93, 0x99, 0xc1, 0x33, 0x6e, 0x41, 0x88,
+	0x0b, 0x57, 0xc4, 0x01, 0xb8, 0xf5, 0xcc, 0x89, 0x23, 0xff, 0x0a, 0x27, 0xee, 0xfc, 0x21, 0xc8,
+	0x63, 0x27, 0x71, 0x93, 0xb4, 0xa4, 0xa7, 0x68, 0xe6, 0xbd, 0xf7, 0x7d, 0xdf, 0x4c, 0x7e, 0x23,
+	0x23, 0x6c, 0x73, 0x9f, 0x1c, 0x04, 0x8c, 0x4a, 0xa0, 0xae, 0xc5, 0x03, 0x26, 0x19, 0xce, 0x31,
+	0x0e, 0xb4, 0x67, 0x4b, 0xa7, 0xa3, 0xab, 0x72, 0x0f, 0x84, 0xb0, 0x3d, 0x10, 0x71, 0x59, 0xff,
+	0xd7, 0x63, 0xcc, 0xeb, 0x02, 0x89, 0x4a, 0x36, 0xa5, 0x4c, 0xda, 0xd2, 0x67, 0xb4, 0x5f, 0xbd,
+	0xa6, 0x7e, 0x9c, 0xa2, 0x07, 0xb4, 0x28, 0x8e, 0x6d, 0xcf, 0x83, 0x80, 0x30, 0xae, 0x3a, 0x26,
+	0x74, 0xe7, 0x13, 0x2d, 0xb5, 0x6a, 0x87, 0x07, 0x04, 0x7a, 0x5c, 0xbe, 0x8a, 0x8b, 0x66, 0x1d,
+	0x2d, 0x6d, 0x07, 0x60, 0x4b, 0x78, 0xe4, 0x3b, 0x87, 0x20, 0x5b, 0xf0, 0x22, 0x04, 0x21, 0xf1,
+	0x3a, 0xca, 0x4a, 0xb5, 0xb1, 0xa2, 0x19, 0xda, 0xd5, 0xf9, 0xca, 0x1f, 0xd6, 0x20, 0xaf, 0x95,
+	0x74, 0x26, 0x0d, 0x66, 0x05, 0x2d, 0xed, 0x40, 0x17, 0x46, 0x15, 0xf2, 0x28, 0x17, 0x37, 0x3c,
+	0xf3, 0x5d, 0x25, 0x92, 0x6b, 0xcd, 0xc5, 0x1b, 0x4d, 0xd7, 0x24, 0x68, 0xf1, 0x2e, 0xc8, 0x0b,
+	0x0c, 0x5c, 0x47, 0x7f, 0x3f, 0x89, 0xcc, 0x1b, 0x42, 0xf8, 0x1e, 0xed, 0x01, 0x95, 0x62, 0xaa,
+	0xb9, 0x87, 0x68, 0x65, 0x7c, 0x4e, 0x70, 0x46, 0x05, 0xe0, 0x4d, 0x84, 0xec, 0xc1, 0x76, 0x72,
+	0xce, 0xbf, 0x52, 0xe7, 0x1c, 0xce, 0xb4, 0x52, 0x8d, 0x66, 0x19, 0xe1, 0x86, 0xe3, 0x00, 0x97,
+	0xf7, 0xa2, 0xae, 0xa9, 0x52, 0x94, 0xd0, 0xd2, 0xa9, 0x91, 0x24, 0xc0, 0x3f, 0x68, 0x4e, 0x39,
+	0x0d, 0x47, 0x66, 0xd5, 0xba, 0xe9, 0xc6, 0x97, 0xea, 0x74, 0x7d, 0x0a, 0xd3, 0xbb, 0x94, 0xd1,
+	0x9f, 0xa7, 0x67, 0x7e, 0x69, 0x53, 0xf9, 0x9c, 0x45, 0xbf, 0xdf, 0x49, 0xc0, 0xdc, 0x87, 0xe0,
+	0xc8, 0x77, 0x00, 0xfb, 0x68, 0x21, 0x4d, 0x04, 0x5e, 0x4d, 0x5d, 0xc9, 0x04, 0x54, 0xf4, 0x71,
+	0x34, 0xcc, 0xcb, 0x6f, 0xbf, 0xfd, 0xf8, 0x94, 0x31, 0xcc, 0x3c, 0x39, 0x2a, 0x0f, 0xc0, 0x17,
+	0xb1, 0x3e, 0x89, 0xf3, 0x8a, 0x9a, 0x56, 0xc0, 0xc7, 0x68, 0x21, 0x8d, 0xce, 0x29, 0xab, 0x09,
+	0x4c, 0xe9, 0xcb, 0x56, 0x8c, 0xb2, 0xd5, 0x47, 0xd9, 0xda, 0x8d, 0x50, 0x36, 0x89, 0xf2, 0x5b,
+	0x2f, 0x5c, 0x39, 0xc7, 0x8f, 0xbc, 0x1e, 0xdc, 0xdc, 0x1b, 0xdc, 0x45, 0xb9, 0x01, 0x7f, 0x38,
+	0x9f, 0x72, 0x1d, 0xa5, 0x72, 0xd2, 0xe9, 0x12, 0x37, 0x3c, 0xb5, 0xdb, 0x89, 0x86, 0x16, 0x47,
+	0x29, 0xc4, 0x66, 0x4a, 0xf8, 0x0c, 0xb4, 0xf5, 0xb5, 0x73, 0x7b, 0xe2, 0xbf, 0xd7, 0xdc, 0x52,
+	0x71, 0x36, 0xf1, 0xc6, 0x94, 0x71, 0xc8, 0x90, 0x65, 0x51, 0xd2, 0xf0, 0x7b, 0x0d, 0xcd, 0xa7,
+	0xe0, 0xc4, 0xff, 0xa5, 0x5f, 0xc0, 0x18, 0xe7, 0xfa, 0xea, 0x59, 0xe5, 0x24, 0xcd, 0x2d, 0x95,
+	0xe6, 0x86, 0x59, 0x9d, 0x36, 0x8d, 0x92, 0xaa, 0xd9, 0x4a, 0x2a, 0x62, 0xe2, 0x83, 0x86, 0x16,
+	0xd2, 0x18, 0x8f, 0x40, 0x31, 0xf6, 0x26, 0xf4, 0xff, 0xcf, 0xac, 0x27, 0x91, 0xea, 0x2a, 0x52,
+	0xcd, 0xdc, 0xbc, 0x58, 0x24, 0x37, 0xd6, 0xaa, 0x69, 0x85, 0xdb, 0xef, 0x66, 0x3e, 0x36, 0xbe,
+	0x67, 0xf0, 0x57, 0x0d, 0xcd, 0xf5, 0x5f, 0x8b, 0xd9, 0x44, 0xe8, 0x01, 0x07, 0x6a, 0xc4, 0x21,
+	0x97, 0x3b, 0x52, 0x72, 0x51, 0x23, 0x24, 0xca, 0x52, 0x8c, 0xc3, 0xb8, 0x70, 0xa4, 0xaf, 0x0d,
+	0xd7, 0x45, 0xd7, 0x17, 0x4e, 0x28, 0x44, 0x3d, 0x66, 0xd8, 0x0b, 0x58, 0xc8, 0x85, 0xe5, 0xb0,
+	0x5e, 0xe1, 0x31, 0xc2, 0x0d, 0x6e, 0x3b, 0x1d, 0x30, 0x2a, 0x56, 0xc9, 0xd8, 0xf3, 0x1d, 0x88,
+	0x9e, 0x6d, 0xbd, 0x2f, 0xe9, 0xf9, 0xb2, 0x13, 0xb6, 0xa3, 0x4e, 0x12, 0x8f, 0x1e, 0xb0, 0xc0,
+	0xb3, 0x7b, 0x20, 0x52, 0x66, 0xa4, 0xdd, 0x65, 0x6d, 0xd2, 0xb3, 0x85, 0x84, 0x80, 0xec, 0x35,
+	0xb7, 0x77, 0xef, 0xef, 0xef, 0x56, 0x66, 0xca, 0x56, 0xa9, 0x90, 0xd1, 0x32, 0x95, 0x45, 0x9b,
+	0xf3, 0xae, 0xef, 0xa8, 0xaf, 0x02, 0x79, 0x2e, 0x18, 0xad, 0x8d, 0xed, 0xb4, 0xb6, 0xd0, 0x4c,
+	0xb5, 0x54, 0xc5, 0x55, 0x54, 0x68, 0x81, 0x0c, 0x03, 0x0a, 0xae, 0x71, 0xdc, 0x01, 0x6a, 0xc8,
+	0x0e, 0x18, 0x01, 0x08, 0x16, 0x06, 0x0e, 0x18, 0x2e, 0x03, 0x61, 0x50, 0x26, 0x0d, 0x78, 0xe9,
+	0x0b, 0x69, 0xe1, 0x2c, 0xfa, 0xed, 0x24, 0xa3, 0xcd, 0x06, 0x37, 0xd1, 0xca, 0xf0, 0x32, 0x8c,
+	0x1d, 0xe6, 0x84, 0x11, 0x5e, 0x4a, 0x1d, 0x5f, 0x9a, 0x7c, 0x35, 0x44, 0xf8, 0x12, 0x88, 0xcb,
+	0x1c, 0x41, 0x9e, 0x1a, 0x23, 0xa5, 0xe1, 0x92, 0xf0, 0x43, 0x8f, 0xf0, 0xf6, 0x97, 0x4c, 0x2e,
+	0xd2, 0x57, 0xf2, 0xed, 0xac, 0x7a, 0xfd, 0x1b, 0x3f, 0x07, 0x00, 0xea, 0xc8, 0x81, 0xa3, 0x57,
+	0x07, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -300,15 +471,29 @@ type FrontendServiceClient interface {
 	//   - If SearchFields exist in a Ticket, CreateTicket will also index these fields such that one can query the ticket with query.QueryTickets function.
 	CreateTicket(ctx context.Context, in *CreateTicketRequest, opts ...grpc.CallOption) (*Ticket, error)
 	// DeleteTicket immediately stops Open Match from using the Ticket for matchmaking and removes the Ticket from state storage.
-	// The client must delete the Ticket when finished matchmaking with it.
-	//   - If SearchFields exist in a Ticket, DeleteTicket will deindex the fields lazily.
-	// Users may still be able to assign/get a ticket after calling DeleteTicket on it.
+	// The client should delete the Ticket when finished matchmaking with it.
 	DeleteTicket(ctx context.Context, in *DeleteTicketRequest, opts ...grpc.CallOption) (*empty.Empty, error)
 	// GetTicket get the Ticket associated with the specified TicketId.
 	GetTicket(ctx context.Context, in *GetTicketRequest, opts ...grpc.CallOption) (*Ticket, error)
 	// WatchAssignments stream back Assignment of the specified TicketId if it is updated.
 	//   - If the Assignment is not updated, GetAssignment will retry using the configured backoff strategy.
 	WatchAssignments(ctx context.Context, in *WatchAssignmentsRequest, opts ...grpc.CallOption) (FrontendService_WatchAssignmentsClient, error)
+	// AcceptMatch accepts the ready check of the match the Ticket is in.  The
+	// match is ready to be assigned once all of its players accepted.  Fails
+	// with NOT_FOUND if the Ticket has no ready check, and FAILED_PRECONDITION if
+	// the ready check is already over.
+	//
+	// BETA FEATURE WARNING:  This call and the associated Request and Response
+	// messages are not finalized and still subject to possible change or removal.
+	AcceptMatch(ctx context.Context, in *AcceptMatchRequest, opts ...grpc.CallOption) (*AcceptMatchResponse, error)
+	// DeclineMatch declines the ready check of the match the Ticket is in.  The
+	// Tickets of the other players are released back to the pool.  Fails with
+	// NOT_FOUND if the Ticket has no ready check, and FAILED_PRECONDITION if the
+	// ready check is already over.
+	//
+	// BETA FEATURE WARNING:  This call and the associated Request and Response
+	// messages are not finalized and still subject to possible change or removal.
+	DeclineMatch(ctx context.Context, in *DeclineMatchRequest, opts ...grpc.CallOption) (*DeclineMatchResponse, error)
 }
 
 type frontendServiceClient struct {
@@ -378,6 +563,24 @@ func (x *frontendServiceWatchAssignmentsClient) Recv() (*WatchAssignmentsRespons
 	return m, nil
 }
 
+func (c *frontendServiceClient) AcceptMatch(ctx context.Context, in *AcceptMatchRequest, opts ...grpc.CallOption) (*AcceptMatchResponse, error) {
+	out := new(AcceptMatchResponse)
+	err := c.cc.Invoke(ctx, "/openmatch.FrontendService/AcceptMatch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *frontendServiceClient) DeclineMatch(ctx context.Context, in *DeclineMatchRequest, opts ...grpc.CallOption) (*DeclineMatchResponse, error) {
+	out := new(DeclineMatchResponse)
+	err := c.cc.Invoke(ctx, "/openmatch.FrontendService/DeclineMatch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // FrontendServiceServer is the server API for FrontendService service.
 type FrontendServiceServer interface {
 	// CreateTicket assigns an unique TicketId to the input Ticket and record it in state storage.
@@ -386,15 +589,29 @@ type FrontendServiceServer interface {
 	//   - If SearchFields exist in a Ticket, CreateTicket will also index these fields such that one can query the ticket with query.QueryTickets function.
 	CreateTicket(context.Context, *CreateTicketRequest) (*Ticket, error)
 	// DeleteTicket immediately stops Open Match from using the Ticket for matchmaking and removes the Ticket from state storage.
-	// The client must delete the Ticket when finished matchmaking with it.
-	//   - If SearchFields exist in a Ticket, DeleteTicket will deindex the fields lazily.
-	// Users may still be able to assign/get a ticket after calling DeleteTicket on it.
+	// The client should delete the Ticket when finished matchmaking with it.
 	DeleteTicket(context.Context, *DeleteTicketRequest) (*empty.Empty, error)
 	// GetTicket get the Ticket associated with the specified TicketId.
 	GetTicket(context.Context, *GetTicketRequest) (*Ticket, error)
 	// WatchAssignments stream back Assignment of the specified TicketId if it is updated.
 	//   - If the Assignment is not updated, GetAssignment will retry using the configured backoff strategy.
 	WatchAssignments(*WatchAssignmentsRequest, FrontendService_WatchAssignmentsServer) error
+	// AcceptMatch accepts the ready check of the match the Ticket is in.  The
+	// match is ready to be assigned once all of its players accepted.  Fails
+	// with NOT_FOUND if the Ticket has no ready check, and FAILED_PRECONDITION if
+	// the ready check is already over.
+	//
+	// BETA FEATURE WARNING:  This call and the associated Request and Response
+	// messages are not finalized and still subject to possible change or removal.
+	AcceptMatch(context.Context, *AcceptMatchRequest) (*AcceptMatchResponse, error)
+	// DeclineMatch declines the ready check of the match the Ticket is in.  The
+	// Tickets of the other players are released back to the pool.  Fails with
+	// NOT_FOUND if the Ticket has no ready check, and FAILED_PRECONDITION if the
+	// ready check is already over.
+	//
+	// BETA FEATURE WARNING:  This call and the associated Request and Response
+	// messages are not finalized and still subject to possible change or removal.
+	DeclineMatch(context.Context, *DeclineMatchRequest) (*DeclineMatchResponse, error)
 }
 
 // UnimplementedFrontendServiceServer can be embedded to have forward compatible implementations.
@@ -413,6 +630,12 @@ func (*UnimplementedFrontendServiceServer) GetTicket(ctx context.Context, req *G
 func (*UnimplementedFrontendServiceServer) WatchAssignments(req *WatchAssignmentsRequest, srv FrontendService_WatchAssignmentsServer) error {
 	return status.Errorf(codes.Unimplemented, "method WatchAssignments not implemented")
 }
+func (*UnimplementedFrontendServiceServer) AcceptMatch(ctx context.Context, req *AcceptMatchRequest) (*AcceptMatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AcceptMatch not implemented")
+}
+func (*UnimplementedFrontendServiceServer) DeclineMatch(ctx context.Context, req *DeclineMatchRequest) (*DeclineMatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeclineMatch not implemented")
+}
 
 func RegisterFrontendServiceServer(s *grpc.Server, srv FrontendServiceServer) {
 	s.RegisterService(&_FrontendService_serviceDesc, srv)
@@ -493,6 +716,42 @@ func (x *frontendServiceWatchAssignmentsServer) Send(m *WatchAssignmentsResponse
 	return x.ServerStream.SendMsg(m)
 }
 
+func _FrontendService_AcceptMatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AcceptMatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FrontendServiceServer).AcceptMatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/openmatch.FrontendService/AcceptMatch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FrontendServiceServer).AcceptMatch(ctx, req.(*AcceptMatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FrontendService_DeclineMatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeclineMatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FrontendServiceServer).DeclineMatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/openmatch.FrontendService/DeclineMatch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FrontendServiceServer).DeclineMatch(ctx, req.(*DeclineMatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _FrontendService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "openmatch.FrontendService",
 	HandlerType: (*FrontendServiceServer)(nil),
@@ -509,6 +768,14 @@ var _FrontendService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetTicket",
 			Handler:    _FrontendService_GetTicket_Handler,
 		},
+		{
+			MethodName: "AcceptMatch",
+			Handler:    _FrontendService_AcceptMatch_Handler,
+		},
+		{
+			MethodName: "DeclineMatch",
+			Handler:    _FrontendService_DeclineMatch_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -115,38 +115,38 @@ func init() {
 func init() { proto.RegisterFile("api/matchfunction.proto", fileDescriptor_2b5069a21f149a55) }
 
 var fileDescriptor_2b5069a21f149a55 = []byte{
-	// 486 bytes of a gzipped FileDescriptorProto
+	// 483 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x52, 0x4d, 0x6e, 0x13, 0x31,
 	0x18, 0xd5, 0x4c, 0x50, 0x4b, 0x5d, 0x81, 0x2a, 0x4b, 0xfd, 0x51, 0xc4, 0xc2, 0x84, 0x0d, 0x8a,
 	0x9a, 0x71, 0x1a, 0xba, 0x4a, 0x85, 0x68, 0x81, 0x22, 0x55, 0x2a, 0x3f, 0x1a, 0x24, 0x84, 0xd8,
-	0x39, 0xce, 0xd7, 0x99, 0x81, 0xc4, 0x9f, 0xf1, 0x67, 0xb7, 0xac, 0xb9, 0x01, 0xb0, 0xe3, 0x08,
-	0x9c, 0x80, 0x7b, 0x70, 0x00, 0x36, 0x1c, 0x04, 0x8d, 0xa7, 0x69, 0x42, 0xca, 0x66, 0x46, 0x7e,
-	0xef, 0x7d, 0xef, 0xd9, 0xcf, 0x66, 0xdb, 0xca, 0x56, 0x72, 0xaa, 0xbc, 0x2e, 0xcf, 0x82, 0xd1,
-	0xbe, 0x42, 0x93, 0x59, 0x87, 0x1e, 0xf9, 0x1a, 0x5a, 0x30, 0x91, 0x68, 0xf3, 0xa8, 0x01, 0x22,
-	0x55, 0x00, 0x35, 0x74, 0xfb, 0x4e, 0x81, 0x58, 0x4c, 0x40, 0xd6, 0x94, 0x32, 0x06, 0xbd, 0xaa,
-	0x67, 0x67, 0xec, 0x6e, 0xfc, 0xe9, 0x5e, 0x01, 0xa6, 0x47, 0x17, 0xaa, 0x28, 0xc0, 0x49, 0xb4,
-	0x51, 0x71, 0x5d, 0xdd, 0x79, 0xc4, 0x58, 0x1e, 0x4c, 0x0e, 0x1f, 0x03, 0x90, 0xe7, 0x7b, 0x6c,
-	0xd5, 0x3a, 0x3c, 0xab, 0x26, 0xb0, 0x93, 0x88, 0xe4, 0xfe, 0xfa, 0x60, 0x3b, 0xbb, 0xda, 0x4a,
-	0xf6, 0xbc, 0xfe, 0xbe, 0x6a, 0xe8, 0x7c, 0xa6, 0xeb, 0x1c, 0xb0, 0xf5, 0x68, 0x40, 0x16, 0x0d,
-	0x01, 0xdf, 0x65, 0x37, 0xad, 0x43, 0x8b, 0xa4, 0x26, 0x97, 0x16, 0x1b, 0xcb, 0x16, 0xf9, 0x95,
-	0x62, 0x50, 0xb1, 0x5b, 0x11, 0x7a, 0x76, 0x79, 0x7e, 0xfe, 0x96, 0xb5, 0xf2, 0x60, 0xf8, 0xe6,
-	0xc2, 0xcc, 0x7c, 0x7b, 0xed, 0xad, 0x65, 0xb8, 0x09, 0xed, 0x88, 0xcf, 0xbf, 0xfe, 0x7c, 0x4b,
-	0xdb, 0x9d, 0x4d, 0x79, 0xbe, 0xf7, 0x6f, 0xa1, 0x43, 0x17, 0xcc, 0x30, 0xe9, 0xf6, 0x93, 0xc7,
-	0x5f, 0x5a, 0x5f, 0x8f, 0x7e, 0xa7, 0xfc, 0x67, 0xc2, 0x6e, 0xc7, 0x48, 0x31, 0xcb, 0xec, 0x9c,
-	0x30, 0xf6, 0xd2, 0x82, 0x11, 0x11, 0xe6, 0x5b, 0xa5, 0xf7, 0x96, 0x86, 0x52, 0xd6, 0x51, 0xbd,
-	0x26, 0x6b, 0x0c, 0xe7, 0xed, 0x7b, 0xf3, 0x75, 0x6f, 0x5c, 0x91, 0x0e, 0x44, 0x87, 0xcd, 0x3d,
-	0x14, 0x0e, 0x83, 0xa5, 0x4c, 0xe3, 0xb4, 0xfb, 0x86, 0xf1, 0x23, 0xab, 0x74, 0x09, 0x62, 0x90,
-	0xf5, 0xc5, 0x69, 0xa5, 0xa1, 0xee, 0xe4, 0x70, 0x66, 0x59, 0x54, 0xbe, 0x0c, 0xa3, 0x5a, 0x29,
-	0x9b, 0xd1, 0x33, 0x74, 0x85, 0x9a, 0x02, 0x2d, 0x84, 0xc9, 0xd1, 0x04, 0x47, 0x72, 0xaa, 0xc8,
-	0x83, 0x93, 0xa7, 0x27, 0x4f, 0x8e, 0x5f, 0xbc, 0x3e, 0x1e, 0xb4, 0xf6, 0xb2, 0x7e, 0x37, 0x4d,
-	0xd2, 0xc1, 0x86, 0xb2, 0x76, 0x52, 0xe9, 0x78, 0x85, 0xf2, 0x3d, 0xa1, 0x19, 0x5e, 0x43, 0xf2,
-	0x03, 0xd6, 0xda, 0xef, 0xef, 0xf3, 0x7d, 0xd6, 0xcd, 0xc1, 0x07, 0x67, 0x60, 0x2c, 0x2e, 0x4a,
-	0x30, 0xc2, 0x97, 0x20, 0x1c, 0x10, 0x06, 0xa7, 0x41, 0x8c, 0x11, 0x48, 0x18, 0xf4, 0x02, 0x3e,
-	0x55, 0xe4, 0x33, 0xbe, 0xc2, 0x6e, 0x7c, 0x4f, 0x93, 0x55, 0xf7, 0x90, 0xed, 0xcc, 0xcb, 0x10,
-	0x4f, 0x51, 0x87, 0x29, 0x98, 0xe6, 0xc9, 0xf0, 0xbb, 0xff, 0xaf, 0x46, 0x52, 0xe5, 0x41, 0x8e,
-	0x51, 0x93, 0x7c, 0x27, 0x96, 0xa8, 0x85, 0x73, 0xd9, 0x0f, 0x85, 0xb4, 0xa3, 0x1f, 0xe9, 0x5a,
-	0xed, 0x1f, 0xed, 0x47, 0x2b, 0xf1, 0x0d, 0x3e, 0xf8, 0x1b, 0x00, 0x00, 0xff, 0xff, 0x79, 0x14,
-	0x90, 0xfd, 0x09, 0x03, 0x00, 0x00,
+	0x39, 0xce, 0x97, 0x99, 0x81, 0xc4, 0x9f, 0xf1, 0x67, 0xb7, 0xac, 0xb9, 0x01, 0xb0, 0xe3, 0x08,
+	0x9c, 0x80, 0x7b, 0x70, 0x00, 0x36, 0x1c, 0x04, 0x8d, 0xa7, 0x69, 0x4a, 0xca, 0x66, 0x46, 0x7e,
+	0xef, 0x7d, 0xef, 0xd9, 0xcf, 0x66, 0xdb, 0xca, 0x56, 0x72, 0xa6, 0xbc, 0x2e, 0x27, 0xc1, 0x68,
+	0x5f, 0xa1, 0xc9, 0xac, 0x43, 0x8f, 0x7c, 0x0d, 0x2d, 0x98, 0x48, 0xb4, 0x79, 0xd4, 0x00, 0x91,
+	0x2a, 0x80, 0x1a, 0xba, 0x7d, 0xa7, 0x40, 0x2c, 0xa6, 0x20, 0x6b, 0x4a, 0x19, 0x83, 0x5e, 0xd5,
+	0xb3, 0x73, 0x76, 0x37, 0xfe, 0x74, 0xaf, 0x00, 0xd3, 0xa3, 0x73, 0x55, 0x14, 0xe0, 0x24, 0xda,
+	0xa8, 0xb8, 0xae, 0xee, 0x3c, 0x62, 0x2c, 0x0f, 0x26, 0x87, 0x8f, 0x01, 0xc8, 0xf3, 0x3d, 0xb6,
+	0x6a, 0x1d, 0x4e, 0xaa, 0x29, 0xec, 0x24, 0x22, 0xb9, 0xbf, 0x3e, 0xd8, 0xce, 0x2e, 0xb7, 0x92,
+	0x3d, 0xaf, 0xbf, 0xaf, 0x1a, 0x3a, 0x9f, 0xeb, 0x3a, 0x07, 0x6c, 0x3d, 0x1a, 0x90, 0x45, 0x43,
+	0xc0, 0x77, 0xd9, 0x4d, 0xeb, 0xd0, 0x22, 0xa9, 0xe9, 0x85, 0xc5, 0xc6, 0xb2, 0x45, 0x7e, 0xa9,
+	0x18, 0x54, 0xec, 0x56, 0x84, 0x9e, 0x5d, 0x9c, 0x9f, 0xbf, 0x65, 0xad, 0x3c, 0x18, 0xbe, 0x79,
+	0x65, 0x66, 0xb1, 0xbd, 0xf6, 0xd6, 0x32, 0xdc, 0x84, 0x76, 0xc4, 0xe7, 0x5f, 0x7f, 0xbe, 0xa5,
+	0xed, 0xce, 0xa6, 0x3c, 0xdb, 0xfb, 0xb7, 0xd0, 0xa1, 0x0b, 0x66, 0x98, 0x74, 0xfb, 0xc9, 0xe3,
+	0x2f, 0xad, 0xaf, 0x47, 0xbf, 0x53, 0xfe, 0x33, 0x61, 0xb7, 0x63, 0xa4, 0x98, 0x67, 0x76, 0x4e,
+	0x18, 0x7b, 0x69, 0xc1, 0x88, 0x08, 0xf3, 0xad, 0xd2, 0x7b, 0x4b, 0x43, 0x29, 0xeb, 0xa8, 0x5e,
+	0x93, 0x35, 0x86, 0xb3, 0xf6, 0xbd, 0xc5, 0xba, 0x37, 0xae, 0x48, 0x07, 0xa2, 0xc3, 0xe6, 0x1e,
+	0x0a, 0x87, 0xc1, 0x52, 0xa6, 0x71, 0xd6, 0x7d, 0xc3, 0xf8, 0x91, 0x55, 0xba, 0x04, 0x31, 0xc8,
+	0xfa, 0xe2, 0xb4, 0xd2, 0x50, 0x77, 0x72, 0x38, 0xb7, 0x2c, 0x2a, 0x5f, 0x86, 0x51, 0xad, 0x94,
+	0xcd, 0xe8, 0x04, 0x5d, 0xa1, 0x66, 0x40, 0x57, 0xc2, 0xe4, 0x68, 0x8a, 0x23, 0x39, 0x53, 0xe4,
+	0xc1, 0xc9, 0xd3, 0x93, 0x27, 0xc7, 0x2f, 0x5e, 0x1f, 0x0f, 0x5a, 0x7b, 0x59, 0xbf, 0x9b, 0x26,
+	0xe9, 0x60, 0x43, 0x59, 0x3b, 0xad, 0x74, 0xbc, 0x42, 0xf9, 0x9e, 0xd0, 0x0c, 0xaf, 0x21, 0xf9,
+	0x01, 0x6b, 0xed, 0xf7, 0xf7, 0xf9, 0x3e, 0xeb, 0xe6, 0xe0, 0x83, 0x33, 0x30, 0x16, 0xe7, 0x25,
+	0x18, 0xe1, 0x4b, 0x10, 0x0e, 0x08, 0x83, 0xd3, 0x20, 0xc6, 0x08, 0x24, 0x0c, 0x7a, 0x01, 0x9f,
+	0x2a, 0xf2, 0x19, 0x5f, 0x61, 0x37, 0xbe, 0xa7, 0xc9, 0xaa, 0x7b, 0xc8, 0x76, 0x16, 0x65, 0x88,
+	0xa7, 0xa8, 0xc3, 0x0c, 0x4c, 0xf3, 0x64, 0xf8, 0xdd, 0xff, 0x57, 0x23, 0xa9, 0xf2, 0x20, 0xc7,
+	0xa8, 0x49, 0xbe, 0x13, 0x4b, 0xd4, 0x62, 0x29, 0xed, 0x87, 0x42, 0xda, 0xd1, 0x8f, 0x74, 0xad,
+	0xf6, 0x8f, 0xf6, 0xa3, 0x95, 0xf8, 0x06, 0x1f, 0xfc, 0x1d, 0x00, 0x79, 0x14, 0x90, 0xfd, 0x09,
+	0x03, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
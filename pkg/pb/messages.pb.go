@@ -23,15 +23,53 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
-// A Ticket is a basic matchmaking entity in Open Match. A Ticket represents either an
-// individual 'Player' or a 'Group' of players. Open Match will not interpret
-// what the Ticket represents but just treat it as a matchmaking unit with a set
-// of SearchFields. Open Match stores the Ticket in state storage and enables an
-// Assignment to be associated with this Ticket.
+type RejectedMatch_Reason int32
+
+const (
+	// No reason was given for rejecting the match.
+	RejectedMatch_UNKNOWN RejectedMatch_Reason = 0
+	// The match shares tickets with another match which was accepted instead.
+	RejectedMatch_COLLISION RejectedMatch_Reason = 1
+	// The match's quality was too low to be accepted.
+	RejectedMatch_LOW_SCORE RejectedMatch_Reason = 2
+	// The evaluator was unable to evaluate the match, for example because its
+	// evaluation input was invalid.
+	RejectedMatch_EVALUATOR_ERROR RejectedMatch_Reason = 3
+)
+
+var RejectedMatch_Reason_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "COLLISION",
+	2: "LOW_SCORE",
+	3: "EVALUATOR_ERROR",
+}
+
+var RejectedMatch_Reason_value = map[string]int32{
+	"UNKNOWN":         0,
+	"COLLISION":       1,
+	"LOW_SCORE":       2,
+	"EVALUATOR_ERROR": 3,
+}
+
+func (x RejectedMatch_Reason) String() string {
+	return proto.EnumName(RejectedMatch_Reason_name, int32(x))
+}
+
+func (RejectedMatch_Reason) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_cb9fb1f207fd5b8c, []int{9, 0}
+}
+
+// A Ticket is a basic matchmaking entity in Open Match. A Ticket may represent
+// an individual 'Player', a 'Group' of players, or any other concepts unique to
+// your use case. Open Match will not interpret what the Ticket represents but
+// just treat it as a matchmaking unit with a set of SearchFields. Open Match
+// stores the Ticket in state storage and enables an Assignment to be set on the
+// Ticket.
 type Ticket struct {
 	// Id represents an auto-generated Id issued by Open Match.
 	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	// An Assignment represents a game server assignment associated with a Ticket.
+	// An Assignment represents a game server assignment associated with a Ticket,
+	// or whatever finalized matched state means for your use case.
 	// Open Match does not require or inspect any fields on Assignment.
 	Assignment *Assignment `protobuf:"bytes,3,opt,name=assignment,proto3" json:"assignment,omitempty"`
 	// Search fields are the fields which Open Match is aware of, and can be used
@@ -41,12 +79,16 @@ type Ticket struct {
 	// making function, evaluator, and components making calls to Open Match.
 	// Optional, depending on the requirements of the connected systems.
 	Extensions map[string]*any.Any `protobuf:"bytes,5,rep,name=extensions,proto3" json:"extensions,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	// Create time represents the time at which this Ticket was created. It is
-	// populated by Open Match at the time of Ticket creation.
-	CreateTime           *timestamp.Timestamp `protobuf:"bytes,6,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
-	XXX_unrecognized     []byte               `json:"-"`
-	XXX_sizecache        int32                `json:"-"`
+	// Create time is the time the Ticket was created. It is populated by Open
+	// Match at the time of Ticket creation.
+	CreateTime *timestamp.Timestamp `protobuf:"bytes,6,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	// Priority of the Ticket relative to other Tickets.  Higher priority Tickets
+	// are returned first by QueryTickets when ordered by priority.  Optional,
+	// defaults to 0.
+	Priority             float64  `protobuf:"fixed64,7,opt,name=priority,proto3" json:"priority,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *Ticket) Reset()         { *m = Ticket{} }
@@ -109,6 +151,13 @@ func (m *Ticket) GetCreateTime() *timestamp.Timestamp {
 	return nil
 }
 
+func (m *Ticket) GetPriority() float64 {
+	if m != nil {
+		return m.Priority
+	}
+	return 0
+}
+
 // Search fields are the fields which Open Match is aware of, and can be used
 // when specifying filters.
 type SearchFields struct {
@@ -169,8 +218,8 @@ func (m *SearchFields) GetTags() []string {
 	return nil
 }
 
-// An Assignment represents a game server assignment associated with a Ticket. Open
-// match does not require or inspect any fields on assignment.
+// An Assignment represents a game server assignment associated with a Ticket.
+// Open Match does not require or inspect any fields on assignment.
 type Assignment struct {
 	// Connection information for this Assignment.
 	Connection string `protobuf:"bytes,1,opt,name=connection,proto3" json:"connection,omitempty"`
@@ -223,18 +272,23 @@ func (m *Assignment) GetExtensions() map[string]*any.Any {
 }
 
 // Filters numerical values to only those within a range.
-//   double_arg: "foo"
-//   max: 10
-//   min: 5
+//
+//	double_arg: "foo"
+//	max: 10
+//	min: 5
+//
 // matches:
-//   {"foo": 5}
-//   {"foo": 7.5}
-//   {"foo": 10}
+//
+//	{"foo": 5}
+//	{"foo": 7.5}
+//	{"foo": 10}
+//
 // does not match:
-//   {"foo": 4}
-//   {"foo": 10.01}
-//   {"foo": "7.5"}
-//   {}
+//
+//	{"foo": 4}
+//	{"foo": 10.01}
+//	{"foo": "7.5"}
+//	{}
 type DoubleRangeFilter struct {
 	// Name of the ticket's search_fields.double_args this Filter operates on.
 	DoubleArg string `protobuf:"bytes,1,opt,name=double_arg,json=doubleArg,proto3" json:"double_arg,omitempty"`
@@ -294,14 +348,19 @@ func (m *DoubleRangeFilter) GetMin() float64 {
 }
 
 // Filters strings exactly equaling a value.
-//   string_arg: "foo"
-//   value: "bar"
+//
+//	string_arg: "foo"
+//	value: "bar"
+//
 // matches:
-//   {"foo": "bar"}
+//
+//	{"foo": "bar"}
+//
 // does not match:
-//   {"foo": "baz"}
-//   {"bar": "foo"}
-//   {}
+//
+//	{"foo": "baz"}
+//	{"bar": "foo"}
+//	{}
 type StringEqualsFilter struct {
 	// Name of the ticket's search_fields.string_args this Filter operates on.
 	StringArg            string   `protobuf:"bytes,1,opt,name=string_arg,json=stringArg,proto3" json:"string_arg,omitempty"`
@@ -351,13 +410,18 @@ func (m *StringEqualsFilter) GetValue() string {
 }
 
 // Filters to the tag being present on the search_fields.
-//   tag: "foo"
+//
+//	tag: "foo"
+//
 // matches:
-//   ["foo"]
-//   ["bar","foo"]
+//
+//	["foo"]
+//	["bar","foo"]
+//
 // does not match:
-//   ["bar"]
-//   []
+//
+//	["bar"]
+//	[]
 type TagPresentFilter struct {
 	Tag                  string   `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -631,7 +695,69 @@ func (m *Match) GetExtensions() map[string]*any.Any {
 	return nil
 }
 
+// A RejectedMatch explains why a proposed Match was not accepted by the
+// evaluator.
+type RejectedMatch struct {
+	// Id of the rejected Match.
+	MatchId string `protobuf:"bytes,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	// Reason the Match was rejected.
+	Reason RejectedMatch_Reason `protobuf:"varint,2,opt,name=reason,proto3,enum=openmatch.RejectedMatch_Reason" json:"reason,omitempty"`
+	// Id of the accepted Match which the rejected Match collided with, if the
+	// reason is COLLISION.
+	CollidingMatchId     string   `protobuf:"bytes,3,opt,name=colliding_match_id,json=collidingMatchId,proto3" json:"colliding_match_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RejectedMatch) Reset()         { *m = RejectedMatch{} }
+func (m *RejectedMatch) String() string { return proto.CompactTextString(m) }
+func (*RejectedMatch) ProtoMessage()    {}
+func (*RejectedMatch) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cb9fb1f207fd5b8c, []int{9}
+}
+
+func (m *RejectedMatch) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RejectedMatch.Unmarshal(m, b)
+}
+func (m *RejectedMatch) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RejectedMatch.Marshal(b, m, deterministic)
+}
+func (m *RejectedMatch) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RejectedMatch.Merge(m, src)
+}
+func (m *RejectedMatch) XXX_Size() int {
+	return xxx_messageInfo_RejectedMatch.Size(m)
+}
+func (m *RejectedMatch) XXX_DiscardUnknown() {
+	xxx_messageInfo_RejectedMatch.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RejectedMatch proto.InternalMessageInfo
+
+func (m *RejectedMatch) GetMatchId() string {
+	if m != nil {
+		return m.MatchId
+	}
+	return ""
+}
+
+func (m *RejectedMatch) GetReason() RejectedMatch_Reason {
+	if m != nil {
+		return m.Reason
+	}
+	return RejectedMatch_UNKNOWN
+}
+
+func (m *RejectedMatch) GetCollidingMatchId() string {
+	if m != nil {
+		return m.CollidingMatchId
+	}
+	return ""
+}
+
 func init() {
+	proto.RegisterEnum("openmatch.RejectedMatch_Reason", RejectedMatch_Reason_name, RejectedMatch_Reason_value)
 	proto.RegisterType((*Ticket)(nil), "openmatch.Ticket")
 	proto.RegisterMapType((map[string]*any.Any)(nil), "openmatch.Ticket.ExtensionsEntry")
 	proto.RegisterType((*SearchFields)(nil), "openmatch.SearchFields")
@@ -647,62 +773,71 @@ func init() {
 	proto.RegisterMapType((map[string]*any.Any)(nil), "openmatch.MatchProfile.ExtensionsEntry")
 	proto.RegisterType((*Match)(nil), "openmatch.Match")
 	proto.RegisterMapType((map[string]*any.Any)(nil), "openmatch.Match.ExtensionsEntry")
+	proto.RegisterType((*RejectedMatch)(nil), "openmatch.RejectedMatch")
 }
 
 func init() { proto.RegisterFile("api/messages.proto", fileDescriptor_cb9fb1f207fd5b8c) }
 
 var fileDescriptor_cb9fb1f207fd5b8c = []byte{
-	// 830 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x55, 0xdd, 0x6e, 0xe3, 0x44,
-	0x14, 0x56, 0x6c, 0x27, 0x69, 0x4e, 0xd2, 0xd6, 0x9d, 0xed, 0x6a, 0xbd, 0x81, 0x85, 0x60, 0xa8,
-	0xa8, 0x40, 0x38, 0x52, 0x11, 0x12, 0xe2, 0x47, 0x90, 0x15, 0x2d, 0x6c, 0x11, 0x50, 0xdc, 0x8a,
-	0x0b, 0x6e, 0xac, 0x49, 0x3c, 0xf1, 0x5a, 0xb5, 0xc7, 0xc6, 0x33, 0x59, 0x6d, 0xde, 0x83, 0xa7,
-	0xe0, 0x9a, 0x6b, 0x9e, 0x82, 0x87, 0xe0, 0x9e, 0x17, 0x40, 0xf3, 0x63, 0x67, 0xd6, 0x09, 0xbb,
-	0xdc, 0xa0, 0xde, 0xcd, 0x9c, 0x9f, 0x6f, 0xce, 0xf9, 0xce, 0xe7, 0x63, 0x40, 0xb8, 0x4c, 0xa7,
-	0x39, 0x61, 0x0c, 0x27, 0x84, 0x05, 0x65, 0x55, 0xf0, 0x02, 0x0d, 0x8a, 0x92, 0xd0, 0x1c, 0xf3,
-	0xc5, 0xd3, 0xf1, 0x83, 0xa4, 0x28, 0x92, 0x8c, 0x4c, 0xab, 0x72, 0x31, 0x65, 0x1c, 0xf3, 0x95,
-	0x8e, 0x19, 0x3f, 0xd4, 0x0e, 0x79, 0x9b, 0xaf, 0x96, 0x53, 0x4c, 0xd7, 0xda, 0xf5, 0x66, 0xdb,
-	0xc5, 0xd3, 0x9c, 0x30, 0x8e, 0xf3, 0x52, 0x05, 0xf8, 0x7f, 0x59, 0xd0, 0xbb, 0x49, 0x17, 0xb7,
-	0x84, 0xa3, 0x03, 0xb0, 0xd2, 0xd8, 0xeb, 0x4c, 0x3a, 0xa7, 0x83, 0xd0, 0x4a, 0x63, 0xf4, 0x11,
-	0x00, 0x66, 0x2c, 0x4d, 0x68, 0x4e, 0x28, 0xf7, 0xec, 0x49, 0xe7, 0x74, 0x78, 0x76, 0x3f, 0x68,
-	0xea, 0x09, 0x66, 0x8d, 0x33, 0x34, 0x02, 0xd1, 0x67, 0xb0, 0xcf, 0x08, 0xae, 0x16, 0x4f, 0xa3,
-	0x65, 0x4a, 0xb2, 0x98, 0x79, 0x8e, 0xcc, 0x7c, 0x60, 0x64, 0x5e, 0x4b, 0xff, 0x85, 0x74, 0x87,
-	0x23, 0x66, 0xdc, 0xd0, 0x0c, 0x80, 0x3c, 0xe7, 0x84, 0xb2, 0xb4, 0xa0, 0xcc, 0xeb, 0x4e, 0xec,
-	0xd3, 0xe1, 0xd9, 0x5b, 0x46, 0xaa, 0xaa, 0x35, 0x38, 0x6f, 0x62, 0xce, 0x29, 0xaf, 0xd6, 0xa1,
-	0x91, 0x84, 0x3e, 0x85, 0xe1, 0xa2, 0x22, 0x98, 0x93, 0x48, 0x34, 0xeb, 0xf5, 0xe4, 0xf3, 0xe3,
-	0x40, 0x31, 0x11, 0xd4, 0x4c, 0x04, 0x37, 0x35, 0x13, 0x21, 0xa8, 0x70, 0x61, 0x18, 0x5f, 0xc3,
-	0x61, 0x0b, 0x1b, 0xb9, 0x60, 0xdf, 0x92, 0xb5, 0x26, 0x46, 0x1c, 0xd1, 0x7b, 0xd0, 0x7d, 0x86,
-	0xb3, 0x15, 0xf1, 0x2c, 0x89, 0x7d, 0xbc, 0x85, 0x3d, 0xa3, 0xeb, 0x50, 0x85, 0x7c, 0x62, 0x7d,
-	0xdc, 0xb9, 0x74, 0xf6, 0x2c, 0xd7, 0xf6, 0x7f, 0xb7, 0x60, 0x64, 0x76, 0x8e, 0xbe, 0x81, 0x61,
-	0x5c, 0xac, 0xe6, 0x19, 0x89, 0x70, 0x95, 0x30, 0xaf, 0x23, 0x9b, 0x7d, 0xf7, 0x5f, 0x78, 0x0a,
-	0xbe, 0x92, 0xa1, 0xb3, 0x2a, 0xa9, 0x5b, 0x8e, 0x1b, 0x83, 0x40, 0x62, 0xbc, 0x4a, 0x69, 0xa2,
-	0x90, 0xac, 0x97, 0x23, 0x5d, 0xcb, 0x50, 0x03, 0x89, 0x35, 0x06, 0x84, 0xc0, 0xe1, 0x38, 0x61,
-	0x9e, 0x3d, 0xb1, 0x4f, 0x07, 0xa1, 0x3c, 0x8f, 0x3f, 0x87, 0xc3, 0xd6, 0xe3, 0x3b, 0x38, 0x39,
-	0x36, 0x39, 0xe9, 0x18, 0xdd, 0x8b, 0xf4, 0xd6, 0x8b, 0xaf, 0x4a, 0x1f, 0x18, 0xe9, 0xfe, 0x9f,
-	0x1d, 0x80, 0x8d, 0xd4, 0xd0, 0x1b, 0x00, 0x8b, 0x82, 0x52, 0xb2, 0xe0, 0x69, 0x41, 0x35, 0x82,
-	0x61, 0x41, 0xe7, 0x2f, 0x08, 0xc8, 0x91, 0x4c, 0x9c, 0xec, 0x54, 0xed, 0xcb, 0x44, 0xf4, 0x3f,
-	0xea, 0xe0, 0xd2, 0xd9, 0xb3, 0x5d, 0xc7, 0xff, 0x09, 0x8e, 0x14, 0xa9, 0x21, 0xa6, 0x09, 0xb9,
-	0x48, 0x33, 0x4e, 0x2a, 0xf4, 0x08, 0x60, 0xa3, 0x08, 0xfd, 0xd2, 0xa0, 0x99, 0xb3, 0xa8, 0x20,
-	0xc7, 0xcf, 0x35, 0xc3, 0xe2, 0x28, 0x2d, 0x29, 0x95, 0x1f, 0xa7, 0xb0, 0xa4, 0xd4, 0x7f, 0x02,
-	0x48, 0xb1, 0x7d, 0xfe, 0xcb, 0x0a, 0x67, 0x6c, 0x03, 0xbc, 0x11, 0x48, 0x0d, 0xdc, 0x8c, 0x7d,
-	0x37, 0xfb, 0xfe, 0x3b, 0xe0, 0xde, 0xe0, 0xe4, 0xaa, 0x22, 0x8c, 0x50, 0xae, 0x81, 0x5c, 0xb0,
-	0x39, 0xae, 0x11, 0xc4, 0xd1, 0xff, 0xd5, 0x06, 0xe7, 0xaa, 0x28, 0x32, 0x21, 0x1d, 0x8a, 0x73,
-	0xa2, 0x7d, 0xf2, 0x8c, 0xbe, 0x87, 0x63, 0xdd, 0x50, 0x25, 0xda, 0x8c, 0x96, 0x12, 0xa5, 0x56,
-	0xe8, 0xeb, 0xc6, 0x5c, 0xb6, 0xc8, 0x08, 0x51, 0xdc, 0x36, 0x31, 0xf4, 0x23, 0xdc, 0xd7, 0x7d,
-	0x10, 0xd9, 0x5e, 0x03, 0xa8, 0x06, 0xfd, 0xc8, 0x94, 0xfc, 0x16, 0x0b, 0xe1, 0x3d, 0xb6, 0x65,
-	0x63, 0xe8, 0x5b, 0xb8, 0xc7, 0x71, 0x12, 0x95, 0xaa, 0xcd, 0x06, 0x50, 0xad, 0x9e, 0xd7, 0xcc,
-	0xd5, 0xd3, 0xe2, 0x22, 0x3c, 0xe2, 0x2d, 0x8b, 0x58, 0x5f, 0x07, 0x6a, 0x99, 0xc4, 0xd1, 0x9c,
-	0x2c, 0x8b, 0xea, 0xbf, 0xac, 0x9f, 0x7d, 0x9d, 0xf1, 0x58, 0x26, 0xa0, 0x2f, 0xa0, 0x36, 0x44,
-	0x78, 0xc9, 0x49, 0xe5, 0xf5, 0x5f, 0x89, 0x30, 0xd2, 0x09, 0x33, 0x11, 0xaf, 0xf5, 0xf5, 0x77,
-	0x07, 0x46, 0xdf, 0x89, 0xba, 0xaf, 0xaa, 0x62, 0x99, 0x66, 0x64, 0xe7, 0x78, 0x4e, 0xa0, 0x5b,
-	0x16, 0x45, 0xa6, 0x3e, 0xf7, 0xe1, 0xd9, 0xa1, 0xd1, 0xad, 0x18, 0x69, 0xa8, 0xbc, 0xe8, 0xeb,
-	0x1d, 0x4b, 0xd9, 0xdc, 0x2e, 0xe6, 0x3b, 0x77, 0xf7, 0x55, 0x39, 0x6e, 0xd7, 0xff, 0xc3, 0x82,
-	0xae, 0xac, 0x06, 0x3d, 0x84, 0x3d, 0x59, 0x5c, 0xd4, 0xfc, 0xd3, 0xfa, 0xf2, 0xfe, 0x24, 0x46,
-	0x6f, 0xc3, 0xbe, 0x72, 0x95, 0xaa, 0x64, 0xad, 0xfa, 0x51, 0x6e, 0xd2, 0x75, 0x02, 0x07, 0x2a,
-	0x68, 0xb9, 0xa2, 0x6a, 0xd7, 0xd8, 0x32, 0x4a, 0xa5, 0x5e, 0x68, 0x23, 0x7a, 0x1f, 0xfa, 0x5c,
-	0xfe, 0x92, 0x6a, 0x09, 0x1e, 0x6d, 0xfd, 0xac, 0xc2, 0x3a, 0x02, 0x7d, 0xf9, 0x02, 0x8f, 0x7d,
-	0x19, 0x3f, 0x69, 0xf3, 0x78, 0x17, 0x04, 0x76, 0xdd, 0xde, 0xa5, 0xb3, 0xd7, 0x73, 0xfb, 0x8f,
-	0x83, 0x9f, 0x27, 0xa2, 0x9e, 0x0f, 0x54, 0x41, 0x31, 0x79, 0x36, 0xdd, 0x5c, 0xa7, 0xe5, 0x6d,
-	0x32, 0x2d, 0xe7, 0xbf, 0x59, 0x83, 0x1f, 0x4a, 0x42, 0x65, 0xb1, 0xf3, 0x9e, 0x04, 0xfd, 0xf0,
-	0x9f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x7f, 0x53, 0xe9, 0x37, 0xbc, 0x08, 0x00, 0x00,
+	// 960 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x55, 0xdd, 0x72, 0xdb, 0x44,
+	0x14, 0x46, 0x92, 0x7f, 0xe2, 0x63, 0x3b, 0x51, 0x36, 0xe9, 0x54, 0x35, 0x94, 0x1a, 0x41, 0x06,
+	0x0f, 0x3f, 0xf2, 0x4c, 0x18, 0x06, 0x86, 0x9f, 0x01, 0xb7, 0x38, 0x34, 0x69, 0x6a, 0x87, 0x75,
+	0xda, 0xce, 0x70, 0xa3, 0x59, 0x5b, 0x6b, 0x55, 0x44, 0x5e, 0x09, 0xed, 0xba, 0x53, 0xdf, 0xf3,
+	0x08, 0x3c, 0x05, 0xd7, 0x5c, 0xf3, 0x14, 0xdc, 0xf1, 0x18, 0xbc, 0x00, 0xa3, 0x5d, 0x59, 0x56,
+	0x6c, 0xd3, 0x70, 0xc3, 0xf4, 0x6e, 0xf7, 0x9c, 0xef, 0x7c, 0xe7, 0x9c, 0x6f, 0x8f, 0x8e, 0x00,
+	0x91, 0x38, 0xe8, 0xce, 0x28, 0xe7, 0xc4, 0xa7, 0xdc, 0x89, 0x93, 0x48, 0x44, 0xa8, 0x16, 0xc5,
+	0x94, 0xcd, 0x88, 0x98, 0x3c, 0x6f, 0xdd, 0xf6, 0xa3, 0xc8, 0x0f, 0x69, 0x37, 0x89, 0x27, 0x5d,
+	0x2e, 0x88, 0x98, 0x67, 0x98, 0xd6, 0x9d, 0xcc, 0x21, 0x6f, 0xe3, 0xf9, 0xb4, 0x4b, 0xd8, 0x22,
+	0x73, 0xdd, 0x5b, 0x77, 0x89, 0x60, 0x46, 0xb9, 0x20, 0xb3, 0x58, 0x01, 0xec, 0x5f, 0x0c, 0xa8,
+	0x5c, 0x06, 0x93, 0x2b, 0x2a, 0xd0, 0x2e, 0xe8, 0x81, 0x67, 0x69, 0x6d, 0xad, 0x53, 0xc3, 0x7a,
+	0xe0, 0xa1, 0x4f, 0x01, 0x08, 0xe7, 0x81, 0xcf, 0x66, 0x94, 0x09, 0xcb, 0x68, 0x6b, 0x9d, 0xfa,
+	0xf1, 0x2d, 0x27, 0xaf, 0xc7, 0xe9, 0xe5, 0x4e, 0x5c, 0x00, 0xa2, 0xaf, 0xa0, 0xc9, 0x29, 0x49,
+	0x26, 0xcf, 0xdd, 0x69, 0x40, 0x43, 0x8f, 0x5b, 0x25, 0x19, 0x79, 0xbb, 0x10, 0x39, 0x92, 0xfe,
+	0x13, 0xe9, 0xc6, 0x0d, 0x5e, 0xb8, 0xa1, 0x1e, 0x00, 0x7d, 0x29, 0x28, 0xe3, 0x41, 0xc4, 0xb8,
+	0x55, 0x6e, 0x1b, 0x9d, 0xfa, 0xf1, 0x3b, 0x85, 0x50, 0x55, 0xab, 0xd3, 0xcf, 0x31, 0x7d, 0x26,
+	0x92, 0x05, 0x2e, 0x04, 0xa1, 0x2f, 0xa1, 0x3e, 0x49, 0x28, 0x11, 0xd4, 0x4d, 0x9b, 0xb5, 0x2a,
+	0x32, 0x7d, 0xcb, 0x51, 0x4a, 0x38, 0x4b, 0x25, 0x9c, 0xcb, 0xa5, 0x12, 0x18, 0x14, 0x3c, 0x35,
+	0xa0, 0x16, 0xec, 0xc4, 0x49, 0x10, 0x25, 0x81, 0x58, 0x58, 0xd5, 0xb6, 0xd6, 0xd1, 0x70, 0x7e,
+	0x6f, 0x8d, 0x60, 0x6f, 0x2d, 0x2f, 0x32, 0xc1, 0xb8, 0xa2, 0x8b, 0x4c, 0xb4, 0xf4, 0x88, 0x3e,
+	0x80, 0xf2, 0x0b, 0x12, 0xce, 0xa9, 0xa5, 0xcb, 0xbc, 0x87, 0x1b, 0x79, 0x7b, 0x6c, 0x81, 0x15,
+	0xe4, 0x0b, 0xfd, 0x73, 0xed, 0xac, 0xb4, 0xa3, 0x9b, 0x86, 0xfd, 0xbb, 0x0e, 0x8d, 0xa2, 0x2a,
+	0xe8, 0x21, 0xd4, 0xbd, 0x68, 0x3e, 0x0e, 0xa9, 0x4b, 0x12, 0x9f, 0x5b, 0x9a, 0x14, 0xe2, 0xfd,
+	0x7f, 0xd1, 0xd0, 0xf9, 0x4e, 0x42, 0x7b, 0x89, 0xbf, 0x94, 0xc3, 0xcb, 0x0d, 0x29, 0x13, 0x17,
+	0x49, 0xc0, 0x7c, 0xc5, 0xa4, 0xbf, 0x9a, 0x69, 0x24, 0xa1, 0x05, 0x26, 0x9e, 0x1b, 0x10, 0x82,
+	0x92, 0x20, 0x3e, 0xb7, 0x8c, 0xb6, 0xd1, 0xa9, 0x61, 0x79, 0x6e, 0x7d, 0x0d, 0x7b, 0x6b, 0xc9,
+	0xb7, 0x68, 0x72, 0x58, 0xd4, 0x44, 0x2b, 0x74, 0x9f, 0x86, 0xaf, 0x65, 0xbc, 0x29, 0xbc, 0x56,
+	0x08, 0xb7, 0xff, 0xd4, 0x00, 0x56, 0x63, 0x88, 0xde, 0x06, 0x98, 0x44, 0x8c, 0xd1, 0x89, 0x08,
+	0x22, 0x96, 0x31, 0x14, 0x2c, 0xa8, 0x7f, 0x6d, 0xb8, 0x4a, 0x52, 0x89, 0xa3, 0xad, 0x13, 0xfd,
+	0xaa, 0x01, 0xfb, 0x1f, 0xe7, 0xe0, 0xac, 0xb4, 0x63, 0x98, 0x25, 0xfb, 0x29, 0xec, 0x2b, 0x51,
+	0x31, 0x61, 0x3e, 0x3d, 0x09, 0x42, 0x41, 0x13, 0x74, 0x17, 0x60, 0x35, 0x11, 0x59, 0xa6, 0x5a,
+	0xfe, 0xce, 0x69, 0x05, 0x33, 0xf2, 0x32, 0x53, 0x38, 0x3d, 0x4a, 0x4b, 0xc0, 0x2c, 0x23, 0xb3,
+	0x04, 0xcc, 0x3e, 0x05, 0xa4, 0xd4, 0xee, 0xff, 0x3c, 0x27, 0x21, 0x5f, 0x11, 0xaf, 0x06, 0x64,
+	0x49, 0x9c, 0x3f, 0xfb, 0x76, 0xf5, 0xed, 0xf7, 0xc0, 0xbc, 0x24, 0xfe, 0x45, 0x42, 0x39, 0x65,
+	0x22, 0x23, 0x32, 0xc1, 0x10, 0x64, 0xc9, 0x90, 0x1e, 0xed, 0x5f, 0x0d, 0x28, 0x5d, 0x44, 0x51,
+	0x98, 0x8e, 0x0e, 0x23, 0x33, 0x9a, 0xf9, 0xe4, 0x19, 0x0d, 0xe0, 0x30, 0x6b, 0x28, 0x49, 0xdb,
+	0x74, 0xa7, 0x92, 0x65, 0x39, 0xa1, 0x6f, 0x15, 0xde, 0x65, 0x43, 0x0c, 0x8c, 0xbc, 0x75, 0x13,
+	0x47, 0x3f, 0xc0, 0xad, 0xac, 0x0f, 0x2a, 0xdb, 0xcb, 0x09, 0xd5, 0x43, 0xdf, 0x2d, 0x8e, 0xfc,
+	0x86, 0x0a, 0xf8, 0x80, 0x6f, 0xd8, 0x38, 0x7a, 0x04, 0x07, 0x82, 0xf8, 0x6e, 0xac, 0xda, 0xcc,
+	0x09, 0xd5, 0x5a, 0x7a, 0xb3, 0xb8, 0x96, 0xd6, 0xb4, 0xc0, 0xfb, 0x62, 0xcd, 0x92, 0xae, 0xb6,
+	0x5d, 0xb5, 0x68, 0x3c, 0x77, 0x4c, 0xa7, 0x51, 0xf2, 0x5f, 0x56, 0x53, 0x33, 0x8b, 0xb8, 0x2f,
+	0x03, 0xd0, 0x37, 0xb0, 0x34, 0xb8, 0x64, 0x2a, 0x68, 0x62, 0x55, 0x6f, 0x64, 0x68, 0x64, 0x01,
+	0xbd, 0x14, 0x9f, 0xcd, 0xd7, 0xdf, 0x1a, 0x34, 0x1e, 0xa7, 0x75, 0x5f, 0x24, 0xd1, 0x34, 0x08,
+	0xe9, 0xd6, 0xe7, 0x39, 0x82, 0x72, 0x1c, 0x45, 0xa1, 0xfa, 0xdc, 0xeb, 0xc7, 0x7b, 0x85, 0x6e,
+	0xd3, 0x27, 0xc5, 0xca, 0x8b, 0xbe, 0xdf, 0xb2, 0xb0, 0x8b, 0xdb, 0xa5, 0x98, 0xe7, 0xf5, 0x7d,
+	0x55, 0x25, 0xb3, 0x6c, 0xff, 0xa1, 0x43, 0x59, 0x56, 0x83, 0xee, 0xc0, 0x8e, 0x2c, 0xce, 0xcd,
+	0xff, 0x77, 0x55, 0x79, 0x3f, 0xf5, 0xd0, 0xbb, 0xd0, 0x54, 0xae, 0x58, 0x95, 0x9c, 0x4d, 0x7d,
+	0x63, 0x56, 0x94, 0xeb, 0x08, 0x76, 0x15, 0x68, 0x3a, 0x67, 0x6a, 0xd7, 0x18, 0x12, 0xa5, 0x42,
+	0x4f, 0x32, 0x23, 0xfa, 0x10, 0xaa, 0x42, 0xfe, 0xae, 0x96, 0x23, 0xb8, 0xbf, 0xf1, 0x23, 0xc3,
+	0x4b, 0x04, 0xfa, 0xf6, 0x9a, 0x8e, 0x55, 0x89, 0x6f, 0xaf, 0xeb, 0xf8, 0x3a, 0x04, 0x2c, 0x9b,
+	0x95, 0xb3, 0xd2, 0x4e, 0xc5, 0xac, 0xda, 0x7f, 0x69, 0xd0, 0xc4, 0xf4, 0x27, 0x3a, 0x11, 0xd4,
+	0xbb, 0x51, 0xc8, 0xcf, 0xa0, 0x92, 0x50, 0xc2, 0x23, 0x26, 0x33, 0xed, 0x1e, 0xdf, 0x2b, 0xf4,
+	0x72, 0x8d, 0xc4, 0xc1, 0x12, 0x86, 0x33, 0x38, 0xfa, 0x08, 0xd0, 0x24, 0x0a, 0xc3, 0xc0, 0x4b,
+	0xbf, 0xe4, 0x9c, 0x5d, 0x09, 0x6c, 0xe6, 0x9e, 0xc7, 0x2a, 0x8d, 0xfd, 0x10, 0x2a, 0x2a, 0x1e,
+	0xd5, 0xa1, 0xfa, 0x64, 0xf0, 0x68, 0x30, 0x7c, 0x36, 0x30, 0xdf, 0x40, 0x4d, 0xa8, 0x3d, 0x18,
+	0x9e, 0x9f, 0x9f, 0x8e, 0x4e, 0x87, 0x03, 0x53, 0x4b, 0xaf, 0xe7, 0xc3, 0x67, 0xee, 0xe8, 0xc1,
+	0x10, 0xf7, 0x4d, 0x1d, 0x1d, 0xc0, 0x5e, 0xff, 0x69, 0xef, 0xfc, 0x49, 0xef, 0x72, 0x88, 0xdd,
+	0x3e, 0xc6, 0x43, 0x6c, 0x1a, 0xf7, 0x9d, 0x1f, 0xdb, 0x69, 0x85, 0x1f, 0xab, 0x12, 0x3d, 0xfa,
+	0xa2, 0xbb, 0xba, 0x76, 0xe3, 0x2b, 0xbf, 0x1b, 0x8f, 0x7f, 0xd3, 0x6b, 0xc3, 0x98, 0x32, 0x99,
+	0x7b, 0x5c, 0x91, 0x92, 0x7d, 0xf2, 0xcf, 0x00, 0xfc, 0xb3, 0xbc, 0x94, 0xb6, 0x09, 0x00, 0x00,
 }
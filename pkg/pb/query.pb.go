@@ -26,12 +26,50 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+// TicketOrder is the order in which Tickets are returned by a query.
+type TicketOrder int32
+
+const (
+	// Tickets are returned in no particular order.
+	TicketOrder_UNORDERED TicketOrder = 0
+	// Tickets with the highest priority first.
+	TicketOrder_PRIORITY TicketOrder = 1
+	// Tickets with the highest effective priority first.  The effective
+	// priority is the Ticket's priority plus queryWaitTimePriority for every
+	// second since the Ticket was created, so that Tickets which waited longer
+	// are picked first among Tickets of the same priority.
+	TicketOrder_EFFECTIVE_PRIORITY TicketOrder = 2
+)
+
+var TicketOrder_name = map[int32]string{
+	0: "UNORDERED",
+	1: "PRIORITY",
+	2: "EFFECTIVE_PRIORITY",
+}
+
+var TicketOrder_value = map[string]int32{
+	"UNORDERED":          0,
+	"PRIORITY":           1,
+	"EFFECTIVE_PRIORITY": 2,
+}
+
+func (x TicketOrder) String() string {
+	return proto.EnumName(TicketOrder_name, int32(x))
+}
+
+func (TicketOrder) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_5ec7651f31a90698, []int{0}
+}
+
 type QueryTicketsRequest struct {
 	// The Pool representing the set of Filters to be queried.
-	Pool                 *Pool    `protobuf:"bytes,1,opt,name=pool,proto3" json:"pool,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Pool *Pool `protobuf:"bytes,1,opt,name=pool,proto3" json:"pool,omitempty"`
+	// The order of the returned Tickets.  Ties are broken by oldest Ticket
+	// first.
+	Order                TicketOrder `protobuf:"varint,2,opt,name=order,proto3,enum=openmatch.TicketOrder" json:"order,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
 }
 
 func (m *QueryTicketsRequest) Reset()         { *m = QueryTicketsRequest{} }
@@ -66,6 +104,13 @@ func (m *QueryTicketsRequest) GetPool() *Pool {
 	return nil
 }
 
+func (m *QueryTicketsRequest) GetOrder() TicketOrder {
+	if m != nil {
+		return m.Order
+	}
+	return TicketOrder_UNORDERED
+}
+
 type QueryTicketsResponse struct {
 	// Tickets that meet all the filtering criteria requested by the pool.
 	Tickets              []*Ticket `protobuf:"bytes,1,rep,name=tickets,proto3" json:"tickets,omitempty"`
@@ -108,10 +153,13 @@ func (m *QueryTicketsResponse) GetTickets() []*Ticket {
 
 type QueryTicketIdsRequest struct {
 	// The Pool representing the set of Filters to be queried.
-	Pool                 *Pool    `protobuf:"bytes,1,opt,name=pool,proto3" json:"pool,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Pool *Pool `protobuf:"bytes,1,opt,name=pool,proto3" json:"pool,omitempty"`
+	// The order of the returned TicketIDs.  Ties are broken by oldest Ticket
+	// first.
+	Order                TicketOrder `protobuf:"varint,2,opt,name=order,proto3,enum=openmatch.TicketOrder" json:"order,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
 }
 
 func (m *QueryTicketIdsRequest) Reset()         { *m = QueryTicketIdsRequest{} }
@@ -146,6 +194,13 @@ func (m *QueryTicketIdsRequest) GetPool() *Pool {
 	return nil
 }
 
+func (m *QueryTicketIdsRequest) GetOrder() TicketOrder {
+	if m != nil {
+		return m.Order
+	}
+	return TicketOrder_UNORDERED
+}
+
 type QueryTicketIdsResponse struct {
 	// TicketIDs that meet all the filtering criteria requested by the pool.
 	Ids                  []string `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
@@ -187,6 +242,7 @@ func (m *QueryTicketIdsResponse) GetIds() []string {
 }
 
 func init() {
+	proto.RegisterEnum("openmatch.TicketOrder", TicketOrder_name, TicketOrder_value)
 	proto.RegisterType((*QueryTicketsRequest)(nil), "openmatch.QueryTicketsRequest")
 	proto.RegisterType((*QueryTicketsResponse)(nil), "openmatch.QueryTicketsResponse")
 	proto.RegisterType((*QueryTicketIdsRequest)(nil), "openmatch.QueryTicketIdsRequest")
@@ -196,44 +252,48 @@ func init() {
 func init() { proto.RegisterFile("api/query.proto", fileDescriptor_5ec7651f31a90698) }
 
 var fileDescriptor_5ec7651f31a90698 = []byte{
-	// 577 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x93, 0xdf, 0x4e, 0x13, 0x4f,
-	0x14, 0xc7, 0xb3, 0x5b, 0x02, 0x61, 0xf8, 0xe5, 0x07, 0x8e, 0x42, 0x48, 0x63, 0x70, 0x58, 0x62,
-	0x02, 0xc5, 0xee, 0x94, 0xca, 0x55, 0xd5, 0x04, 0x04, 0x2e, 0x48, 0x8a, 0x7f, 0x8a, 0xf1, 0xc2,
-	0xbb, 0xe9, 0xec, 0x71, 0x3b, 0xd2, 0xce, 0x19, 0x66, 0x66, 0x41, 0x12, 0xaf, 0x8c, 0x4f, 0x20,
-	0x37, 0xc6, 0x47, 0xf0, 0x25, 0x7c, 0x08, 0x5f, 0xc1, 0xf8, 0x1c, 0x66, 0x77, 0x8b, 0x94, 0x7f,
-	0x26, 0x5e, 0xb5, 0x3b, 0xdf, 0xef, 0x39, 0xdf, 0xcf, 0x9c, 0x99, 0x21, 0xd3, 0xc2, 0x28, 0x7e,
-	0x98, 0x81, 0x3d, 0x89, 0x8d, 0x45, 0x8f, 0x74, 0x12, 0x0d, 0xe8, 0x81, 0xf0, 0xb2, 0x57, 0xa5,
-	0xb9, 0x36, 0x00, 0xe7, 0x44, 0x0a, 0xae, 0x94, 0xab, 0x77, 0x53, 0xc4, 0xb4, 0x0f, 0x3c, 0x97,
-	0x84, 0xd6, 0xe8, 0x85, 0x57, 0xa8, 0xcf, 0xd4, 0x07, 0xc5, 0x8f, 0xac, 0xa7, 0xa0, 0xeb, 0xee,
-	0x58, 0xa4, 0x29, 0x58, 0x8e, 0xa6, 0x70, 0x5c, 0x75, 0x47, 0x2d, 0x72, 0xfb, 0x65, 0x9e, 0xfc,
-	0x4a, 0xc9, 0x03, 0xf0, 0xae, 0x03, 0x87, 0x19, 0x38, 0x4f, 0x97, 0xc8, 0x98, 0x41, 0xec, 0xcf,
-	0x07, 0x2c, 0x58, 0x9e, 0x6a, 0x4e, 0xc7, 0x7f, 0x80, 0xe2, 0x17, 0x88, 0xfd, 0x4e, 0x21, 0x46,
-	0x5b, 0xe4, 0xce, 0xc5, 0x5a, 0x67, 0x50, 0x3b, 0xa0, 0xab, 0x64, 0xc2, 0x97, 0x4b, 0xf3, 0x01,
-	0xab, 0x2c, 0x4f, 0x35, 0x6f, 0x8d, 0xd4, 0x97, 0xe6, 0xce, 0x99, 0x23, 0x7a, 0x4c, 0x66, 0x47,
-	0x9a, 0xec, 0x26, 0xff, 0x86, 0x50, 0x23, 0x73, 0x97, 0xab, 0x87, 0x10, 0x33, 0xa4, 0xa2, 0x92,
-	0x12, 0x60, 0xb2, 0x93, 0xff, 0x6d, 0x9e, 0x86, 0xe4, 0xbf, 0xc2, 0xbc, 0x0f, 0xf6, 0x48, 0x49,
-	0xa0, 0x1f, 0x86, 0xdf, 0x43, 0x7e, 0xba, 0x30, 0x92, 0x71, 0xcd, 0x50, 0xaa, 0xf7, 0x6e, 0xd4,
-	0xcb, 0xcc, 0x68, 0xe5, 0xe3, 0x8f, 0x9f, 0xa7, 0xe1, 0x52, 0xb4, 0xc0, 0x8f, 0xd6, 0xca, 0x03,
-	0x75, 0x65, 0x14, 0x1f, 0xee, 0xb6, 0x55, 0x2c, 0xb6, 0x82, 0x5a, 0x23, 0xa0, 0x9f, 0x02, 0xf2,
-	0xff, 0x45, 0x76, 0xca, 0xae, 0x0f, 0x38, 0x1f, 0x4a, 0x75, 0xf1, 0x2f, 0x8e, 0x21, 0xc4, 0x6a,
-	0x01, 0x71, 0x3f, 0x62, 0x37, 0x40, 0xa8, 0x64, 0x14, 0xe3, 0xe9, 0x97, 0xca, 0xe7, 0xcd, 0x5f,
-	0x21, 0xfd, 0x1e, 0x90, 0xd9, 0xbd, 0x3d, 0xd6, 0xc6, 0x54, 0x49, 0xb6, 0xbc, 0x2d, 0xbc, 0x60,
-	0x6d, 0x71, 0x02, 0x76, 0x25, 0xda, 0x25, 0xe4, 0xb9, 0x01, 0xcd, 0xf6, 0xf2, 0x50, 0x3a, 0xd7,
-	0xf3, 0xde, 0xb8, 0x16, 0xe7, 0x39, 0x47, 0xbd, 0x04, 0x49, 0xe0, 0xa8, 0xba, 0x74, 0xfe, 0x5d,
-	0x4f, 0x94, 0x93, 0x99, 0x73, 0x1b, 0xe5, 0x35, 0x4d, 0x2d, 0x66, 0xc6, 0xc5, 0x12, 0x07, 0xb5,
-	0xd7, 0x84, 0x6e, 0x1a, 0x21, 0x7b, 0xc0, 0x9a, 0x71, 0x83, 0xb5, 0x95, 0x84, 0xfc, 0xa4, 0x36,
-	0xce, 0x5a, 0xa6, 0xca, 0xf7, 0xb2, 0x6e, 0xee, 0xe4, 0x65, 0xe9, 0x5b, 0xb4, 0xa9, 0x18, 0x80,
-	0x1b, 0x09, 0xe3, 0xdd, 0x3e, 0x76, 0xf9, 0x40, 0x38, 0x0f, 0x96, 0xb7, 0x77, 0xb7, 0x76, 0x9e,
-	0xed, 0xef, 0x34, 0x2b, 0x6b, 0x71, 0xa3, 0x16, 0x06, 0x61, 0x73, 0x46, 0x18, 0xd3, 0x57, 0xb2,
-	0xb8, 0xe1, 0xfc, 0x9d, 0x43, 0xdd, 0xba, 0xb2, 0xd2, 0x79, 0x44, 0x2a, 0xeb, 0x8d, 0x75, 0xba,
-	0x4e, 0x6a, 0x1d, 0xf0, 0x99, 0xd5, 0x90, 0xb0, 0xe3, 0x1e, 0x68, 0xe6, 0x7b, 0xc0, 0x2c, 0x38,
-	0xcc, 0xac, 0x04, 0x96, 0x20, 0x38, 0xa6, 0xd1, 0x33, 0x78, 0xaf, 0x9c, 0x8f, 0xe9, 0x38, 0x19,
-	0xfb, 0x1a, 0x06, 0x13, 0xf6, 0x09, 0x99, 0x3f, 0x1f, 0x06, 0xdb, 0x46, 0x99, 0x0d, 0x40, 0x97,
-	0x2f, 0x8a, 0x2e, 0x5e, 0x3f, 0x1a, 0xee, 0x94, 0x07, 0x9e, 0xa0, 0x74, 0xfc, 0x0d, 0xbb, 0x24,
-	0x8d, 0xec, 0xcb, 0x1c, 0xa4, 0xdc, 0x74, 0xbf, 0x85, 0x93, 0x79, 0xff, 0xa2, 0x7d, 0x77, 0xbc,
-	0x78, 0xa2, 0x0f, 0x7f, 0x07, 0x00, 0x00, 0xff, 0xff, 0x69, 0xc6, 0x96, 0x66, 0x20, 0x04, 0x00,
-	0x00,
+	// 644 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x54, 0xed, 0x4e, 0xd3, 0x50,
+	0x18, 0xb6, 0x1d, 0x82, 0x3b, 0x20, 0xcc, 0xa3, 0x2c, 0xcb, 0x62, 0xf0, 0x30, 0x62, 0x32, 0x06,
+	0xdb, 0x19, 0x95, 0x5f, 0x33, 0x26, 0x7c, 0xac, 0x24, 0x4b, 0x06, 0xc3, 0x82, 0x24, 0xfa, 0xc7,
+	0x74, 0xed, 0x6b, 0x5b, 0xd8, 0x7a, 0x0e, 0xe7, 0x9c, 0x82, 0x24, 0xfe, 0x32, 0x5e, 0x81, 0xfc,
+	0x31, 0x5e, 0x82, 0x37, 0xe1, 0x45, 0x78, 0x0b, 0xc6, 0xeb, 0x30, 0x6d, 0x07, 0x1b, 0x5f, 0xfe,
+	0xf3, 0x57, 0x7b, 0xde, 0xe7, 0xe9, 0xf3, 0x3c, 0x7d, 0xdf, 0xbe, 0x45, 0x33, 0x36, 0x0f, 0xe8,
+	0x71, 0x04, 0xe2, 0xac, 0xc6, 0x05, 0x53, 0x0c, 0x67, 0x19, 0x87, 0xb0, 0x6f, 0x2b, 0xc7, 0x2f,
+	0xe2, 0x18, 0xeb, 0x83, 0x94, 0xb6, 0x07, 0x32, 0x85, 0x8b, 0x4f, 0x3d, 0xc6, 0xbc, 0x1e, 0xd0,
+	0x18, 0xb2, 0xc3, 0x90, 0x29, 0x5b, 0x05, 0x2c, 0xbc, 0x40, 0x97, 0x93, 0x8b, 0x53, 0xf5, 0x20,
+	0xac, 0xca, 0x53, 0xdb, 0xf3, 0x40, 0x50, 0xc6, 0x13, 0xc6, 0x4d, 0x76, 0xc9, 0x47, 0x8f, 0x5f,
+	0xc7, 0xce, 0xfb, 0x81, 0x73, 0x04, 0x4a, 0x5a, 0x70, 0x1c, 0x81, 0x54, 0x78, 0x01, 0x8d, 0x71,
+	0xc6, 0x7a, 0x05, 0x8d, 0x68, 0xe5, 0x49, 0x63, 0xa6, 0x76, 0x19, 0xa8, 0xb6, 0xcb, 0x58, 0xcf,
+	0x4a, 0x40, 0xbc, 0x8c, 0xee, 0x33, 0xe1, 0x82, 0x28, 0xe8, 0x44, 0x2b, 0x4f, 0x1b, 0xf9, 0x11,
+	0x56, 0x2a, 0xd7, 0x89, 0x51, 0x2b, 0x25, 0x95, 0x36, 0xd1, 0x93, 0xab, 0x4e, 0x92, 0xb3, 0x50,
+	0x02, 0x5e, 0x42, 0x13, 0x2a, 0x2d, 0x15, 0x34, 0x92, 0x29, 0x4f, 0x1a, 0x8f, 0x6e, 0xe8, 0x58,
+	0x17, 0x8c, 0xd2, 0x21, 0x9a, 0x1d, 0x11, 0x69, 0xb9, 0xff, 0x33, 0x70, 0x05, 0xe5, 0xaf, 0x7b,
+	0x0d, 0x22, 0xe7, 0x50, 0x26, 0x70, 0xd3, 0xb8, 0x59, 0x2b, 0xbe, 0xad, 0x6c, 0xa0, 0xc9, 0x11,
+	0x05, 0xfc, 0x10, 0x65, 0xdf, 0xec, 0x74, 0xac, 0xa6, 0x69, 0x99, 0xcd, 0xdc, 0x3d, 0x3c, 0x85,
+	0x1e, 0xec, 0x5a, 0xad, 0x8e, 0xd5, 0xda, 0x7f, 0x9b, 0xd3, 0x70, 0x1e, 0x61, 0x73, 0x6b, 0xcb,
+	0xdc, 0xdc, 0x6f, 0x1d, 0x98, 0xef, 0x2f, 0xeb, 0xba, 0x71, 0xae, 0xa3, 0xa9, 0xc4, 0x70, 0x0f,
+	0xc4, 0x49, 0xe0, 0x00, 0xfe, 0x34, 0x38, 0x0f, 0x3a, 0x86, 0xe7, 0x46, 0xf2, 0xde, 0x32, 0xb4,
+	0xe2, 0xb3, 0x3b, 0xf1, 0x34, 0x77, 0x69, 0xf1, 0xf3, 0xaf, 0xdf, 0xe7, 0xfa, 0x42, 0x69, 0x8e,
+	0x9e, 0xac, 0xa4, 0x1f, 0x9c, 0x4c, 0xad, 0xe8, 0xa0, 0xbf, 0x8d, 0xa4, 0xd8, 0xd0, 0x2a, 0x75,
+	0x0d, 0x7f, 0xd1, 0xd0, 0xf4, 0xd5, 0xf7, 0xc7, 0xe4, 0x76, 0x83, 0xe1, 0x18, 0x8a, 0xf3, 0xff,
+	0x60, 0x0c, 0x42, 0x2c, 0x25, 0x21, 0x9e, 0x97, 0xc8, 0x1d, 0x21, 0x02, 0x77, 0x34, 0xc6, 0xc6,
+	0xb7, 0xcc, 0xd7, 0xf5, 0x3f, 0x3a, 0xfe, 0xa9, 0xa1, 0xd9, 0xed, 0x6d, 0xd2, 0x66, 0x5e, 0xe0,
+	0x90, 0x72, 0xd3, 0x56, 0x36, 0x69, 0xdb, 0x67, 0x20, 0x16, 0x4b, 0x2d, 0x84, 0x3a, 0x1c, 0x42,
+	0xb2, 0x1d, 0x9b, 0xe2, 0xbc, 0xaf, 0x14, 0x97, 0x0d, 0x4a, 0xe3, 0x1c, 0xd5, 0x34, 0x88, 0x0b,
+	0x27, 0xc5, 0x85, 0xe1, 0xb9, 0xea, 0x06, 0xd2, 0x89, 0xa4, 0x5c, 0x4b, 0xd7, 0xc8, 0x13, 0x2c,
+	0xe2, 0xb2, 0xe6, 0xb0, 0x7e, 0xe5, 0x00, 0xe1, 0x75, 0x6e, 0x3b, 0x3e, 0x10, 0xa3, 0x56, 0x27,
+	0xed, 0xc0, 0x81, 0x78, 0xda, 0x6b, 0x17, 0x92, 0x5e, 0xa0, 0xfc, 0xa8, 0x1b, 0x33, 0x69, 0xfa,
+	0xe8, 0x07, 0x26, 0x3c, 0xbb, 0x0f, 0x72, 0xc4, 0x8c, 0x76, 0x7b, 0xac, 0x4b, 0xfb, 0xb6, 0x54,
+	0x20, 0x68, 0xbb, 0xb5, 0x69, 0xee, 0xec, 0x99, 0x46, 0x66, 0xa5, 0x56, 0xaf, 0xe8, 0x9a, 0x6e,
+	0xe4, 0x6c, 0xce, 0x7b, 0x81, 0x93, 0x6c, 0x20, 0x3d, 0x94, 0x2c, 0x6c, 0xdc, 0xa8, 0x58, 0x2f,
+	0x51, 0x66, 0xb5, 0xbe, 0x8a, 0x57, 0x51, 0xc5, 0x02, 0x15, 0x89, 0x10, 0x5c, 0x72, 0xea, 0x43,
+	0x48, 0x94, 0x0f, 0x44, 0x80, 0x64, 0x91, 0x70, 0x80, 0xb8, 0x0c, 0x24, 0x09, 0x99, 0x22, 0xf0,
+	0x31, 0x90, 0xaa, 0x86, 0xc7, 0xd1, 0xd8, 0x77, 0x5d, 0x9b, 0x10, 0xaf, 0x50, 0x61, 0xd8, 0x0c,
+	0xd2, 0x64, 0x4e, 0xd4, 0x87, 0x30, 0xdd, 0x78, 0x3c, 0x7f, 0x7b, 0x6b, 0xa8, 0x0c, 0x14, 0x50,
+	0x97, 0x39, 0x92, 0xbe, 0x23, 0xd7, 0xa0, 0xe1, 0x91, 0xf2, 0x23, 0x8f, 0xf2, 0xee, 0x0f, 0x3d,
+	0x1b, 0xeb, 0x27, 0xf2, 0xdd, 0xf1, 0xe4, 0x17, 0xf2, 0xe2, 0xef, 0x00, 0x30, 0x56, 0x39, 0xd6,
+	0xc0, 0x04, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -250,13 +310,13 @@ const _ = grpc.SupportPackageIsVersion4
 type QueryServiceClient interface {
 	// QueryTickets gets a list of Tickets that match all Filters of the input Pool.
 	//   - If the Pool contains no Filters, QueryTickets will return all Tickets in the state storage.
-	// QueryTickets pages the Tickets by `storage.pool.size` and stream back responses.
-	//   - storage.pool.size is default to 1000 if not set, and has a mininum of 10 and maximum of 10000.
+	// QueryTickets pages the Tickets by `queryPageSize` and stream back responses.
+	//   - queryPageSize is default to 1000 if not set, and has a mininum of 10 and maximum of 10000.
 	QueryTickets(ctx context.Context, in *QueryTicketsRequest, opts ...grpc.CallOption) (QueryService_QueryTicketsClient, error)
 	// QueryTicketIds gets the list of TicketIDs that meet all the filtering criteria requested by the pool.
 	//   - If the Pool contains no Filters, QueryTicketIds will return all TicketIDs in the state storage.
-	// QueryTicketIds pages the TicketIDs by `storage.pool.size` and stream back responses.
-	//   - storage.pool.size is default to 1000 if not set, and has a mininum of 10 and maximum of 10000.
+	// QueryTicketIds pages the TicketIDs by `queryPageSize` and stream back responses.
+	//   - queryPageSize is default to 1000 if not set, and has a mininum of 10 and maximum of 10000.
 	QueryTicketIds(ctx context.Context, in *QueryTicketIdsRequest, opts ...grpc.CallOption) (QueryService_QueryTicketIdsClient, error)
 }
 
@@ -336,13 +396,13 @@ func (x *queryServiceQueryTicketIdsClient) Recv() (*QueryTicketIdsResponse, erro
 type QueryServiceServer interface {
 	// QueryTickets gets a list of Tickets that match all Filters of the input Pool.
 	//   - If the Pool contains no Filters, QueryTickets will return all Tickets in the state storage.
-	// QueryTickets pages the Tickets by `storage.pool.size` and stream back responses.
-	//   - storage.pool.size is default to 1000 if not set, and has a mininum of 10 and maximum of 10000.
+	// QueryTickets pages the Tickets by `queryPageSize` and stream back responses.
+	//   - queryPageSize is default to 1000 if not set, and has a mininum of 10 and maximum of 10000.
 	QueryTickets(*QueryTicketsRequest, QueryService_QueryTicketsServer) error
 	// QueryTicketIds gets the list of TicketIDs that meet all the filtering criteria requested by the pool.
 	//   - If the Pool contains no Filters, QueryTicketIds will return all TicketIDs in the state storage.
-	// QueryTicketIds pages the TicketIDs by `storage.pool.size` and stream back responses.
-	//   - storage.pool.size is default to 1000 if not set, and has a mininum of 10 and maximum of 10000.
+	// QueryTicketIds pages the TicketIDs by `queryPageSize` and stream back responses.
+	//   - queryPageSize is default to 1000 if not set, and has a mininum of 10 and maximum of 10000.
 	QueryTicketIds(*QueryTicketIdsRequest, QueryService_QueryTicketIdsServer) error
 }
 